@@ -5,13 +5,48 @@ import (
 	"dataflow/logger"
 	"dataflow/models"
 	"dataflow/services/dataFlowService"
+	"dataflow/services/graphService"
 	"dataflow/services/languageService"
 	"dataflow/services/nodeService"
+	"dataflow/services/projectService"
+	"dataflow/services/taintService"
+	"dataflow/services/variableService"
 	"fmt"
-	"log"
 	"os"
+
+	sitter "github.com/smacker/go-tree-sitter"
 )
 
+// Analyzer runs the analyses of a configuration with its own logger and crawler sessions, so that analyzers can run concurrently
+type Analyzer struct {
+	config      models.Config
+	logger      *logger.Logger
+	edges       []models.DataFlowEdge
+	content     []byte                   // Content of the file, of which the parsed source is only the code of the language in a template
+	notebook    *models.Notebook         // Notebook whose virtual module is analyzed, nil for the other files
+	diagnostics []models.ParseDiagnostic // Syntax errors of the analyzed file, found by the last run
+	project     *models.Project          // Project of the last run in project mode, shared by the analyses of its variables
+	startFile   *models.SourceFile       // File of the project the last run starts in
+}
+
+// -----------------------------------------------------------------------------
+// NewAnalyzer - Creates an analyzer for a configuration
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - config (models.Config): Configuration settings for the data flow analysis.
+//
+// Returns:
+//   - (*Analyzer): The analyzer, logging with the verbosity of the configuration.
+//
+// -----------------------------------------------------------------------------
+func NewAnalyzer(config models.Config) *Analyzer {
+	return &Analyzer{
+		config: config,
+		logger: newLogger(config),
+	}
+}
+
 // -----------------------------------------------------------------------------
 // RunDataflowAnalysis - Runs data flow analysis based on the provided configuration
 // -----------------------------------------------------------------------------
@@ -25,77 +60,312 @@ import (
 //
 // -----------------------------------------------------------------------------
 func RunDataflowAnalysis(config models.Config) ([]models.DataFlow, error) {
-	// Logger configuration
-	logger.Setup(
-		func(format string, v ...interface{}) {
-			if config.Verbose {
-				log.Printf("[INFO] "+format+"\n", v...)
-			}
-		},
-		func(format string, v ...interface{}) {
-			if config.Verbose {
-				log.Printf("[WARNING] "+format+"\n", v...)
-			}
-		},
-		func(format string, v ...interface{}) {
-			log.Printf("[ERROR] "+format+"\n", v...)
-		},
-		func(format string, v ...interface{}) {
-			if config.Debug {
-				log.Printf("[DEBUG] "+format+"\n", v...)
-			}
-		},
-	)
+	dataflow, _, err := NewAnalyzer(config).Run()
+	return dataflow, err
+}
+
+// -----------------------------------------------------------------------------
+// RunDataflowAnalysisWithSteps - Runs data flow analysis and also returns the raw steps of the crawler
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - config (models.Config): Configuration settings for the data flow analysis.
+//
+// Returns:
+//   - ([]models.DataFlow): A slice of DataFlow models representing the result of the analysis.
+//   - ([]models.DataFlowStep): The deduplicated steps found by the crawler, before the code snippets are added.
+//   - (error): An error object if an error occurred during the analysis.
+//
+// -----------------------------------------------------------------------------
+func RunDataflowAnalysisWithSteps(config models.Config) ([]models.DataFlow, []models.DataFlowStep, error) {
+	return NewAnalyzer(config).Run()
+}
 
-	dataflowInitial := dataFlowService.CreateDataflowInitial(config)
+// -----------------------------------------------------------------------------
+// Run - Runs the data flow analysis of the configuration
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - None
+//
+// Returns:
+//   - ([]models.DataFlow): A slice of DataFlow models representing the result of the analysis.
+//   - ([]models.DataFlowStep): The deduplicated steps found by the crawler, before the code snippets are added.
+//   - (error): An error object if an error occurred during the analysis.
+//
+// -----------------------------------------------------------------------------
+func (a *Analyzer) Run() ([]models.DataFlow, []models.DataFlowStep, error) {
+	a.edges = nil
+	a.diagnostics = nil
+	if err := a.resolveLanguage(); err != nil {
+		return nil, nil, err
+	}
+	if err := a.loadProject(); err != nil {
+		return nil, nil, err
+	}
 
-	// Check if the variable is defined
-	if config.Variable == "" {
-		logger.PrintError("Variable is not defined in config")
-		return dataflowInitial, nil
+	// Without a variable, every variable of the start line is traced
+	if a.config.Variable == "" {
+		return a.runDetectedVariables()
 	}
 
-	// Set the global language
-	models.GlobalLanguage = config.Language
-	logger.PrintDebug("Global language set to: %s", config.Language)
+	return a.runVariable(a.config)
+}
 
-	// Read the file content
-	content, err := os.ReadFile(config.FilePath)
+// -----------------------------------------------------------------------------
+// DetectVariables - Lists the identifiers of the start line, classified as variables, functions, fields, modules or literals
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - None
+//
+// Returns:
+//   - ([]models.IdentifyVariableResponse): The candidates of the start line, in the order they appear.
+//   - (error): An error object if the file could not be read or parsed.
+//
+// -----------------------------------------------------------------------------
+func (a *Analyzer) DetectVariables() ([]models.IdentifyVariableResponse, error) {
+	if err := a.resolveLanguage(); err != nil {
+		return nil, err
+	}
+
+	content, tree, err := a.parseSource()
 	if err != nil {
-		logger.PrintError("error reading file: %v", err)
-		return nil, fmt.Errorf("error reading file: %v", err)
+		return nil, err
 	}
 
-	// Parse the content into a syntax tree
-	tree := languageService.ParseContent(content, config.Language)
-	if tree == nil {
-		logger.PrintError("failed to parse the file into a syntax tree")
-		return nil, fmt.Errorf("failed to parse the file into a syntax tree")
+	return variableService.DetectVariables(a.config.Language, tree.RootNode(), content, uint32(a.config.StartLine), a.logger), nil
+}
+
+// -----------------------------------------------------------------------------
+// Graph - Builds the graph of the data flow computed by the last run of the analyzer
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - steps ([]models.DataFlowStep): The steps returned by Run.
+//
+// Returns:
+//   - (models.DataFlowGraph): The graph of the data flow, with the edges recorded by the crawler.
+//
+// -----------------------------------------------------------------------------
+func (a *Analyzer) Graph(steps []models.DataFlowStep) models.DataFlowGraph {
+	graph := graphService.BuildGraph(a.config.Variable, a.config.FilePath, steps, a.edges)
+	a.logger.PrintDebug("Data flow graph built: %d nodes, %d edges", len(graph.Nodes), len(graph.Edges))
+	return graph
+}
+
+// -----------------------------------------------------------------------------
+// Diagnostics - Returns the syntax errors found in the file by the last run of the analyzer
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - None
+//
+// Returns:
+//   - ([]models.ParseDiagnostic): The regions the parser could not recognize and the elements it had to insert.
+//
+// -----------------------------------------------------------------------------
+func (a *Analyzer) Diagnostics() []models.ParseDiagnostic {
+	return a.diagnostics
+}
+
+// -----------------------------------------------------------------------------
+// resolveLanguage - Replaces the language of the configuration by its registered name, detected from the file (or the template region of the start line) when it is not given
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - None
+//
+// Returns:
+//   - (error): An error object if the language is not supported or could not be detected.
+//
+// -----------------------------------------------------------------------------
+func (a *Analyzer) resolveLanguage() error {
+	language, err := languageService.ResolveLanguageAt(a.config.Language, a.config.FilePath, a.config.Content, a.config.StartLine)
+	if err != nil {
+		return err
+	}
+
+	a.config.Language = language
+
+	// A notebook is analyzed as the virtual module of its code cells
+	if a.notebook == nil && languageService.IsNotebookFile(a.config.FilePath) {
+		return a.loadNotebook()
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// loadNotebook - Replaces the notebook of the configuration by the virtual module of its code cells, and its start line by the line of the module
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - None
+//
+// Returns:
+//   - (error): An error object if the notebook could not be read or the start line is not in one of its code cells.
+//
+// -----------------------------------------------------------------------------
+func (a *Analyzer) loadNotebook() error {
+	content := a.config.Content
+	if len(content) == 0 {
+		var err error
+		content, err = os.ReadFile(a.config.FilePath)
+		if err != nil {
+			return fmt.Errorf("error reading file: %v", err)
+		}
+	}
+
+	// The errors are reported once, by the caller of the analysis
+	notebook, err := languageService.LoadNotebook(content)
+	if err != nil {
+		return err
+	}
+	line, err := languageService.GetNotebookModuleLine(notebook, a.config.Cell, a.config.StartLine)
+	if err != nil {
+		return err
+	}
+	a.logger.PrintDebug("line %d of cell %d is the line %d of the notebook module", a.config.StartLine, a.config.Cell, line)
+
+	a.notebook = notebook
+	a.config.Content = notebook.Content
+	a.config.StartLine = line
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// loadProject - Loads the project of the configuration once for the analyses of the run, the content given for the start file replacing the file on disk
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - None
+//
+// Returns:
+//   - (error): An error object if the project could not be loaded or the file is not part of it.
+//
+// -----------------------------------------------------------------------------
+func (a *Analyzer) loadProject() error {
+	a.project, a.startFile = nil, nil
+	if a.config.ProjectRoot == "" {
+		return nil
+	}
+
+	project, err := projectService.LoadProject(a.config.ProjectRoot, a.logger)
+	if err != nil {
+		return fmt.Errorf("error loading project: %v", err)
+	}
+
+	startFile := projectService.FindFile(project, a.config.FilePath)
+	if startFile == nil {
+		return fmt.Errorf("file '%s' is not part of the project '%s'", a.config.FilePath, a.config.ProjectRoot)
+	}
+	if startFile.Language != a.config.Language {
+		// The project files are parsed in the language of their extension, not in the language of a template region
+		return fmt.Errorf("the %s code of '%s' cannot be analyzed in project mode", a.config.Language, a.config.FilePath)
+	}
+
+	// The content given by the caller (e.g. the unsaved buffer of an editor) is analyzed instead of the file on disk
+	if len(a.config.Content) > 0 {
+		if err := projectService.SetFileContent(project, startFile, a.config.Content, a.logger); err != nil {
+			return fmt.Errorf("error parsing '%s': %v", a.config.FilePath, err)
+		}
+	}
+
+	a.project, a.startFile = project, startFile
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// runVariable - Runs the data flow analysis of one variable in a new crawler session
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - config (models.Config): Configuration settings with the variable to trace.
+//
+// Returns:
+//   - ([]models.DataFlow): A slice of DataFlow models representing the result of the analysis.
+//   - ([]models.DataFlowStep): The deduplicated steps found by the crawler, before the code snippets are added.
+//   - (error): An error object if an error occurred during the analysis.
+//
+// -----------------------------------------------------------------------------
+func (a *Analyzer) runVariable(config models.Config) ([]models.DataFlow, []models.DataFlowStep, error) {
+	// Check the direction of the analysis
+	if config.Direction != "" && config.Direction != models.DirectionBackward && config.Direction != models.DirectionForward {
+		return nil, nil, fmt.Errorf("unsupported direction: %s", config.Direction)
 	}
 
-	logger.PrintDebug("variable selected: %s", config.Variable)
+	project, startFile := a.project, a.startFile
+	var content, fileContent []byte
+	var tree *sitter.Tree
+	if startFile != nil {
+		content = startFile.Content
+		tree = startFile.Tree
+	} else {
+		var err error
+		content, tree, err = a.parseSource()
+		if err != nil {
+			return nil, nil, err
+		}
+		fileContent = a.content
+	}
 
-	// Variables to track
+	// The steps in a region the parser could not recognize are unreliable
+	startPath := config.FilePath
+	if startFile != nil {
+		startPath = startFile.Path
+	}
+	diagnostics := languageService.GetParseDiagnostics(tree.RootNode(), content)
+	if len(diagnostics) > 0 {
+		a.logger.PrintWarning("%d parse errors in %s, the first one at line %d", len(diagnostics), startPath, diagnostics[0].Line)
+		if config.FailOnParseError {
+			return nil, nil, fmt.Errorf("syntax error in %s at line %d: %s", startPath, diagnostics[0].Line, diagnostics[0].Text)
+		}
+	}
+
+	a.logger.PrintDebug("variable selected: %s", config.Variable)
+
+	// Variables to track, a field or a key being written the way the analysis builds the access paths (e.g. $req->path as $req.path) and cut to the field depth
+	config.Variable = nodeService.TruncateAccessPath(nodeService.NormalizeAccessPath(config.Variable), fieldDepth(config))
 	variablesToTrack := map[string]bool{config.Variable: true}
 
 	// Analyze the variable in the function
 	root := tree.RootNode()
-	startingFunction := nodeService.FindFunctionByLine(root, uint32(config.StartLine), config.Language)
+	startingFunction := nodeService.FindFunctionByLine(config.Language, root, uint32(config.StartLine))
 	if startingFunction == nil {
-		return dataflowInitial, nil
+		// Outside the functions, the analysis runs in the module scope (e.g. the body of a shell script)
+		startingFunction = root
 	}
 
-	visitedLines := make(map[uint32]bool)
-	visitedFunctions := make(map[string]*models.VisitInfo)
+	// The session holds the visited lines and functions, and enables (or disables) the cross-file analysis
+	session := crawler.NewSession(config.Language, a.logger, project, startFile, fieldDepth(config))
 
-	// Start data flow analysis
-	result := crawler.CrawlFromLine(root, startingFunction, content, variablesToTrack, uint32(config.StartLine), true, visitedLines, visitedFunctions)
+	// Start data flow analysis, backward from a sink by default or forward from a source
+	forward := config.Direction == models.DirectionForward
+	result := session.Crawl(root, startingFunction, content, variablesToTrack, uint32(config.StartLine), !forward)
+	edges := session.Edges()
+	if a.notebook != nil {
+		edges = dataFlowService.MapNotebookEdges(edges, a.notebook)
+	}
+	a.edges = append(a.edges, edges...)
+
+	// The forward flow always starts with the use of the variable on the start line
+	if forward {
+		result = append([]models.DataFlowStep{{
+			Line:     uint32(config.StartLine),
+			Type:     "Use of variable",
+			Function: nodeService.FindParentFunction(config.Language, startingFunction, content),
+			Value:    config.Variable,
+			Variable: config.Variable,
+		}}, result...)
+	}
 
 	// delete duplicate steps
-	result = dataFlowService.RemoveDuplicateDataFlowStep(result, uint32(config.StartLine), config.Variable)
+	result = dataFlowService.RemoveDuplicateDataFlowStep(result, uint32(config.StartLine), config.Variable, a.logger)
 
-	// Add a verification step for the global variable
-	result = nodeService.AddGlobalVariableSteps(result, root, content, uint32(config.StartLine))
+	// Add a verification step for the global variable (only the backward flow looks for origins)
+	if !forward {
+		result = nodeService.AddGlobalVariableSteps(config.Language, result, root, content, uint32(config.StartLine), a.logger)
+	}
 
 	// Print the data flow
 	if config.Verbose {
@@ -103,7 +373,239 @@ func RunDataflowAnalysis(config models.Config) ([]models.DataFlow, error) {
 	}
 
 	// Create the data flow model
-	dataflow := dataFlowService.CreateDataflow(result, content, config.StartLine, config.Language, config.FilePath, config.Variable)
+	var dataflow []models.DataFlow
+	if project != nil {
+		dataflow = dataFlowService.CreateProjectDataflow(result, project, config.StartLine, config.Language, startFile.Path, config.Variable)
+	} else {
+		// The code snippets are the lines of the file, with the HTML around the code of a template
+		dataflow = dataFlowService.CreateDataflow(result, fileContent, config.StartLine, config.Language, config.FilePath, config.Variable)
+	}
+
+	dataflow = dataFlowService.FlagParseErrors(dataflow, diagnostics, startPath)
+
+	// The steps of a notebook are reported at their cell and their line in the cell
+	if a.notebook != nil {
+		dataflow = dataFlowService.MapNotebookDataflow(dataflow, a.notebook)
+		result = dataFlowService.MapNotebookSteps(result, a.notebook)
+		diagnostics = dataFlowService.MapNotebookDiagnostics(diagnostics, a.notebook)
+	}
+	a.diagnostics = diagnostics
+
+	return dataflow, result, nil
+}
+
+// -----------------------------------------------------------------------------
+// runDetectedVariables - Traces each variable detected on the start line when the configuration has no variable
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - None
+//
+// Returns:
+//   - ([]models.DataFlow): The data flows of the detected variables, one after the other, or the data flow of the start line when no variable is found.
+//   - ([]models.DataFlowStep): The steps of the detected variables, one after the other.
+//   - (error): An error object if an error occurred during the analysis.
+//
+// -----------------------------------------------------------------------------
+func (a *Analyzer) runDetectedVariables() ([]models.DataFlow, []models.DataFlowStep, error) {
+	candidates, err := a.DetectVariables()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	variables := variableService.GetVariableNames(candidates)
+	if len(variables) == 0 {
+		a.logger.PrintError("Variable is not defined in config and no variable was found at line %d", a.config.StartLine)
+		dataflowInitial := dataFlowService.CreateDataflowInitial(a.config)
+		if a.notebook != nil {
+			dataflowInitial = dataFlowService.MapNotebookDataflow(dataflowInitial, a.notebook)
+		}
+		return dataflowInitial, nil, nil
+	}
+	a.logger.PrintInfo("Variables detected at line %d: %v", a.config.StartLine, variables)
+
+	var dataflow []models.DataFlow
+	var steps []models.DataFlowStep
+	for _, variable := range variables {
+		variableConfig := a.config
+		variableConfig.Variable = variable
+
+		variableDataflow, variableSteps, err := a.runVariable(variableConfig)
+		if err != nil {
+			return nil, nil, err
+		}
+		dataflow = append(dataflow, variableDataflow...)
+		steps = append(steps, variableSteps...)
+	}
+
+	return dataflow, steps, nil
+}
+
+// -----------------------------------------------------------------------------
+// parseSource - Reads the source code of the analysis and parses it into a syntax tree
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - None
+//
+// Returns:
+//   - ([]byte): The source code, reduced to the code of the language in a template (at the lines and columns it has in the file).
+//   - (*sitter.Tree): The syntax tree of the source code.
+//   - (error): An error object if the file could not be read or parsed.
+//
+// -----------------------------------------------------------------------------
+func (a *Analyzer) parseSource() ([]byte, *sitter.Tree, error) {
+	// Read the file content, unless it is given by the caller
+	content := a.config.Content
+	if len(content) == 0 {
+		var err error
+		content, err = os.ReadFile(a.config.FilePath)
+		if err != nil {
+			return nil, nil, fmt.Errorf("error reading file: %v", err)
+		}
+	}
+
+	a.content = content
+
+	// In a template, only the regions of the language are parsed, the rest of the file is blanked out
+	source := content
+	if embedded := languageService.ExtractEmbeddedSource(a.config.FilePath, content, a.config.Language); embedded != nil {
+		a.logger.PrintDebug("analyzing the %s code embedded in %s", a.config.Language, a.config.FilePath)
+		source = embedded
+	}
+
+	// Parse the source code into a syntax tree
+	a.logger.PrintDebug("parsing %s as %s", a.config.FilePath, a.config.Language)
+	tree := languageService.ParseContent(source, a.config.Language)
+	if tree == nil {
+		return nil, nil, fmt.Errorf("failed to parse the file into a syntax tree")
+	}
+
+	return source, tree, nil
+}
+
+// -----------------------------------------------------------------------------
+// RunTaintAnalysis - Runs data flow analysis and classifies the result with the taint rules
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - config (models.Config): Configuration settings for the data flow analysis.
+//
+// Returns:
+//   - (models.TaintReport): The data flow annotated with its sources, sinks and sanitizers, and its taint status.
+//   - (error): An error object if an error occurred during the analysis.
+//
+// -----------------------------------------------------------------------------
+func RunTaintAnalysis(config models.Config) (models.TaintReport, error) {
+	dataflow, err := RunDataflowAnalysis(config)
+	if err != nil {
+		return models.TaintReport{}, err
+	}
+
+	return ApplyTaintRules(config, dataflow)
+}
+
+// -----------------------------------------------------------------------------
+// ApplyTaintRules - Classifies an already computed data flow with the taint rules of the configuration
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - config (models.Config): Configuration settings of the analysis that produced the data flow.
+//   - dataflow ([]models.DataFlow): The data flow to classify.
+//
+// Returns:
+//   - (models.TaintReport): The data flow annotated with its sources, sinks and sanitizers, and its taint status.
+//   - (error): An error object if the taint rules could not be loaded.
+//
+// -----------------------------------------------------------------------------
+func ApplyTaintRules(config models.Config, dataflow []models.DataFlow) (models.TaintReport, error) {
+	language, err := languageService.ResolveLanguageAt(config.Language, config.FilePath, config.Content, config.StartLine)
+	if err != nil {
+		return models.TaintReport{}, err
+	}
+
+	log := newLogger(config)
+	rules, err := taintService.LoadRules(language, config.RulesFile, log)
+	if err != nil {
+		return models.TaintReport{}, err
+	}
+
+	// The content given by the caller replaces the file on the disk
+	contents := make(map[string][]byte)
+	if len(config.Content) > 0 {
+		contents[config.FilePath] = config.Content
+	}
+	return taintService.AnalyzeDataflow(dataflow, rules, config.Variable, contents, log), nil
+}
+
+// -----------------------------------------------------------------------------
+// DetectVariables - Lists the identifiers of the start line, classified as variables, functions, fields, modules or literals
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - config (models.Config): Configuration settings with the file, the language and the start line.
+//
+// Returns:
+//   - ([]models.IdentifyVariableResponse): The candidates of the start line, in the order they appear.
+//   - (error): An error object if the file could not be read or parsed.
+//
+// -----------------------------------------------------------------------------
+func DetectVariables(config models.Config) ([]models.IdentifyVariableResponse, error) {
+	return NewAnalyzer(config).DetectVariables()
+}
 
-	return dataflow, nil
+// -----------------------------------------------------------------------------
+// newLogger - Creates the logger of an analysis with the verbosity of its configuration, writing to the output of the process logger
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - config (models.Config): Configuration settings with the verbose and debug options.
+//
+// Returns:
+//   - (*logger.Logger): The logger of the analysis.
+//
+// -----------------------------------------------------------------------------
+func newLogger(config models.Config) *logger.Logger {
+	return logger.New(
+		func(format string, v ...interface{}) {
+			if config.Verbose {
+				logger.Output("[INFO] "+format, v...)
+			}
+		},
+		func(format string, v ...interface{}) {
+			if config.Verbose {
+				logger.Output("[WARNING] "+format, v...)
+			}
+		},
+		func(format string, v ...interface{}) {
+			logger.Output("[ERROR] "+format, v...)
+		},
+		func(format string, v ...interface{}) {
+			if config.Debug {
+				logger.Output("[DEBUG] "+format, v...)
+			}
+		},
+	)
+}
+
+// -----------------------------------------------------------------------------
+// fieldDepth - Returns the number of fields and keys kept in the access paths tracked by an analysis
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - config (models.Config): Configuration settings with the field depth.
+//
+// Returns:
+//   - (int): The depth of the configuration, models.DefaultFieldDepth when it is not set, 0 to track whole objects when it is 0 or negative.
+//
+// -----------------------------------------------------------------------------
+func fieldDepth(config models.Config) int {
+	switch {
+	case config.FieldDepth == nil:
+		return models.DefaultFieldDepth
+	case *config.FieldDepth < 0:
+		return 0
+	default:
+		return *config.FieldDepth
+	}
 }
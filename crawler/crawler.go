@@ -1,16 +1,16 @@
 package crawler
 
 import (
-	"dataflow/logger"
 	"dataflow/models"
 	"dataflow/services/nodeService"
+	"dataflow/services/projectService"
 	"dataflow/services/utilityService"
+	"sort"
+	"strings"
 
 	sitter "github.com/smacker/go-tree-sitter"
 )
 
-var visitedFunctionStack []string
-
 // -----------------------------------------------------------------------------
 // CrawlFromLine - Performs data flow analysis starting from a specific line.
 // -----------------------------------------------------------------------------
@@ -29,7 +29,7 @@ var visitedFunctionStack []string
 //   - ([]models.DataFlowStep): A slice of data flow steps identified during the analysis.
 //
 // -----------------------------------------------------------------------------
-func CrawlFromLine(
+func (s *Session) CrawlFromLine(
 	root,
 	node *sitter.Node,
 	content []byte,
@@ -41,32 +41,60 @@ func CrawlFromLine(
 ) []models.DataFlowStep {
 	var dataFlow []models.DataFlowStep
 
-	functionStart, functionEnd := nodeService.FindFunctionBounds(root, node, startLine)
-	var line uint32
-	if startFromEnd {
-		line = startLine
-		logger.PrintInfo("Starting analysis from line %d.", line)
-	}
+	functionStart, functionEnd := nodeService.FindFunctionBounds(s.language, root, node, startLine)
+	moduleScope := nodeService.FindFunctionByLine(s.language, root, startLine) == nil
+	line := startLine
+	s.logger.PrintInfo("Starting analysis from line %d.", line)
 
 	step := int32(-1) // Backward analysis by default
+	if !startFromEnd {
+		step = 1 // Forward analysis, from the start line to the end of the function
+	}
 
 	for (startFromEnd && line >= functionStart) || (!startFromEnd && line <= functionEnd) { //
 		if visitedLines[line] {
-			logger.PrintDebug("Line %d already analyzed. Skipping to the next line.", line)
+			s.logger.PrintDebug("Line %d already analyzed. Skipping to the next line.", line)
 			line = uint32(int32(line) + step)
 			continue
 		}
 
-		logger.PrintDebug("Analyzing line %d.", line)
-		currentNode := nodeService.FindNodeAtLine(root, line)
+		// The module scope of a script goes around the functions, which are entered through their calls only
+		if moduleScope {
+			if function := nodeService.FindFunctionByLine(s.language, root, line); function != nil {
+				s.logger.PrintDebug("Line %d is inside a function. Skipping the function.", line)
+				if startFromEnd {
+					line = function.StartPoint().Row
+				} else {
+					line = function.EndPoint().Row + 2
+				}
+				continue
+			}
+		}
+
+		s.logger.PrintDebug("Analyzing line %d.", line)
+		currentNode := nodeService.FindNodeAtLine(s.language, root, line)
 		if currentNode != nil {
-			logger.PrintDebug("Node of type '%s' found at line %d.", currentNode.Type(), line)
-			for variable := range variablesToTrack {
-				logger.PrintDebug("Analyzing node for variable '%s' at line %d.", variable, line)
-				dataFlow = append(dataFlow, analyzeNode(root, currentNode, content, variable, visitedLines, visitedFunctions, variablesToTrack, startLine)...)
+			s.logger.PrintDebug("Node of type '%s' found at line %d.", currentNode.Type(), line)
+			// The variables found on the line are analyzed on the same line, until the tracked set stops growing
+			analyzed := make(map[string]bool)
+			for pending := true; pending; {
+				pending = false
+				for _, variable := range s.lineVariables(currentNode, content, variablesToTrack, startFromEnd) {
+					if analyzed[variable] || !variablesToTrack[variable] {
+						continue
+					}
+					analyzed[variable] = true
+					pending = true
+					s.logger.PrintDebug("Analyzing node for variable '%s' at line %d.", variable, line)
+					if startFromEnd {
+						dataFlow = append(dataFlow, s.analyzeNode(root, currentNode, content, variable, visitedLines, visitedFunctions, variablesToTrack, startLine)...)
+					} else {
+						dataFlow = append(dataFlow, s.analyzeNodeForward(root, currentNode, content, variable, visitedLines, visitedFunctions, variablesToTrack, startLine)...)
+					}
+				}
 			}
 		} else {
-			logger.PrintWarning("No node found at line %d.", line)
+			s.logger.PrintWarning("No node found at line %d.", line)
 		}
 
 		visitedLines[line] = true
@@ -78,14 +106,570 @@ func CrawlFromLine(
 		if step.Value != "" {
 			filteredDataFlow = append(filteredDataFlow, step)
 		} else {
-			logger.PrintInfo("Skipping data flow step with empty value at line %d.", step.Line)
+			s.logger.PrintInfo("Skipping data flow step with empty value at line %d.", step.Line)
+		}
+	}
+
+	// Attach the current file to the steps found in it
+	if s.currentFile != nil {
+		for i := range filteredDataFlow {
+			if filteredDataFlow[i].FilePath == "" {
+				filteredDataFlow[i].FilePath = s.currentFile.Path
+			}
 		}
 	}
 
-	logger.PrintInfo("Data flow analysis complete for variable '%s'.", variablesToTrack)
+	s.logger.PrintInfo("Data flow analysis complete for variable '%s'.", variablesToTrack)
 	return filteredDataFlow
 }
 
+// -----------------------------------------------------------------------------
+// crawlInFile - Performs data flow analysis starting from a line of another project file.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - file (*models.SourceFile): The project file to analyze.
+//   - node (*sitter.Node): The node where the analysis starts.
+//   - variablesToTrack (map[string]bool): A map of variables to track during the analysis.
+//   - startLine (uint32): The line number to start the analysis from.
+//   - startFromEnd (bool): Flag indicating whether the analysis goes backward.
+//   - visitedFunctions (map[string]*models.VisitInfo): A map of functions that have already been visited.
+//
+// Returns:
+//   - ([]models.DataFlowStep): A slice of data flow steps identified in the file.
+//
+// -----------------------------------------------------------------------------
+func (s *Session) crawlInFile(
+	file *models.SourceFile,
+	node *sitter.Node,
+	variablesToTrack map[string]bool,
+	startLine uint32,
+	startFromEnd bool,
+	visitedFunctions map[string]*models.VisitInfo,
+) []models.DataFlowStep {
+	if s.projectVisitedLines[file] == nil {
+		s.projectVisitedLines[file] = make(map[uint32]bool)
+	}
+
+	// The file is crawled in its own language
+	previousFile, previousLanguage := s.currentFile, s.language
+	s.currentFile, s.language = file, file.Language
+	s.logger.PrintInfo("Entering file '%s' at line %d", file.Path, startLine)
+
+	dataFlow := s.CrawlFromLine(file.Root, node, file.Content, variablesToTrack, startLine, startFromEnd, s.projectVisitedLines[file], visitedFunctions)
+
+	s.currentFile, s.language = previousFile, previousLanguage
+	return dataFlow
+}
+
+// -----------------------------------------------------------------------------
+// lineVariables - Orders the tracked variables to analyze on a line.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - node (*sitter.Node): The node of the line.
+//   - content ([]byte): The content of the source code.
+//   - variablesToTrack (map[string]bool): The tracked variables.
+//   - startFromEnd (bool): True for the backward analysis.
+//
+// Returns:
+//   - ([]string): The tracked variables by name, the variables assigned on the line first in the backward analysis: the
+//     value of full in full := filepath.Join(base, clean) leads to clean, base being only read by the line.
+//
+// -----------------------------------------------------------------------------
+func (s *Session) lineVariables(node *sitter.Node, content []byte, variablesToTrack map[string]bool, startFromEnd bool) []string {
+	variables := utilityService.SortedKeys(variablesToTrack)
+	if !startFromEnd {
+		return variables
+	}
+
+	assigned := make(map[string]bool)
+	for _, variable := range variables {
+		assigned[variable] = nodeService.IsAssignedVariable(s.language, node, content, variable)
+	}
+	sort.SliceStable(variables, func(i, j int) bool {
+		return assigned[variables[i]] && !assigned[variables[j]]
+	})
+	return variables
+}
+
+// -----------------------------------------------------------------------------
+// isValidVariableToTrack - Verifies that a name is not a function declared in the file or in the project.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - root (*sitter.Node): The root node of the syntax tree.
+//   - variable (string): The variable name to check.
+//   - content ([]byte): The content of the source code.
+//
+// Returns:
+//   - (bool): True if the name can be tracked as a variable, false otherwise.
+//
+// -----------------------------------------------------------------------------
+func (s *Session) isValidVariableToTrack(root *sitter.Node, variable string, content []byte) bool {
+	if !nodeService.IsValidVariableToTrack(s.language, root, variable, content) {
+		return false
+	}
+	return s.project == nil || len(s.project.Functions[variable]) == 0
+}
+
+// -----------------------------------------------------------------------------
+// mapVariablesToCallSite - Maps the tracked parameters of a function to the arguments of one of its call sites.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - callNode (*sitter.Node): The function call node.
+//   - callContent ([]byte): The content of the file containing the call.
+//   - callFile (*models.SourceFile): The project file containing the call, or nil for the current file.
+//   - functionName (string): The name of the function.
+//   - functionNode (*sitter.Node): The function declaration node.
+//   - functionContent ([]byte): The content of the file containing the function.
+//   - functionFile (*models.SourceFile): The project file containing the function, or nil for the current file.
+//   - variablesToTrack (map[string]bool): The variables tracked inside the function.
+//   - callLine (uint32): The line of the call site.
+//
+// Returns:
+//   - (map[string]bool): The variables to track at the call site.
+//   - (bool): True if at least one variable was mapped.
+//   - ([]models.DataFlowStep): The arguments passed to the tracked parameters, the parameters on the declaration line of
+//     the function and the default values of those the call leaves out.
+//
+// -----------------------------------------------------------------------------
+func (s *Session) mapVariablesToCallSite(
+	callNode *sitter.Node,
+	callContent []byte,
+	callFile *models.SourceFile,
+	functionName string,
+	functionNode *sitter.Node,
+	functionContent []byte,
+	functionFile *models.SourceFile,
+	variablesToTrack map[string]bool,
+	callLine uint32,
+) (map[string]bool, bool, []models.DataFlowStep) {
+	newVariablesToTrack := make(map[string]bool)
+	variableMapped := false
+	var callSiteSteps []models.DataFlowStep
+	functionLine := functionNode.StartPoint().Row + 1
+
+	s.logger.PrintInfo("Starting variable mapping at call site line %d", callLine)
+	for _, varName := range utilityService.SortedKeys(variablesToTrack) {
+		s.logger.PrintDebug("Analyzing variable '%s' at call site line %d", varName, callLine)
+
+		argVariable := s.accessPath(nodeService.GetArgumentVariableFromContents(s.language, callNode, callContent, varName, functionNode, functionContent))
+
+		s.logger.PrintDebug("Argument '%s' of the call site for the variable '%s'", argVariable, varName)
+
+		if argVariable != "" {
+			argumentStep := models.DataFlowStep{
+				Line:     callLine,
+				Type:     "Function parameters",
+				Method:   functionName,
+				Function: nodeService.FindParentFunction(s.language, callNode, callContent),
+				Value:    argVariable,
+				Variable: argVariable,
+			}
+			if callFile != nil {
+				argumentStep.FilePath = callFile.Path
+			}
+			callSiteSteps = append(callSiteSteps, argumentStep)
+			callSiteSteps = append(callSiteSteps, s.reportParameter(functionLine, functionName, varName, functionFile)...)
+			s.recordEdge(argumentStep, parameterStep(functionLine, functionName, varName, functionFile), models.EdgeKindArgument)
+			s.trackOccurrences(callNode, callContent, argVariable)
+
+			if argVariable != varName {
+				s.logger.PrintInfo("Tracking variable '%s' as '%s' at call site line %d", varName, argVariable, callLine)
+				newVariablesToTrack[argVariable] = true
+				s.logger.PrintDebug("Mapped variable '%s' to '%s' at call site line %d", varName, argVariable, callLine)
+				variableMapped = true
+			} else {
+				s.logger.PrintDebug("No mapping needed for variable '%s' at call site line %d", varName, callLine)
+				newVariablesToTrack[varName] = true
+				variableMapped = true
+			}
+		} else if defaultValue := nodeService.GetParameterDefaultValue(s.language, callNode, callContent, varName, functionNode, functionContent); defaultValue != nil {
+			// The call leaves the parameter out: its default value is where it comes from
+			s.logger.PrintInfo("Variable '%s' takes its default value at call site line %d", varName, callLine)
+			callSiteSteps = append(callSiteSteps, s.reportParameter(functionLine, functionName, varName, functionFile)...)
+			defaultKey := functionName + "#default#" + varName
+			if !s.reportedParameters[defaultKey] {
+				s.reportedParameters[defaultKey] = true
+				value := nodeService.SafeContent(defaultValue, functionContent)
+				defaultStep := models.DataFlowStep{
+					Line:     defaultValue.StartPoint().Row + 1,
+					Type:     "Default Parameter Value",
+					Function: functionName,
+					Value:    value,
+					Variable: value,
+				}
+				if functionFile != nil {
+					defaultStep.FilePath = functionFile.Path
+				}
+				callSiteSteps = append(callSiteSteps, defaultStep)
+				s.recordEdge(defaultStep, parameterStep(functionLine, functionName, varName, functionFile), models.EdgeKindAssignment)
+			}
+		} else {
+			s.logger.PrintInfo("Variable '%s' not found in arguments at call site line %d", varName, callLine)
+		}
+	}
+
+	// Delete variables that were not mapped
+	for varName := range variablesToTrack {
+		if !newVariablesToTrack[varName] {
+			s.logger.PrintInfo("Removing variable '%s' from tracking as it was not found in the function parameters.", varName)
+		}
+	}
+
+	return newVariablesToTrack, variableMapped, callSiteSteps
+}
+
+// -----------------------------------------------------------------------------
+// reportParameter - Creates the step of a tracked parameter on the declaration line of its function, once per function.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - functionLine (uint32): The line of the function declaration.
+//   - functionName (string): The name of the function.
+//   - parameter (string): The tracked parameter, or one of its fields.
+//   - file (*models.SourceFile): The project file declaring the function, or nil for the current file.
+//
+// Returns:
+//   - ([]models.DataFlowStep): The step of the parameter, or nil if a parameter of the function was already reported.
+//
+// -----------------------------------------------------------------------------
+func (s *Session) reportParameter(functionLine uint32, functionName, parameter string, file *models.SourceFile) []models.DataFlowStep {
+	parameterKey := functionName + "#parameter"
+	if s.reportedParameters[parameterKey] {
+		return nil
+	}
+	s.reportedParameters[parameterKey] = true
+	step := models.DataFlowStep{
+		Line:     functionLine,
+		Type:     "Function parameters",
+		Function: functionName,
+		Value:    parameter,
+		Variable: parameter,
+	}
+	if file != nil {
+		step.FilePath = file.Path
+	}
+	return []models.DataFlowStep{step}
+}
+
+// -----------------------------------------------------------------------------
+// followReturnIntoCallee - Follows the value a called function returns to an assigned variable back into the function.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - root (*sitter.Node): The root node of the syntax tree.
+//   - assignmentNode (*sitter.Node): The assignment of the returned value (e.g. path := buildPath(base, name)).
+//   - content ([]byte): The content of the source code.
+//   - variable (string): The tracked variable assigned by the call.
+//   - assignmentStep (models.DataFlowStep): The step of the assignment.
+//   - visitedLines (map[uint32]bool): A map to keep track of visited lines.
+//   - visitedFunctions (map[string]*models.VisitInfo): A map to keep track of visited functions.
+//   - variablesToTrack (map[string]bool): The variables tracked in the caller, completed with the arguments the returned value is built from.
+//
+// Returns:
+//   - ([]models.DataFlowStep): The steps found inside the called functions, from their return statements.
+//
+// -----------------------------------------------------------------------------
+func (s *Session) followReturnIntoCallee(
+	root, assignmentNode *sitter.Node,
+	content []byte,
+	variable string,
+	assignmentStep models.DataFlowStep,
+	visitedLines map[uint32]bool,
+	visitedFunctions map[string]*models.VisitInfo,
+	variablesToTrack map[string]bool,
+) []models.DataFlowStep {
+	var dataFlow []models.DataFlowStep
+	line := assignmentNode.StartPoint().Row + 1
+
+	// The returned value goes to the assigned variables only, each one receiving the value at its position
+	lhsVars, _, _ := nodeService.ExtractAssignmentVariables(s.language, assignmentNode, content)
+	position := -1
+	for i, lhsVar := range lhsVars {
+		if nodeService.AccessPathsOverlap(lhsVar, variable) {
+			position = i
+			break
+		}
+	}
+	if position < 0 {
+		return dataFlow
+	}
+
+	for _, callNode := range nodeService.FindCallExpressions(s.language, nodeService.GetAssignmentValue(s.language, assignmentNode, content)) {
+		functionName := nodeService.ExtractFunctionNameFromCall(s.language, callNode, content)
+		functions, candidates := s.resolveCall(root, callNode, content, nil)
+		if len(functions) == 0 {
+			continue
+		}
+		if s.isVisiting(functions) {
+			s.logger.PrintInfo("Function '%s' has already been visited. Skipping its returned value to prevent infinite recursion.", functionName)
+			continue
+		}
+
+		visitKey := functionName + "#returned"
+		if visitedFunctions[visitKey] == nil {
+			visitedFunctions[visitKey] = &models.VisitInfo{
+				VisitedCalls: make(map[int]bool),
+			}
+		}
+		if visitedFunctions[visitKey].VisitedCalls[int(line)] {
+			continue
+		}
+		visitedFunctions[visitKey].VisitedCalls[int(line)] = true
+
+		// The value of an ambiguous call may be returned by each of its candidates
+		for _, function := range functions {
+			functionNode, functionContent, functionFile := function.node, function.content, function.file
+			functionRoot := root
+			if functionFile != nil {
+				functionRoot = functionFile.Root
+			}
+
+			// The variables the returned values are built from, linked to the assigned variable
+			returnedVariables := make(map[string]bool)
+			returnLines := make(map[uint32]string)
+			for _, returnNode := range nodeService.FindReturnStatements(s.language, functionNode, functionContent) {
+				returnLine := returnNode.StartPoint().Row + 1
+				returnLines[returnLine] = nodeService.FindParentFunction(s.language, returnNode, functionContent)
+
+				// A returned literal is an origin of the assigned value (return "cached")
+				for _, literal := range nodeService.GetReturnedLiterals(s.language, returnNode, position, len(lhsVars)) {
+					value := nodeService.SafeContent(literal, functionContent)
+					s.logger.PrintInfo("Value returned by '%s' at line %d is the literal %s", functionName, returnLine, value)
+					literalStep := models.DataFlowStep{
+						Line:       returnLine,
+						Type:       "Returned Literal Value",
+						Function:   nodeService.FindParentFunction(s.language, returnNode, functionContent),
+						Value:      value,
+						Variable:   value,
+						Candidates: candidates,
+					}
+					if functionFile != nil {
+						literalStep.FilePath = functionFile.Path
+					}
+					dataFlow = append(dataFlow, literalStep)
+					s.recordEdge(literalStep, assignmentStep, models.EdgeKindReturn)
+				}
+
+				for _, returnedVariable := range nodeService.GetReturnedVariables(s.language, returnNode, functionContent, position, len(lhsVars)) {
+					returnedVariable = s.accessPath(returnedVariable)
+					if !s.isValidVariableToTrack(functionRoot, returnedVariable, functionContent) || !s.isDeclaredAtLine(functionRoot, functionContent, returnedVariable, returnLine) {
+						continue
+					}
+
+					s.logger.PrintInfo("Value returned by '%s' at line %d built from '%s'", functionName, returnLine, returnedVariable)
+					returnedVariables[returnedVariable] = true
+					s.trackOccurrencesAtLine(functionRoot, functionContent, returnedVariable, returnLine)
+					returnStep := models.DataFlowStep{
+						Line:       returnLine,
+						Type:       "Variable used in return statement",
+						Function:   nodeService.FindParentFunction(s.language, returnNode, functionContent),
+						Value:      returnedVariable,
+						Variable:   returnedVariable,
+						Candidates: candidates,
+					}
+					if functionFile != nil {
+						returnStep.FilePath = functionFile.Path
+					}
+					s.recordEdge(returnStep, assignmentStep, models.EdgeKindReturn)
+				}
+			}
+			if len(returnedVariables) == 0 {
+				s.logger.PrintInfo("No variable returned by '%s' to follow.", functionName)
+				continue
+			}
+
+			// Analyze the function from its end, its declaration leading back to this call site only
+			s.visitedFunctionStack = append(s.visitedFunctionStack, function.key())
+			s.logger.PrintInfo("Entering function '%s' to follow the value returned to '%s'", functionName, variable)
+			var functionFlow []models.DataFlowStep
+			if functionFile != nil {
+				functionFlow = s.crawlInFile(functionFile, functionNode, returnedVariables, functionNode.EndPoint().Row+1, true, visitedFunctions)
+			} else {
+				functionFlow = s.CrawlFromLine(root, functionNode, content, returnedVariables, functionNode.EndPoint().Row+1, true, visitedLines, visitedFunctions)
+			}
+			s.visitedFunctionStack = s.visitedFunctionStack[:len(s.visitedFunctionStack)-1]
+
+			// The return statements of a candidate return the value of the ambiguous call
+			for i, step := range functionFlow {
+				if step.Type == "Variable used in return statement" && returnLines[step.Line] == step.Function {
+					functionFlow[i].Candidates = candidates
+				}
+			}
+			dataFlow = append(dataFlow, functionFlow...)
+
+			// The parameters the returned value is built from come from the arguments of the call
+			arguments, _, callSiteSteps := s.mapVariablesToCallSite(callNode, content, nil, functionName, functionNode, functionContent, functionFile, returnedVariables, line)
+			for _, step := range callSiteSteps {
+				// The assignment step stands for the arguments of its call, the parameters and their defaults being in the file of the function
+				if step.Line != line || step.FilePath != "" {
+					dataFlow = append(dataFlow, step)
+				}
+			}
+			for _, argument := range utilityService.SortedKeys(arguments) {
+				if variablesToTrack[argument] || !s.isValidVariableToTrack(root, argument, content) || !s.isDeclaredAtLine(root, content, argument, line) {
+					continue
+				}
+				s.logger.PrintInfo("Value returned by '%s' built from the argument '%s' at line %d", functionName, argument, line)
+				variablesToTrack[argument] = true
+			}
+		}
+	}
+
+	return dataFlow
+}
+
+// pairedVariable - Variable mapped by a paired assignment of a file: a value read backward, a target assigned forward
+type pairedVariable struct {
+	root     *sitter.Node
+	line     uint32
+	variable string
+}
+
+// -----------------------------------------------------------------------------
+// replacePairedTarget - Stops tracking a target of a paired assignment, whose value then only comes from its paired element.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - node (*sitter.Node): The assignment or declaration node.
+//   - content ([]byte): The content of the source code.
+//   - variable (string): The tracked variable or access path.
+//   - variablesToTrack (map[string]bool): The variables tracked by the analysis.
+//
+// Returns:
+//   - None
+//
+// -----------------------------------------------------------------------------
+func (s *Session) replacePairedTarget(node *sitter.Node, content []byte, variable string, variablesToTrack map[string]bool) {
+	pairs, _ := nodeService.ExtractAssignmentPairs(s.language, node, content)
+	if !hasOwnValues(pairs) {
+		return
+	}
+
+	// In a, b = b, a, the a read on the right is the value before the assignment: b and a are not assigned by it
+	line := node.StartPoint().Row + 1
+	for _, pair := range pairs {
+		if !nodeService.IsAccessPathPrefix(pair.Target, variable) {
+			continue
+		}
+		delete(variablesToTrack, variable)
+		for _, source := range pair.Sources {
+			s.markPaired(node, s.accessPath(source))
+		}
+		s.logger.PrintInfo("Variable '%s' is replaced by its paired value at line %d", variable, line)
+		return
+	}
+}
+
+// -----------------------------------------------------------------------------
+// markPaired - Records a variable mapped by a paired assignment, so that the assignment does not map it back.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - node (*sitter.Node): The assignment or declaration node.
+//   - variable (string): The variable or access path mapped by the assignment.
+//
+// Returns:
+//   - None
+//
+// -----------------------------------------------------------------------------
+func (s *Session) markPaired(node *sitter.Node, variable string) {
+	s.pairedVariables[pairedVariable{root: treeRoot(node), line: node.StartPoint().Row + 1, variable: variable}] = true
+}
+
+// -----------------------------------------------------------------------------
+// isPaired - Checks if a variable was mapped by a paired assignment.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - node (*sitter.Node): The assignment or declaration node.
+//   - variable (string): The tracked variable or access path.
+//
+// Returns:
+//   - (bool): True if the variable comes from the assignment (b read by a, b = b, a backward, b assigned by it forward).
+//
+// -----------------------------------------------------------------------------
+func (s *Session) isPaired(node *sitter.Node, variable string) bool {
+	return s.pairedVariables[pairedVariable{root: treeRoot(node), line: node.StartPoint().Row + 1, variable: variable}]
+}
+
+// -----------------------------------------------------------------------------
+// hasOwnValues - Checks if the targets of an assignment are paired with values of their own.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - pairs ([]models.AssignmentPair): The targets of the assignment with the variables of their values.
+//
+// Returns:
+//   - (bool): True for a, b = b, a, false when every target receives the whole value (data, err := read(path)).
+//
+// -----------------------------------------------------------------------------
+func hasOwnValues(pairs []models.AssignmentPair) bool {
+	for _, pair := range pairs[utilityService.Min(1, len(pairs)):] {
+		if strings.Join(pair.Sources, ",") != strings.Join(pairs[0].Sources, ",") {
+			return true
+		}
+	}
+	return false
+}
+
+// -----------------------------------------------------------------------------
+// untrackedVariables - Selects the variables of a value that are not tracked yet.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - root (*sitter.Node): The root node of the syntax tree.
+//   - value (*sitter.Node): The value of the assignment.
+//   - content ([]byte): The content of the source code.
+//   - variables ([]string): The variables or access paths of the value.
+//   - variablesToTrack (map[string]bool): The variables tracked by the analysis.
+//
+// Returns:
+//   - ([]string): The variables that are not tracked and are valid to track, the called functions and modules
+//     excepted, in order.
+//
+// -----------------------------------------------------------------------------
+func (s *Session) untrackedVariables(root, value *sitter.Node, content []byte, variables []string, variablesToTrack map[string]bool) []string {
+	var untracked []string
+	for _, variable := range variables {
+		if !variablesToTrack[variable] && s.isValidVariableToTrack(root, variable, content) && !nodeService.IsCalledName(s.language, root, value, variable, content) {
+			untracked = append(untracked, variable)
+		}
+	}
+	return untracked
+}
+
+// -----------------------------------------------------------------------------
+// isDestructuredBy - Checks if a variable is one of the several targets of an assignment.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): The assignment or declaration node.
+//   - content ([]byte): The content of the source code.
+//   - variable (string): The tracked variable or access path.
+//
+// Returns:
+//   - (bool): True if the node assigns several targets (a, b = x, y or const {path, mode} = opts) and the variable,
+//     one of its fields or an object holding it is one of them.
+//
+// -----------------------------------------------------------------------------
+func isDestructuredBy(language string, node *sitter.Node, content []byte, variable string) bool {
+	pairs, _ := nodeService.ExtractAssignmentPairs(language, node, content)
+	if len(pairs) < 2 {
+		return false
+	}
+	for _, pair := range pairs {
+		if nodeService.AccessPathsOverlap(pair.Target, variable) {
+			return true
+		}
+	}
+	return false
+}
+
 // analyzeNode - Analyzes a node in the syntax tree to trace variable data flow
 // -----------------------------------------------------------------------------
 //
@@ -103,7 +687,7 @@ func CrawlFromLine(
 //   - ([]models.DataFlowStep): A slice of DataFlowStep representing the steps in the variable's data flow.
 //
 // -----------------------------------------------------------------------------
-func analyzeNode(
+func (s *Session) analyzeNode(
 	root, node *sitter.Node,
 	content []byte,
 	variable string,
@@ -115,177 +699,264 @@ func analyzeNode(
 	var dataFlow []models.DataFlowStep
 	line := node.StartPoint().Row + 1
 
-	// Skip analyzing 'block' and 'body_statement' nodes directly to avoid duplicate analysis for langages like python and ruby
-	if node.Type() == "block" || node.Type() == "body_statement" || node.Type() == "compound_statement" {
-		logger.PrintDebug("Skipping 'block' node at line %d; processing its children instead.", line)
-		return append(dataFlow, analyzeNode(
+	// The name may be another variable shadowing the tracked one
+	if !s.mayReferToTrackedVariable(root, node, content, variable) {
+		return dataFlow
+	}
+
+	// Skip analyzing 'block', 'body_statement' and 'statements' nodes directly to avoid duplicate analysis for langages like python, ruby and kotlin
+	if nodeService.IsBlockNode(s.language, node) {
+		s.logger.PrintDebug("Skipping 'block' node at line %d; processing its children instead.", line)
+		return append(dataFlow, s.analyzeNode(
 			root, node.Child(0), content, variable,
 			visitedLines, visitedFunctions, variablesToTrack, startLine)...)
 	}
 
 	// 1. Check if the node is an assignment
-	assignment, newVariable := nodeService.IsAssignment(node, content, variable)
-	if assignment && line != startLine {
+	assignment, assignedValues := nodeService.IsAssignment(s.language, node, content, variable)
+	var newVariables []string
+	for _, value := range assignedValues {
+		if value = s.accessPath(value); value != "" && !utilityService.ContainsString(newVariables, value) {
+			newVariables = append(newVariables, value)
+		}
+	}
+	if assignment && s.isPaired(node, variable) {
+		s.logger.PrintInfo("Variable '%s' is read by the paired assignment at line %d", variable, line)
+	} else if assignment && (line != startLine || nodeService.IsStartAssignment(s.language, node, content, variable)) {
+		// The start line assigning the variable leads to its value in the languages naming their arguments in assignments
 		if !visitedLines[line] {
-			logger.PrintInfo("Assignment found for variable '%s' at line %d", variable, line)
+			s.logger.PrintInfo("Assignment found for variable '%s' at line %d", variable, line)
 			rightNode := node.ChildByFieldName("right")
+			if rightNode == nil {
+				// Statements wrapping their assignment and declarations name their value otherwise
+				rightNode = nodeService.GetAssignmentValue(s.language, node, content)
+			}
 			value := nodeService.SafeContent(rightNode, content)
 
+			// The value of an ambiguous call may come from each of its candidates
+			var functions []calledFunction
+			var candidates []string
+			callExprNode := nodeService.FindCallExpression(s.language, rightNode)
+			if callExprNode != nil {
+				functions, candidates = s.resolveCall(root, callExprNode, content, nil)
+			}
+			assignmentStep := models.DataFlowStep{
+				Line:       line,
+				Type:       "Assignment of value",
+				Function:   nodeService.FindParentFunction(s.language, node, content),
+				Value:      value,
+				Variable:   variable,
+				Candidates: candidates,
+			}
+
+			// The value returned by a called function is built in its return statements
+			dataFlow = append(dataFlow, s.followReturnIntoCallee(root, node, content, variable, assignmentStep, visitedLines, visitedFunctions, variablesToTrack)...)
+
 			// Check if the right side is a function call
 			if rightNode != nil {
-				callExprNode := nodeService.FindCallExpression(rightNode)
 				if callExprNode != nil {
-					functionIdentifier := callExprNode.ChildByFieldName("function")
+					functionIdentifier := nodeService.GetCallee(s.language, callExprNode, content)
 					if functionIdentifier == nil {
 						functionIdentifier = callExprNode.Child(0)
 					}
 					functionName := nodeService.SafeContent(functionIdentifier, content)
-					logger.PrintInfo("Function call to '%s' detected at line %d", functionName, line)
+					s.logger.PrintInfo("Function call to '%s' detected at line %d", functionName, line)
 
 					// Check if the variable is passed as an argument
 					variablePassedAsArgument := false
-					argumentsNode := callExprNode.ChildByFieldName("arguments")
-					if argumentsNode != nil {
-						for i := 0; i < int(argumentsNode.NamedChildCount()); i++ {
-							argNode := argumentsNode.NamedChild(i)
-							argContent := nodeService.SafeContent(argNode, content)
-							if argContent == variable {
-								variablePassedAsArgument = true
-								break
-							}
+					for _, argNode := range nodeService.GetCallArguments(s.language, callExprNode, content) {
+						if nodeService.ArgumentRefersToVariable(s.language, argNode, content, variable) {
+							variablePassedAsArgument = true
+							break
 						}
 					}
 
-					// Find the function declaration
-					funcDeclNode := nodeService.FindFunctionDeclaration(root, functionName, content)
-
-					if funcDeclNode != nil {
+					// The function declarations, the methods of the type of the receiver, in the current file first and then in the other project files
+					for _, function := range functions {
+						funcDeclNode, funcDeclContent, funcDeclFile := function.node, function.content, function.file
 						funcLine := funcDeclNode.StartPoint().Row + 1
 
+						// Each candidate of an ambiguous call is visited on its own
+						visitKey := functionName
+						if candidates != nil {
+							visitKey = function.label(functionName)
+						}
+
 						if variablePassedAsArgument {
 							// Add "Function Declaration" step only if the variable is passed as an argument
-							dataFlow = append(dataFlow, models.DataFlowStep{
-								Line:     funcLine,
-								Type:     "Function Declaration",
-								Function: functionName,
-								Value:    functionName,
-								Variable: variable,
-							})
-							logger.PrintInfo("Dataflow step added for function '%s' at line %d", functionName, funcLine)
+							declarationStep := models.DataFlowStep{
+								Line:       funcLine,
+								Type:       "Function Declaration",
+								Function:   functionName,
+								Value:      functionName,
+								Variable:   variable,
+								Candidates: candidates,
+							}
+							if funcDeclFile != nil {
+								declarationStep.FilePath = funcDeclFile.Path
+							}
+							dataFlow = append(dataFlow, declarationStep)
+							s.logger.PrintInfo("Dataflow step added for function '%s' at line %d", functionName, funcLine)
 						} else {
-							logger.PrintInfo("Variable '%s' is not passed as an argument to function '%s', skipping 'Function Declaration' data flow step", variable, functionName)
+							s.logger.PrintInfo("Variable '%s' is not passed as an argument to function '%s', skipping 'Function Declaration' data flow step", variable, functionName)
 						}
 
-						logger.PrintInfo("Function declaration for '%s' found at line %d", functionName, funcLine)
+						s.logger.PrintInfo("Function declaration for '%s' found at line %d", functionName, funcLine)
 
 						if variablePassedAsArgument {
 							// Proceed to analyze the function only if the variable is passed as an argument
-							if visitedFunctions[functionName] == nil {
-								visitedFunctions[functionName] = &models.VisitInfo{
+							if visitedFunctions[visitKey] == nil {
+								visitedFunctions[visitKey] = &models.VisitInfo{
 									VisitedCalls: make(map[int]bool),
 								}
-								logger.PrintDebug("Initialized VisitInfo for new function: %s", functionName)
+								s.logger.PrintDebug("Initialized VisitInfo for new function: %s", functionName)
 							}
 
 							// Log already visited functions
-							logger.PrintInfo("visitedFunctionStack before joining = %v", visitedFunctionStack)
+							s.logger.PrintDebug("Visited functions before joining the call: %v", s.visitedFunctionStack)
 
 							// Check if function is already in visitedFunctions
-							visitInfo, exists := visitedFunctions[functionName]
+							visitInfo, exists := visitedFunctions[visitKey]
 							if !exists {
 								// Initialize if it doesn't exist
-								visitedFunctions[functionName] = &models.VisitInfo{VisitedDef: false}
-								visitInfo = visitedFunctions[functionName]
+								visitedFunctions[visitKey] = &models.VisitInfo{VisitedDef: false}
+								visitInfo = visitedFunctions[visitKey]
 							}
 
-							logger.PrintDebug("VisitInfo for function '%s': %+v", functionName, visitInfo)
+							s.logger.PrintDebug("VisitInfo for function '%s': %+v", functionName, visitInfo)
 
 							if !visitInfo.VisitedDef {
 								// Mark the definition as visited
 								visitInfo.VisitedDef = true
-								visitedFunctionStack = append(visitedFunctionStack, functionName)
-								logger.PrintInfo("Entering function '%s' to analyze variable '%s'", functionName, variable)
+								s.visitedFunctionStack = append(s.visitedFunctionStack, function.key())
+								s.logger.PrintInfo("Entering function '%s' to analyze variable '%s'", functionName, variable)
 
 								// Get the corresponding parameter name
-								paramVariable := nodeService.GetParameterName(funcDeclNode, content, variable, node)
-								logger.PrintInfo("Tracking variable '%s' as '%s' inside function '%s'", variable, paramVariable, functionName)
+								paramVariable := s.accessPath(nodeService.GetParameterNameFromContents(s.language, funcDeclNode, funcDeclContent, variable, node, content))
+								s.logger.PrintInfo("Tracking variable '%s' as '%s' inside function '%s'", variable, paramVariable, functionName)
+								if paramVariable != "" {
+									s.trackParameter(funcDeclNode, funcDeclContent, paramVariable)
+									s.recordEdge(models.DataFlowStep{
+										Line:     line,
+										Type:     "Function parameters",
+										Method:   functionName,
+										Function: nodeService.FindParentFunction(s.language, node, content),
+										Value:    variable,
+										Variable: variable,
+									}, parameterStep(funcLine, functionName, paramVariable, funcDeclFile), models.EdgeKindArgument)
+								}
 
 								// Create a new variablesToTrack map only for relevant variables
 								newVariablesToTrack := make(map[string]bool)
 								for varName := range variablesToTrack {
 									if varName == variable && paramVariable != "" {
 										newVariablesToTrack[paramVariable] = true
-										logger.PrintDebug("Mapped variable '%s' to '%s' in function '%s'", varName, paramVariable, functionName)
-									} else if nodeService.IsVariableInScope(varName, funcDeclNode, content) {
+										s.logger.PrintDebug("Mapped variable '%s' to '%s' in function '%s'", varName, paramVariable, functionName)
+									} else if s.isVariableInScope(varName, funcDeclNode, funcDeclContent) {
 										// Track only variables that are in scope
 										newVariablesToTrack[varName] = true
 									}
 								}
 
 								// Continue with data flow analysis inside the called function if a variable is mapped
-								if len(newVariablesToTrack) > 0 {
-									dataFlow = append(dataFlow, CrawlFromLine(
+								if len(newVariablesToTrack) > 0 && funcDeclFile != nil {
+									dataFlow = append(dataFlow, s.crawlInFile(
+										funcDeclFile, funcDeclNode, newVariablesToTrack, funcDeclNode.EndPoint().Row+1, true, visitedFunctions)...)
+								} else if len(newVariablesToTrack) > 0 {
+									dataFlow = append(dataFlow, s.CrawlFromLine(
 										root, funcDeclNode, content, newVariablesToTrack, funcDeclNode.EndPoint().Row+1, true, visitedLines, visitedFunctions)...)
 								} else {
-									logger.PrintInfo("No relevant variables to track in function '%s'. Skipping analysis.", functionName)
+									s.logger.PrintInfo("No relevant variables to track in function '%s'. Skipping analysis.", functionName)
 								}
 
 								// Remove the function from the stack after analysis
-								visitedFunctionStack = visitedFunctionStack[:len(visitedFunctionStack)-1]
+								s.visitedFunctionStack = s.visitedFunctionStack[:len(s.visitedFunctionStack)-1]
 							} else {
-								logger.PrintInfo("Function '%s' has already been visited. Skipping to prevent infinite recursion.", functionName)
+								s.logger.PrintInfo("Function '%s' has already been visited. Skipping to prevent infinite recursion.", functionName)
 							}
 						}
-					} else {
-						logger.PrintInfo("Function declaration for '%s' not found", functionName)
+					}
+					if len(functions) == 0 {
+						s.logger.PrintInfo("Function declaration for '%s' not found", functionName)
 					}
 				}
 			}
 
 			// Add the assignment step
-			dataFlow = append(dataFlow, models.DataFlowStep{
-				Line:     line,
-				Type:     "Assignment of value",
-				Function: nodeService.FindParentFunction(node, content),
-				Value:    value,
-				Variable: variable,
-			})
+			dataFlow = append(dataFlow, assignmentStep)
 			visitedLines[line] = true
 
-			// Handle new variables
-			if newVariable != "" && !nodeService.IsLiteral(rightNode) {
-				// Check if newVariable is an identifier
-				if nodeService.IsVariableUsedInExpression(root, newVariable, content) && nodeService.IsValidVariableToTrack(root, newVariable, content) {
-					variablesToTrack[newVariable] = true
-					logger.PrintInfo("New variable '%s' found in assignment at line %d", newVariable, line)
-					dataFlow = append(dataFlow, models.DataFlowStep{
-						Line:     line,
-						Type:     "Assignment of value",
-						Function: nodeService.FindParentFunction(node, content),
-						Value:    value,
-						Variable: newVariable,
-					})
-				} else {
-					logger.PrintInfo("New variable '%s' is not a valid variable to track.", newVariable)
+			// A value computed from the variable itself comes from the declaration it shadows or updates
+			s.trackOccurrences(node, content, variable)
+			s.replacePairedTarget(node, content, variable, variablesToTrack)
+
+			// Handle new variables, each variable the value is built from (base and name in base + "/" + name)
+			if len(newVariables) > 0 && !nodeService.IsLiteral(s.language, rightNode) {
+				for _, newVariable := range newVariables {
+					if nodeService.IsCalledName(s.language, root, rightNode, newVariable, content) {
+						s.logger.PrintInfo("New variable '%s' only names a called function.", newVariable)
+						continue
+					}
+
+					// Check if newVariable is an identifier
+					if nodeService.IsVariableUsedInExpression(s.language, root, newVariable, content) && s.isValidVariableToTrack(root, newVariable, content) {
+						variablesToTrack[newVariable] = true
+						s.trackOccurrences(node, content, newVariable)
+						s.logger.PrintInfo("New variable '%s' found in assignment at line %d", newVariable, line)
+						newVariableStep := models.DataFlowStep{
+							Line:       line,
+							Type:       "Assignment of value",
+							Function:   nodeService.FindParentFunction(s.language, node, content),
+							Value:      value,
+							Variable:   newVariable,
+							Candidates: candidates,
+						}
+						dataFlow = append(dataFlow, newVariableStep)
+						s.recordEdge(newVariableStep, assignmentStep, models.EdgeKindAssignment)
+					} else {
+						s.logger.PrintInfo("New variable '%s' is not a valid variable to track.", newVariable)
+					}
 				}
 			} else {
-				logger.PrintInfo("Right-hand side is a literal or newVariable is empty; no new variable tracked.")
+				s.logger.PrintInfo("Right-hand side is a literal or newVariable is empty; no new variable tracked.")
 			}
 
+		} else if untracked := s.untrackedVariables(root, nodeService.GetAssignmentValue(s.language, node, content), content, newVariables, variablesToTrack); len(untracked) > 0 && isDestructuredBy(s.language, node, content, variable) {
+			// Each target of a destructuring receives its own value (b from y in a, b = x, y)
+			value := nodeService.SafeContent(node.ChildByFieldName("right"), content)
+			assignmentStep := models.DataFlowStep{
+				Line:     line,
+				Type:     "Assignment of value",
+				Function: nodeService.FindParentFunction(s.language, node, content),
+				Value:    value,
+				Variable: variable,
+			}
+			dataFlow = append(dataFlow, assignmentStep)
+			for _, newVariable := range untracked {
+				s.logger.PrintInfo("New variable '%s' found in assignment at line %d", newVariable, line)
+				newVariableStep := assignmentStep
+				newVariableStep.Variable = newVariable
+				dataFlow = append(dataFlow, newVariableStep)
+				s.recordEdge(newVariableStep, assignmentStep, models.EdgeKindAssignment)
+				variablesToTrack[newVariable] = true
+				s.trackOccurrences(node, content, newVariable)
+			}
+			s.replacePairedTarget(node, content, variable, variablesToTrack)
 		} else {
-			logger.PrintInfo("Line %d already visited for assignment.", line)
+			s.logger.PrintInfo("Line %d already visited for assignment.", line)
 		}
 	}
 
 	// 2. Check if the node is a function call
-	functionCall, newVariableFromCall := nodeService.IsFunctionCall(node, content, variable)
+	functionCall, newVariableFromCall := nodeService.IsFunctionCall(s.language, node, content, variable)
+	newVariableFromCall = s.accessPath(newVariableFromCall)
 	if functionCall {
 		line := node.StartPoint().Row + 1
 
 		// Extract the method name from the function call node
-		methodName := nodeService.ExtractFunctionNameFromCall(node, content)
+		methodName := nodeService.ExtractFunctionNameFromCall(s.language, node, content)
 
-		logger.PrintInfo("Function call found for variable '%s' at line %d, calling method '%s'", variable, line, methodName)
+		s.logger.PrintInfo("Function call found for variable '%s' at line %d, calling method '%s'", variable, line, methodName)
 
 		if visitedFunctions[methodName] == nil {
 			visitedFunctions[methodName] = &models.VisitInfo{
@@ -295,42 +966,60 @@ func analyzeNode(
 
 		methodNameInt := int(line)
 		if visitedFunctions[methodName].VisitedCalls[methodNameInt] {
-			logger.PrintInfo("Skipping previously visited function '%s' for variable '%s'", methodName, variable)
+			s.logger.PrintInfo("Skipping previously visited function '%s' for variable '%s'", methodName, variable)
 			return dataFlow
 		}
 		visitedFunctions[methodName].VisitedCalls[methodNameInt] = true
 
-		if methodName == nodeService.FindParentFunction(node, content) {
-			logger.PrintInfo("Skipping recursive call to function '%s' at line %d", methodName, line)
+		if methodName == nodeService.FindParentFunction(s.language, node, content) {
+			s.logger.PrintInfo("Skipping recursive call to function '%s' at line %d", methodName, line)
 			return dataFlow
 		}
 
-		dataFlow = append(dataFlow, models.DataFlowStep{
-			Line:     line,
-			Type:     "Function parameters",
-			Method:   methodName,
-			Function: nodeService.FindParentFunction(node, content),
-			Value:    variable,
-			Variable: variable,
-		})
+		// Find the called functions, the methods of the type of the receiver, in the current file first and then in the other project files
+		functions, candidates := s.resolveCall(root, node, content, nil)
+		calledName := nodeService.GetCalledFunctionName(s.language, node, content)
+
+		callStep := models.DataFlowStep{
+			Line:       line,
+			Type:       "Function parameters",
+			Method:     methodName,
+			Function:   nodeService.FindParentFunction(s.language, node, content),
+			Value:      variable,
+			Variable:   variable,
+			Candidates: candidates,
+		}
+		dataFlow = append(dataFlow, callStep)
 		visitedLines[line] = true
 
-		newFunction := nodeService.FindFunctionByName(root, methodName, content)
-		if newFunction != nil {
-			// Check if the function has already been visited
-			logger.PrintInfo("visitedFunctionStack = %v", visitedFunctionStack)
-			if utilityService.ContainsString(visitedFunctionStack, methodName) {
-				logger.PrintInfo("Function '%s' has already been visited. Skipping to prevent infinite recursion.", methodName)
+		// Check if the function has already been visited
+		if len(functions) > 0 {
+			s.logger.PrintDebug("Visited functions: %v", s.visitedFunctionStack)
+			if s.isVisiting(functions) {
+				s.logger.PrintInfo("Function '%s' has already been visited. Skipping to prevent infinite recursion.", methodName)
 				return dataFlow
 			}
+		}
+
+		// An ambiguous call is followed into each of its candidates
+		for _, function := range functions {
+			newFunction, newFunctionContent, newFunctionFile := function.node, function.content, function.file
+			if newFunctionFile == nil && containsNode(newFunction, node) {
+				s.logger.PrintInfo("Skipping recursive call to function '%s' at line %d", methodName, line)
+				continue
+			}
 
 			// Add the function to the visited functions stack
-			visitedFunctionStack = append(visitedFunctionStack, methodName)
-			logger.PrintInfo("Entering function '%s' to analyze variable '%s'", methodName, variable)
+			s.visitedFunctionStack = append(s.visitedFunctionStack, function.key())
+			s.logger.PrintInfo("Entering function '%s' to analyze variable '%s'", methodName, variable)
 
 			// Get the corresponding parameter name
-			paramVariable := nodeService.GetParameterName(newFunction, content, variable, node)
-			logger.PrintInfo("Tracking variable '%s' as '%s' inside function '%s'", variable, paramVariable, methodName)
+			paramVariable := s.accessPath(nodeService.GetParameterNameFromContents(s.language, newFunction, newFunctionContent, variable, node, content))
+			s.logger.PrintInfo("Tracking variable '%s' as '%s' inside function '%s'", variable, paramVariable, methodName)
+			if paramVariable != "" {
+				s.trackParameter(newFunction, newFunctionContent, paramVariable)
+				s.recordEdge(callStep, parameterStep(newFunction.StartPoint().Row+1, calledName, paramVariable, newFunctionFile), models.EdgeKindArgument)
+			}
 
 			// Create a new variablesToTrack map only for relevant variables
 			newVariablesToTrack := make(map[string]bool)
@@ -338,28 +1027,33 @@ func analyzeNode(
 				for varName := range variablesToTrack {
 					if varName == variable {
 						newVariablesToTrack[paramVariable] = true
-						logger.PrintDebug("Mapped variable '%s' to '%s' in function '%s'", varName, paramVariable, methodName)
+						s.logger.PrintDebug("Mapped variable '%s' to '%s' in function '%s'", varName, paramVariable, methodName)
 					}
 				}
 			}
 
 			// Continue with data flow analysis inside the called function if a variable is mapped
-			if len(newVariablesToTrack) > 0 {
-				functionStart, functionEnd := nodeService.FindFunctionBounds(root, newFunction, newFunction.StartPoint().Row+1)
-				logger.PrintInfo("Function '%s' bounds: %d - %d", methodName, functionStart, functionEnd)
-				dataFlow = append(dataFlow, CrawlFromLine(root, newFunction, content, newVariablesToTrack, functionEnd-1, true, visitedLines, visitedFunctions)...)
+			if len(newVariablesToTrack) > 0 && newFunctionFile != nil {
+				functionStart, functionEnd := nodeService.FindFunctionBounds(s.language, newFunctionFile.Root, newFunction, newFunction.StartPoint().Row+1)
+				s.logger.PrintInfo("Function '%s' bounds in '%s': %d - %d", methodName, newFunctionFile.Path, functionStart, functionEnd)
+				dataFlow = append(dataFlow, s.crawlInFile(newFunctionFile, newFunction, newVariablesToTrack, functionEnd-1, true, visitedFunctions)...)
+			} else if len(newVariablesToTrack) > 0 {
+				functionStart, functionEnd := nodeService.FindFunctionBounds(s.language, root, newFunction, newFunction.StartPoint().Row+1)
+				s.logger.PrintInfo("Function '%s' bounds: %d - %d", methodName, functionStart, functionEnd)
+				dataFlow = append(dataFlow, s.CrawlFromLine(root, newFunction, content, newVariablesToTrack, functionEnd-1, true, visitedLines, visitedFunctions)...)
 			} else {
-				logger.PrintInfo("No relevant variables to track for function '%s'. Skipping analysis.", methodName)
+				s.logger.PrintInfo("No relevant variables to track for function '%s'. Skipping analysis.", methodName)
 			}
 
 			// Remove the function from the stack after analysis
-			visitedFunctionStack = visitedFunctionStack[:len(visitedFunctionStack)-1]
-		} else {
-			logger.PrintInfo("Function '%s' not found, treating it as an assignment", methodName)
+			s.visitedFunctionStack = s.visitedFunctionStack[:len(s.visitedFunctionStack)-1]
+		}
+		if len(functions) == 0 {
+			s.logger.PrintInfo("Function '%s' not found, treating it as an assignment", methodName)
 			dataFlow = append(dataFlow, models.DataFlowStep{
 				Line:     line,
 				Type:     "Assignment of value",
-				Function: nodeService.FindParentFunction(node, content),
+				Function: nodeService.FindParentFunction(s.language, node, content),
 				Value:    nodeService.SafeContent(node.ChildByFieldName("right"), content),
 				Variable: variable,
 			})
@@ -367,121 +1061,132 @@ func analyzeNode(
 		}
 
 		if newVariableFromCall != "" {
-			logger.PrintInfo("New variable '%s' assigned from function call at line %d", newVariableFromCall, line)
-			if nodeService.IsValidVariableToTrack(root, variable, content) {
+			s.logger.PrintInfo("New variable '%s' assigned from function call at line %d", newVariableFromCall, line)
+			if s.isValidVariableToTrack(root, variable, content) {
 				variablesToTrack[variable] = true
-				logger.PrintInfo("New variable '%s' found in assignment at line %d", newVariableFromCall, line)
-				dataFlow = append(dataFlow, models.DataFlowStep{
+				s.logger.PrintInfo("New variable '%s' found in assignment at line %d", newVariableFromCall, line)
+				newVariableStep := models.DataFlowStep{
 					Line:     line,
 					Type:     "Assignment of value",
-					Function: nodeService.FindParentFunction(node, content),
+					Function: nodeService.FindParentFunction(s.language, node, content),
 					Value:    newVariableFromCall,
 					Variable: newVariableFromCall,
-				})
+				}
+				dataFlow = append(dataFlow, newVariableStep)
+				s.recordEdge(callStep, newVariableStep, models.EdgeKindAssignment)
 			} else {
 				delete(variablesToTrack, variable)
 			}
 
-			dataFlow = append(dataFlow, analyzeNode(root, node, content, newVariableFromCall, visitedLines, visitedFunctions, variablesToTrack, startLine)...)
+			dataFlow = append(dataFlow, s.analyzeNode(root, node, content, newVariableFromCall, visitedLines, visitedFunctions, variablesToTrack, startLine)...)
 		}
 	}
 
 	// 3. Check if the node is a function declaration
-	funcName := nodeService.IsFunctionDeclaration(root, node, content)
+	funcName := nodeService.IsFunctionDeclaration(s.language, root, node, content)
 	if funcName != "" {
+		// Methods of the same name declared for different types are visited separately
+		funcKey := functionKey(s.language, node, content)
+
 		// Check if the function has already been visited
-		logger.PrintInfo("visitedFunctionStack = %v", visitedFunctionStack)
-		if utilityService.ContainsString(visitedFunctionStack, funcName) {
-			logger.PrintInfo("Function '%s' has already been visited. Skipping to prevent infinite recursion.", funcName)
+		s.logger.PrintDebug("Visited functions: %v", s.visitedFunctionStack)
+		if utilityService.ContainsString(s.visitedFunctionStack, funcKey) {
+			s.logger.PrintInfo("Function '%s' has already been visited. Skipping to prevent infinite recursion.", funcKey)
 			return dataFlow
 		}
 
 		// Add the function to the visited functions stack
-		visitedFunctionStack = append(visitedFunctionStack, funcName)
+		s.visitedFunctionStack = append(s.visitedFunctionStack, funcKey)
 
-		if visitedFunctions[funcName] == nil {
-			visitedFunctions[funcName] = &models.VisitInfo{
+		if visitedFunctions[funcKey] == nil {
+			visitedFunctions[funcKey] = &models.VisitInfo{
 				VisitedCalls: make(map[int]bool),
 			}
-			logger.PrintDebug("Initialized VisitInfo for new function: %s", funcName)
+			s.logger.PrintDebug("Initialized VisitInfo for new function: %s", funcKey)
 		}
 
 		// Put the function declaration in the visited functions map
-		visitInfo := visitedFunctions[funcName]
+		visitInfo := visitedFunctions[funcKey]
 		if !visitInfo.VisitedDef {
 			visitInfo.VisitedDef = true
-			logger.PrintInfo("Function declaration detected and marked: %s", funcName)
+			s.logger.PrintInfo("Function declaration detected and marked: %s", funcKey)
 		} else {
-			logger.PrintInfo("Skipping revisited function declaration: %s", funcName)
+			s.logger.PrintInfo("Skipping revisited function declaration: %s", funcKey)
 			// Remove the function from the stack after analysis
-			visitedFunctionStack = visitedFunctionStack[:len(visitedFunctionStack)-1]
+			s.visitedFunctionStack = s.visitedFunctionStack[:len(s.visitedFunctionStack)-1]
 			return dataFlow
 		}
 
 		// From the function declaration to the call sites
-		callSites := nodeService.FindFunctionCallSites(root, funcName, content)
-		logger.PrintDebug("Call sites for function '%s'", funcName)
+		callSites := nodeService.FindFunctionCallSites(s.language, root, funcName, content)
+		s.logger.PrintDebug("Call sites for function '%s'", funcName)
 		for _, callSite := range callSites {
+			// Calls to a method of the same name declared for another type are not call sites of this function
+			if !s.callsFunction(root, callSite.CallNode, content, nil, node) {
+				s.logger.PrintInfo("Call at line %d refers to another declaration of '%s'. Skipping it.", callSite.Line, funcName)
+				continue
+			}
 			callSiteInt := int(callSite.Line)
-			if !visitedFunctions[funcName].VisitedCalls[callSiteInt] {
-				visitedFunctions[funcName].VisitedCalls[callSiteInt] = true
+			if !visitedFunctions[funcKey].VisitedCalls[callSiteInt] {
+				visitedFunctions[funcKey].VisitedCalls[callSiteInt] = true
 
 				// Map the variables to the function parameters
-				newVariablesToTrack := make(map[string]bool)
-				variableMapped := false
-
-				logger.PrintInfo("Starting variable mapping at call site line %d", callSite.Line)
-				for varName := range variablesToTrack {
-					logger.PrintDebug("Analyzing variable '%s' at call site line %d", varName, callSite.Line)
+				newVariablesToTrack, variableMapped, callSiteSteps := s.mapVariablesToCallSite(callSite.CallNode, content, nil, funcName, node, content, nil, variablesToTrack, callSite.Line)
+				dataFlow = append(dataFlow, callSiteSteps...)
 
-					argVariable := nodeService.GetArgumentVariable(callSite.CallNode, varName, node, content)
+				// If no variables are mapped, skip the analysis
+				if !variableMapped {
+					s.logger.PrintInfo("No relevant variables found in call site at line %d. Skipping analysis for this call.", callSite.Line)
+					continue
+				}
 
-					logger.PrintInfo("argVariable '%s' et varName '%s'", argVariable, varName)
+				// Continue with data flow analysis inside the called function if a variable is mapped
+				dataFlow = append(dataFlow, s.CrawlFromLine(root, node, content, newVariablesToTrack, callSite.Line, true, visitedLines, visitedFunctions)...)
+			}
+		}
 
-					if argVariable != "" {
-						if argVariable != varName {
-							logger.PrintInfo("Tracking variable '%s' as '%s' at call site line %d", varName, argVariable, callSite.Line)
-							newVariablesToTrack[argVariable] = true
-							logger.PrintDebug("Mapped variable '%s' to '%s' at call site line %d", varName, argVariable, callSite.Line)
-							variableMapped = true
-						} else {
-							logger.PrintDebug("No mapping needed for variable '%s' at call site line %d", varName, callSite.Line)
-							newVariablesToTrack[varName] = true
-							variableMapped = true
-						}
-					} else {
-						logger.PrintInfo("Variable '%s' not found in arguments at call site line %d", varName, callSite.Line)
+		// From the function declaration to the call sites located in the other project files
+		if s.project != nil {
+			for _, projectCallSite := range projectService.FindCallSites(s.project, funcName, s.currentFile, s.logger) {
+				if !s.callsFunction(projectCallSite.File.Root, projectCallSite.CallSite.CallNode, projectCallSite.File.Content, projectCallSite.File, node) {
+					s.logger.PrintInfo("Call at line %d of '%s' refers to another declaration of '%s'. Skipping it.", projectCallSite.CallSite.Line, projectCallSite.File.Path, funcName)
+					continue
+				}
+				visitKey := funcKey + "@" + projectCallSite.File.Path
+				if visitedFunctions[visitKey] == nil {
+					visitedFunctions[visitKey] = &models.VisitInfo{
+						VisitedCalls: make(map[int]bool),
 					}
 				}
 
-				// Delete variables that were not mapped
-				for varName := range variablesToTrack {
-					if !newVariablesToTrack[varName] {
-						logger.PrintInfo("Removing variable '%s' from tracking as it was not found in the function parameters.", varName)
-					}
+				callSiteInt := int(projectCallSite.CallSite.Line)
+				if visitedFunctions[visitKey].VisitedCalls[callSiteInt] {
+					continue
 				}
+				visitedFunctions[visitKey].VisitedCalls[callSiteInt] = true
 
-				// If no variables are mapped, skip the analysis
+				newVariablesToTrack, variableMapped, callSiteSteps := s.mapVariablesToCallSite(
+					projectCallSite.CallSite.CallNode, projectCallSite.File.Content, projectCallSite.File, funcName, node, content, nil, variablesToTrack, projectCallSite.CallSite.Line)
+				dataFlow = append(dataFlow, callSiteSteps...)
 				if !variableMapped {
-					logger.PrintInfo("No relevant variables found in call site at line %d. Skipping analysis for this call.", callSite.Line)
+					s.logger.PrintInfo("No relevant variables found in call site at line %d of '%s'. Skipping analysis for this call.", projectCallSite.CallSite.Line, projectCallSite.File.Path)
 					continue
 				}
 
-				// Continue with data flow analysis inside the called function if a variable is mapped
-				dataFlow = append(dataFlow, CrawlFromLine(root, node, content, newVariablesToTrack, callSite.Line, true, visitedLines, visitedFunctions)...)
+				dataFlow = append(dataFlow, s.crawlInFile(projectCallSite.File, projectCallSite.CallSite.CallNode, newVariablesToTrack, projectCallSite.CallSite.Line, true, visitedFunctions)...)
 			}
 		}
 
 		// Remove the function from the stack after analysis
-		visitedFunctionStack = visitedFunctionStack[:len(visitedFunctionStack)-1]
+		s.visitedFunctionStack = s.visitedFunctionStack[:len(s.visitedFunctionStack)-1]
 		return dataFlow
 	}
 
 	// 4. Check if the node is a control structure
-	controlType := nodeService.GetControlType(node.Type())
-	if controlType != "" && nodeService.IsVariableUsedInExpression(node, variable, content) {
+	controlType := nodeService.GetControlType(s.language, node)
+	if controlType != "" && nodeService.IsVariableUsedInExpression(s.language, node, variable, content) {
 		line := node.StartPoint().Row + 1
-		functionName := nodeService.FindParentFunction(node, content)
+		functionName := nodeService.FindParentFunction(s.language, node, content)
 		dataFlow = append(dataFlow, models.DataFlowStep{
 			Line:     line,
 			Type:     controlType,
@@ -489,13 +1194,13 @@ func analyzeNode(
 			Value:    variable,
 			Variable: variable,
 		})
-		logger.PrintInfo("Variable '%s' used in %s at line %d within function '%s'", variable, controlType, line, functionName)
+		s.logger.PrintInfo("Variable '%s' used in %s at line %d within function '%s'", variable, controlType, line, functionName)
 	}
 
 	// 5. Check if the node is a return statement
 	for i := 0; i < int(node.ChildCount()); i++ {
 		child := node.Child(i)
-		dataFlow = append(dataFlow, analyzeNode(
+		dataFlow = append(dataFlow, s.analyzeNode(
 			root, child, content, variable,
 			visitedLines, visitedFunctions, variablesToTrack, startLine)...)
 	}
@@ -0,0 +1,520 @@
+package crawler
+
+import (
+	"dataflow/models"
+	"dataflow/services/nodeService"
+	"dataflow/services/projectService"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// forwardCallContext - A call site through which the forward analysis entered a function
+type forwardCallContext struct {
+	functionName     string
+	callNode         *sitter.Node
+	content          []byte
+	file             *models.SourceFile
+	variablesToTrack map[string]bool
+	candidates       []string // Candidates of the call when it is ambiguous, nil otherwise
+}
+
+// -----------------------------------------------------------------------------
+// analyzeNodeForward - Analyzes a node in the syntax tree to follow where the value of a variable goes
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - root (*sitter.Node): The root node of the syntax tree.
+//   - node (*sitter.Node): The current node being analyzed.
+//   - content ([]byte): The content of the source code.
+//   - variable (string): The variable to follow in the data flow analysis.
+//   - visitedLines (map[uint32]bool): A map to keep track of visited lines to avoid duplicate analysis.
+//   - visitedFunctions (map[string]*models.VisitInfo): A map to keep track of visited functions and their visit information.
+//   - variablesToTrack (map[string]bool): A map of variables to track during the analysis.
+//   - startLine (uint32): The starting line number for the analysis.
+//
+// Returns:
+//   - ([]models.DataFlowStep): A slice of DataFlowStep representing the steps in the variable's data flow.
+//
+// -----------------------------------------------------------------------------
+func (s *Session) analyzeNodeForward(
+	root, node *sitter.Node,
+	content []byte,
+	variable string,
+	visitedLines map[uint32]bool,
+	visitedFunctions map[string]*models.VisitInfo,
+	variablesToTrack map[string]bool,
+	startLine uint32,
+) []models.DataFlowStep {
+	var dataFlow []models.DataFlowStep
+	line := node.StartPoint().Row + 1
+
+	// The name may be another variable shadowing the followed one
+	if !s.mayReferToTrackedVariable(root, node, content, variable) {
+		return dataFlow
+	}
+
+	// Skip analyzing 'block', 'body_statement' and 'statements' nodes directly to avoid duplicate analysis for langages like python, ruby and kotlin
+	if nodeService.IsBlockNode(s.language, node) {
+		s.logger.PrintDebug("Skipping 'block' node at line %d; processing its children instead.", line)
+		return append(dataFlow, s.analyzeNodeForward(
+			root, node.Child(0), content, variable,
+			visitedLines, visitedFunctions, variablesToTrack, startLine)...)
+	}
+
+	// Function bodies are analyzed line by line, never through their declaration
+	if nodeService.IsFunctionDeclaration(s.language, root, node, content) != "" {
+		return dataFlow
+	}
+
+	// 1. Check if the value of the variable flows into an assignment
+	pairs, isAssignment := nodeService.ExtractAssignmentPairs(s.language, node, content)
+	if isAssignment && s.isPaired(node, variable) {
+		// In a, b = b, a, the b assigned from a is not read by the assignment
+		s.logger.PrintInfo("Variable '%s' is assigned by the paired assignment at line %d", variable, line)
+	} else if isAssignment {
+		// The variable is overwritten by an untracked value on every path: its previous value does not go any further
+		overwritten := line != startLine && isOverwritten(pairs, variable, variablesToTrack) && !isInsideConditionalBranch(s.language, root, node, content)
+
+		value := nodeService.SafeContent(node.ChildByFieldName("right"), content)
+		if value == "" {
+			value = nodeService.SafeContent(node, content)
+		}
+
+		// The value of an ambiguous call may come from each of its candidates
+		var candidates []string
+		if callNode := nodeService.FindCallExpression(s.language, nodeService.GetAssignmentValue(s.language, node, content)); callNode != nil {
+			_, candidates = s.resolveCall(root, callNode, content, nil)
+		}
+
+		// Only the targets receiving the variable are followed (b after a, b = x, y for y)
+		for _, pair := range pairs {
+			if !nodeService.ContainsAccessPath(pair.Sources, variable) {
+				continue
+			}
+
+			// A copied object holds the tracked fields in the copy (req.Path is followed as x.Path after x = req)
+			assignedVariable := s.accessPath(nodeService.RebaseAccessPath(variable, pair.Copied, pair.Target))
+			if assignedVariable != variable && (variablesToTrack[assignedVariable] || !s.isValidVariableToTrack(root, assignedVariable, content)) {
+				continue
+			}
+
+			s.logger.PrintInfo("Value of '%s' assigned to '%s' at line %d", variable, assignedVariable, line)
+			assignmentStep := models.DataFlowStep{
+				Line:       line,
+				Type:       "Assignment of value",
+				Function:   nodeService.FindParentFunction(s.language, node, content),
+				Value:      value,
+				Variable:   assignedVariable,
+				Candidates: candidates,
+			}
+			dataFlow = append(dataFlow, assignmentStep)
+			variablesToTrack[assignedVariable] = true
+			s.trackOccurrences(node, content, assignedVariable)
+			if assignedVariable != variable && hasOwnValues(pairs) {
+				s.markPaired(node, assignedVariable)
+			}
+
+			if assignedVariable != variable {
+				sourceStep := assignmentStep
+				sourceStep.Variable = variable
+				s.recordEdge(sourceStep, assignmentStep, models.EdgeKindAssignment)
+			}
+		}
+
+		if overwritten {
+			s.logger.PrintInfo("Variable '%s' overwritten at line %d, stopping its analysis.", variable, line)
+			delete(variablesToTrack, variable)
+			return dataFlow
+		}
+	}
+
+	// 2. Check if the variable is passed to a function call
+	functionCall, _ := nodeService.IsFunctionCall(s.language, node, content, variable)
+	if functionCall {
+		dataFlow = append(dataFlow, s.followArgumentIntoCallee(root, node, content, variable, visitedLines, visitedFunctions, variablesToTrack)...)
+	}
+
+	// 3. Check if the node is a control structure
+	controlType := nodeService.GetControlType(s.language, node)
+	if controlType != "" && nodeService.IsVariableUsedInExpression(s.language, node, variable, content) {
+		functionName := nodeService.FindParentFunction(s.language, node, content)
+		isReturn := nodeService.IsReturnNode(s.language, node)
+		controlStep := models.DataFlowStep{
+			Line:     line,
+			Type:     controlType,
+			Function: functionName,
+			Value:    variable,
+			Variable: variable,
+		}
+		// The value returned by a candidate of an ambiguous call goes back to that call
+		if isReturn && len(s.forwardCallStack) > 0 && s.forwardCallStack[len(s.forwardCallStack)-1].functionName == functionName {
+			controlStep.Candidates = s.forwardCallStack[len(s.forwardCallStack)-1].candidates
+		}
+		dataFlow = append(dataFlow, controlStep)
+		s.logger.PrintInfo("Variable '%s' used in %s at line %d within function '%s'", variable, controlType, line, functionName)
+
+		// 4. The returned value goes back to the callers of the function
+		if isReturn {
+			dataFlow = append(dataFlow, s.followReturnToCallers(root, nodeService.FindEnclosingFunctionNode(s.language, node), content, functionName, controlStep, visitedLines, visitedFunctions)...)
+		}
+	}
+
+	// 5. Analyze the children of the node
+	for i := 0; i < int(node.ChildCount()); i++ {
+		child := node.Child(i)
+		dataFlow = append(dataFlow, s.analyzeNodeForward(
+			root, child, content, variable,
+			visitedLines, visitedFunctions, variablesToTrack, startLine)...)
+	}
+
+	return dataFlow
+}
+
+// -----------------------------------------------------------------------------
+// followArgumentIntoCallee - Follows a variable passed as an argument into the called function.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - root (*sitter.Node): The root node of the syntax tree.
+//   - callNode (*sitter.Node): The function call node.
+//   - content ([]byte): The content of the source code.
+//   - variable (string): The variable passed as an argument.
+//   - visitedLines (map[uint32]bool): A map to keep track of visited lines.
+//   - visitedFunctions (map[string]*models.VisitInfo): A map to keep track of visited functions.
+//   - variablesToTrack (map[string]bool): The variables tracked at the call site.
+//
+// Returns:
+//   - ([]models.DataFlowStep): The steps found at the call site and inside the called function.
+//
+// -----------------------------------------------------------------------------
+func (s *Session) followArgumentIntoCallee(
+	root, callNode *sitter.Node,
+	content []byte,
+	variable string,
+	visitedLines map[uint32]bool,
+	visitedFunctions map[string]*models.VisitInfo,
+	variablesToTrack map[string]bool,
+) []models.DataFlowStep {
+	var dataFlow []models.DataFlowStep
+	line := callNode.StartPoint().Row + 1
+	methodName := nodeService.ExtractFunctionNameFromCall(s.language, callNode, content)
+	parentFunction := nodeService.FindParentFunction(s.language, callNode, content)
+
+	if visitedFunctions[methodName] == nil {
+		visitedFunctions[methodName] = &models.VisitInfo{
+			VisitedCalls: make(map[int]bool),
+		}
+	}
+	if visitedFunctions[methodName].VisitedCalls[int(line)] {
+		s.logger.PrintInfo("Skipping previously visited function '%s' for variable '%s'", methodName, variable)
+		return dataFlow
+	}
+	visitedFunctions[methodName].VisitedCalls[int(line)] = true
+
+	// Find the called functions, the methods of the type of the receiver, in the current file first and then in the other project files
+	functions, candidates := s.resolveCall(root, callNode, content, nil)
+	calledName := nodeService.GetCalledFunctionName(s.language, callNode, content)
+
+	s.logger.PrintInfo("Variable '%s' passed to function '%s' at line %d", variable, methodName, line)
+	callStep := models.DataFlowStep{
+		Line:       line,
+		Type:       "Function parameters",
+		Method:     methodName,
+		Function:   parentFunction,
+		Value:      variable,
+		Variable:   variable,
+		Candidates: candidates,
+	}
+	dataFlow = append(dataFlow, callStep)
+
+	if methodName == parentFunction || s.isVisiting(functions) {
+		s.logger.PrintInfo("Function '%s' has already been visited. Skipping to prevent infinite recursion.", methodName)
+		return dataFlow
+	}
+	if len(functions) == 0 {
+		s.logger.PrintInfo("Function '%s' not found, its parameters are not followed.", methodName)
+		return dataFlow
+	}
+
+	// An ambiguous call is followed into each of its candidates
+	for _, function := range functions {
+		calleeNode, calleeContent, calleeFile := function.node, function.content, function.file
+		if calleeFile == nil && containsNode(calleeNode, callNode) {
+			s.logger.PrintInfo("Skipping recursive call to function '%s' at line %d", methodName, line)
+			continue
+		}
+
+		paramVariable := s.accessPath(nodeService.GetParameterNameFromContents(s.language, calleeNode, calleeContent, variable, callNode, content))
+		if paramVariable == "" {
+			continue
+		}
+		s.logger.PrintInfo("Following variable '%s' as '%s' inside function '%s'", variable, paramVariable, methodName)
+		s.trackParameter(calleeNode, calleeContent, paramVariable)
+		s.recordEdge(callStep, parameterStep(calleeNode.StartPoint().Row+1, calledName, paramVariable, calleeFile), models.EdgeKindArgument)
+
+		s.visitedFunctionStack = append(s.visitedFunctionStack, function.key())
+		s.forwardCallStack = append(s.forwardCallStack, forwardCallContext{
+			functionName:     calledName,
+			callNode:         callNode,
+			content:          content,
+			file:             s.currentFile,
+			variablesToTrack: variablesToTrack,
+			candidates:       candidates,
+		})
+		newVariablesToTrack := map[string]bool{paramVariable: true}
+		calleeStart := calleeNode.StartPoint().Row + 1
+		if calleeFile != nil {
+			dataFlow = append(dataFlow, s.crawlInFile(calleeFile, calleeNode, newVariablesToTrack, calleeStart, false, visitedFunctions)...)
+		} else {
+			dataFlow = append(dataFlow, s.CrawlFromLine(root, calleeNode, content, newVariablesToTrack, calleeStart, false, visitedLines, visitedFunctions)...)
+		}
+		s.forwardCallStack = s.forwardCallStack[:len(s.forwardCallStack)-1]
+		s.visitedFunctionStack = s.visitedFunctionStack[:len(s.visitedFunctionStack)-1]
+	}
+
+	return dataFlow
+}
+
+// -----------------------------------------------------------------------------
+// followReturnToCallers - Follows a returned value into the variables assigned at each call site.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - root (*sitter.Node): The root node of the syntax tree.
+//   - functionNode (*sitter.Node): The declaration of the function returning the value.
+//   - content ([]byte): The content of the source code.
+//   - functionName (string): The name of the function returning the value.
+//   - returnStep (models.DataFlowStep): The step of the return statement.
+//   - visitedLines (map[uint32]bool): A map to keep track of visited lines.
+//   - visitedFunctions (map[string]*models.VisitInfo): A map to keep track of visited functions.
+//
+// Returns:
+//   - ([]models.DataFlowStep): The steps found at the call sites and after them.
+//
+// -----------------------------------------------------------------------------
+func (s *Session) followReturnToCallers(
+	root, functionNode *sitter.Node,
+	content []byte,
+	functionName string,
+	returnStep models.DataFlowStep,
+	visitedLines map[uint32]bool,
+	visitedFunctions map[string]*models.VisitInfo,
+) []models.DataFlowStep {
+	var dataFlow []models.DataFlowStep
+	if functionName == "" {
+		return dataFlow
+	}
+
+	// When the function was entered from a call site, the value only goes back to that call site
+	if len(s.forwardCallStack) > 0 && s.forwardCallStack[len(s.forwardCallStack)-1].functionName == functionName {
+		return append(dataFlow, s.returnToCallContext(s.forwardCallStack[len(s.forwardCallStack)-1], returnStep)...)
+	}
+
+	visitKey := functionName + "#return"
+	if visitedFunctions[visitKey] == nil {
+		visitedFunctions[visitKey] = &models.VisitInfo{
+			VisitedCalls: make(map[int]bool),
+		}
+	}
+
+	// Call sites of the current file, the calls to a method of another type being left out
+	for _, callSite := range nodeService.FindFunctionCallSites(s.language, root, functionName, content) {
+		functions, candidates := s.resolveCall(root, callSite.CallNode, content, nil)
+		if functionNode != nil && !containsFunction(functions, functionNode) {
+			continue
+		}
+		if visitedFunctions[visitKey].VisitedCalls[int(callSite.Line)] {
+			continue
+		}
+		visitedFunctions[visitKey].VisitedCalls[int(callSite.Line)] = true
+
+		assignedVariable := s.accessPath(nodeService.FindAssignedVariable(s.language, callSite.CallNode, content))
+		if assignedVariable == "" || !s.isValidVariableToTrack(root, assignedVariable, content) {
+			s.logger.PrintInfo("Value returned by '%s' is not assigned at line %d.", functionName, callSite.Line)
+			continue
+		}
+
+		s.logger.PrintInfo("Value returned by '%s' assigned to '%s' at line %d", functionName, assignedVariable, callSite.Line)
+		assignmentStep := models.DataFlowStep{
+			Line:       callSite.Line,
+			Type:       "Assignment of value",
+			Function:   nodeService.FindParentFunction(s.language, callSite.CallNode, content),
+			Value:      nodeService.SafeContent(callSite.CallNode, content),
+			Variable:   assignedVariable,
+			Candidates: candidates,
+		}
+		dataFlow = append(dataFlow, assignmentStep)
+		s.recordEdge(returnStep, assignmentStep, models.EdgeKindReturn)
+		s.trackOccurrencesAtLine(root, content, assignedVariable, callSite.Line)
+		dataFlow = append(dataFlow, s.CrawlFromLine(root, callSite.CallNode, content, map[string]bool{assignedVariable: true}, callSite.Line, false, visitedLines, visitedFunctions)...)
+	}
+
+	// Call sites located in the other project files
+	if s.project != nil {
+		for _, projectCallSite := range projectService.FindCallSites(s.project, functionName, s.currentFile, s.logger) {
+			functions, candidates := s.resolveCall(projectCallSite.File.Root, projectCallSite.CallSite.CallNode, projectCallSite.File.Content, projectCallSite.File)
+			if functionNode != nil && !containsFunction(functions, functionNode) {
+				continue
+			}
+			fileVisitKey := visitKey + "@" + projectCallSite.File.Path
+			if visitedFunctions[fileVisitKey] == nil {
+				visitedFunctions[fileVisitKey] = &models.VisitInfo{
+					VisitedCalls: make(map[int]bool),
+				}
+			}
+
+			callLine := projectCallSite.CallSite.Line
+			if visitedFunctions[fileVisitKey].VisitedCalls[int(callLine)] {
+				continue
+			}
+			visitedFunctions[fileVisitKey].VisitedCalls[int(callLine)] = true
+
+			fileContent := projectCallSite.File.Content
+			assignedVariable := s.accessPath(nodeService.FindAssignedVariable(s.language, projectCallSite.CallSite.CallNode, fileContent))
+			if assignedVariable == "" || !s.isValidVariableToTrack(projectCallSite.File.Root, assignedVariable, fileContent) {
+				s.logger.PrintInfo("Value returned by '%s' is not assigned at line %d of '%s'.", functionName, callLine, projectCallSite.File.Path)
+				continue
+			}
+
+			assignmentStep := models.DataFlowStep{
+				Line:       callLine,
+				Type:       "Assignment of value",
+				Function:   nodeService.FindParentFunction(s.language, projectCallSite.CallSite.CallNode, fileContent),
+				Value:      nodeService.SafeContent(projectCallSite.CallSite.CallNode, fileContent),
+				Variable:   assignedVariable,
+				FilePath:   projectCallSite.File.Path,
+				Candidates: candidates,
+			}
+			dataFlow = append(dataFlow, assignmentStep)
+			s.recordEdge(returnStep, assignmentStep, models.EdgeKindReturn)
+			s.trackOccurrencesAtLine(projectCallSite.File.Root, fileContent, assignedVariable, callLine)
+			dataFlow = append(dataFlow, s.crawlInFile(projectCallSite.File, projectCallSite.CallSite.CallNode, map[string]bool{assignedVariable: true}, callLine, false, visitedFunctions)...)
+		}
+	}
+
+	return dataFlow
+}
+
+// -----------------------------------------------------------------------------
+// returnToCallContext - Assigns a returned value to the variable of the call site the function was entered from.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - context (forwardCallContext): The call site the function was entered from.
+//   - returnStep (models.DataFlowStep): The step of the return statement.
+//
+// Returns:
+//   - ([]models.DataFlowStep): The assignment step at the call site, if the returned value is assigned.
+//
+// -----------------------------------------------------------------------------
+func (s *Session) returnToCallContext(context forwardCallContext, returnStep models.DataFlowStep) []models.DataFlowStep {
+	var dataFlow []models.DataFlowStep
+	callLine := context.callNode.StartPoint().Row + 1
+
+	assignedVariable := s.accessPath(nodeService.FindAssignedVariable(s.language, context.callNode, context.content))
+	if assignedVariable == "" {
+		s.logger.PrintInfo("Value returned by '%s' is not assigned at line %d.", context.functionName, callLine)
+		return dataFlow
+	}
+
+	s.logger.PrintInfo("Value returned by '%s' assigned to '%s' at line %d", context.functionName, assignedVariable, callLine)
+	step := models.DataFlowStep{
+		Line:       callLine,
+		Type:       "Assignment of value",
+		Function:   nodeService.FindParentFunction(s.language, context.callNode, context.content),
+		Value:      nodeService.SafeContent(context.callNode, context.content),
+		Variable:   assignedVariable,
+		Candidates: context.candidates,
+	}
+	if context.file != nil {
+		step.FilePath = context.file.Path
+	}
+
+	s.recordEdge(returnStep, step, models.EdgeKindReturn)
+
+	// The caller keeps analyzing the following lines with the assigned variable
+	context.variablesToTrack[assignedVariable] = true
+	s.trackOccurrencesAtLine(treeRoot(context.callNode), context.content, assignedVariable, callLine)
+	return append(dataFlow, step)
+}
+
+// -----------------------------------------------------------------------------
+// isAnyVariableTracked - Checks if one of the given variables is currently tracked.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - variables ([]string): The variables to check.
+//   - variablesToTrack (map[string]bool): The tracked variables.
+//
+// Returns:
+//   - (bool): True if at least one variable is tracked, or is an object holding a tracked field or a field of a tracked object.
+//
+// -----------------------------------------------------------------------------
+func isAnyVariableTracked(variables []string, variablesToTrack map[string]bool) bool {
+	for _, variable := range variables {
+		if variablesToTrack[variable] {
+			return true
+		}
+		for trackedVariable := range variablesToTrack {
+			if nodeService.AccessPathsOverlap(variable, trackedVariable) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// -----------------------------------------------------------------------------
+// isOverwritten - Checks if an assignment replaces the whole value of a tracked variable or access path with an untracked value.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - pairs ([]models.AssignmentPair): The targets of the assignment and the variables their values are read from.
+//   - variable (string): The tracked variable or access path.
+//   - variablesToTrack (map[string]bool): The tracked variables.
+//
+// Returns:
+//   - (bool): True if the variable or an object holding it is assigned (req or req.Path for req.Path) a value read from
+//     untracked variables only, false if only one of its fields is.
+//
+// -----------------------------------------------------------------------------
+func isOverwritten(pairs []models.AssignmentPair, variable string, variablesToTrack map[string]bool) bool {
+	for _, pair := range pairs {
+		if nodeService.IsAccessPathPrefix(pair.Target, variable) && !isAnyVariableTracked(pair.Sources, variablesToTrack) {
+			return true
+		}
+	}
+	return false
+}
+
+// -----------------------------------------------------------------------------
+// isInsideConditionalBranch - Checks if a node is only executed on some paths of its function.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - root (*sitter.Node): The root node of the syntax tree.
+//   - node (*sitter.Node): The node to check.
+//   - content ([]byte): The content of the source code.
+//
+// Returns:
+//   - (bool): True if the node is nested in a condition, a loop, a switch or an exception handler.
+//
+// -----------------------------------------------------------------------------
+func isInsideConditionalBranch(language string, root, node *sitter.Node, content []byte) bool {
+	for parent := node.Parent(); parent != nil; parent = parent.Parent() {
+		if nodeService.IsFunctionDeclaration(language, root, parent, content) != "" {
+			return false
+		}
+
+		switch nodeService.GetControlType(language, parent) {
+		case "Variable used in 'if' condition",
+			"Variable used in loop condition",
+			"Variable used in 'while' loop condition",
+			"Variable used in 'switch' or 'match' statement",
+			"Variable used in exception handling":
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package crawler
+
+import (
+	"dataflow/models"
+)
+
+// -----------------------------------------------------------------------------
+// recordEdge - Records that the value of a step flows into another step.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - from (models.DataFlowStep): The step the value comes from.
+//   - to (models.DataFlowStep): The step the value goes to.
+//   - kind (string): The kind of the edge (assignment, argument, return or global).
+//
+// Returns:
+//   - None
+//
+// -----------------------------------------------------------------------------
+func (s *Session) recordEdge(from, to models.DataFlowStep, kind string) {
+	// Steps without a file belong to the file currently crawled
+	if s.currentFile != nil {
+		if from.FilePath == "" {
+			from.FilePath = s.currentFile.Path
+		}
+		if to.FilePath == "" {
+			to.FilePath = s.currentFile.Path
+		}
+	}
+
+	s.logger.PrintDebug("Edge '%s' recorded from '%s' at line %d to '%s' at line %d", kind, from.Variable, from.Line, to.Variable, to.Line)
+	s.edges = append(s.edges, models.DataFlowEdge{
+		From: from,
+		To:   to,
+		Kind: kind,
+	})
+}
+
+// -----------------------------------------------------------------------------
+// parameterStep - Creates the step of a function parameter receiving an argument.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - functionLine (uint32): The line of the function declaration.
+//   - functionName (string): The name of the function.
+//   - parameter (string): The name of the parameter.
+//   - file (*models.SourceFile): The file declaring the function, or nil for the current file.
+//
+// Returns:
+//   - (models.DataFlowStep): The step of the parameter, located on the function declaration line.
+//
+// -----------------------------------------------------------------------------
+func parameterStep(functionLine uint32, functionName, parameter string, file *models.SourceFile) models.DataFlowStep {
+	step := models.DataFlowStep{
+		Line:     functionLine,
+		Type:     "Function Declaration",
+		Function: functionName,
+		Value:    parameter,
+		Variable: parameter,
+	}
+	if file != nil {
+		step.FilePath = file.Path
+	}
+	return step
+}
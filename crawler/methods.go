@@ -0,0 +1,268 @@
+package crawler
+
+import (
+	"dataflow/models"
+	"dataflow/services/nodeService"
+	"dataflow/services/projectService"
+	"dataflow/services/utilityService"
+	"fmt"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// calledFunction - A function declaration a call may refer to, with the file declaring it
+type calledFunction struct {
+	node     *sitter.Node
+	content  []byte
+	language string
+	file     *models.SourceFile // File declaring the function, nil for the file currently crawled
+}
+
+// -----------------------------------------------------------------------------
+// resolveCall - Finds the declarations a function or a method call refers to, from the type of its receiver.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - root (*sitter.Node): The root node of the syntax tree of the file containing the call.
+//   - callNode (*sitter.Node): The function call node.
+//   - content ([]byte): The content of the file containing the call.
+//   - callFile (*models.SourceFile): The project file containing the call, or nil for the file currently crawled.
+//
+// Returns:
+//   - ([]calledFunction): The functions the call refers to: the methods of the type of the receiver, or all the
+//     methods of that name when the type is not known. The declarations of the file come first.
+//   - ([]string): The candidates of an ambiguous call (Store.Open (line 12)), nil when the call refers to one function.
+//
+// -----------------------------------------------------------------------------
+func (s *Session) resolveCall(root, callNode *sitter.Node, content []byte, callFile *models.SourceFile) ([]calledFunction, []string) {
+	name := nodeService.GetCalledFunctionName(s.language, callNode, content)
+	if name == "" {
+		return nil, nil
+	}
+
+	// The declarations of the file come first, then the declarations of the other project files
+	var candidates []calledFunction
+	for _, functionNode := range nodeService.FindFunctionDeclarations(s.language, root, name, content) {
+		candidates = append(candidates, calledFunction{node: functionNode, content: content, language: s.language, file: callFile})
+	}
+	excludedFile := callFile
+	if excludedFile == nil {
+		excludedFile = s.currentFile
+	}
+	for _, location := range projectService.FindFunctions(s.project, name, excludedFile) {
+		candidates = append(candidates, calledFunction{node: location.Node, content: location.File.Content, language: location.File.Language, file: location.File})
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	receiverType, hasReceiver := nodeService.InferReceiverType(s.language, callNode, content)
+	var matched []calledFunction
+	switch {
+	case !hasReceiver:
+		// A call without receiver calls a function, or a method of the current object (read(path) in a Java method)
+		matched = filterByOwner(candidates, "")
+		if len(matched) == 0 {
+			matched = filterByOwner(candidates, nodeService.GetEnclosingMethodOwner(s.language, callNode, content))
+		}
+		if len(matched) == 0 {
+			matched = candidates
+		}
+	case receiverType != "":
+		// The methods of the type, or the functions of a module (os.Open)
+		matched = filterByOwner(candidates, receiverType)
+		if len(matched) == 0 {
+			matched = filterByOwner(candidates, "")
+		}
+		if len(matched) == 0 {
+			s.logger.PrintInfo("No method '%s' declared for the type '%s' of the receiver at line %d", name, receiverType, callNode.StartPoint().Row+1)
+			return nil, nil
+		}
+	default:
+		matched = candidates
+	}
+
+	// Overloads receiving a different number of arguments
+	var accepting []calledFunction
+	for _, function := range matched {
+		if nodeService.AcceptsArguments(s.language, function.node, function.content, callNode, content) {
+			accepting = append(accepting, function)
+		}
+	}
+	if len(accepting) > 0 {
+		matched = accepting
+	}
+
+	// Functions of the same name in several files are resolved to the closest one, as before any method
+	if len(matched) == 1 || len(filterByOwner(matched, "")) == len(matched) {
+		return matched[:1], nil
+	}
+
+	var labels []string
+	for _, function := range matched {
+		labels = append(labels, function.label(name))
+	}
+	s.logger.PrintWarning("Ambiguous call to '%s' at line %d, following all its candidates: %s", name, callNode.StartPoint().Row+1, strings.Join(labels, ", "))
+	return matched, labels
+}
+
+// -----------------------------------------------------------------------------
+// callsFunction - Checks if a call refers to a function declaration.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - root (*sitter.Node): The root node of the syntax tree of the file containing the call.
+//   - callNode (*sitter.Node): The function call node.
+//   - content ([]byte): The content of the file containing the call.
+//   - callFile (*models.SourceFile): The project file containing the call, or nil for the file currently crawled.
+//   - functionNode (*sitter.Node): The function declaration node.
+//
+// Returns:
+//   - (bool): True if the function is one of the declarations the call is resolved to (a.Open() does not call the Open
+//     of another type than the type of a).
+//
+// -----------------------------------------------------------------------------
+func (s *Session) callsFunction(root, callNode *sitter.Node, content []byte, callFile *models.SourceFile, functionNode *sitter.Node) bool {
+	functions, _ := s.resolveCall(root, callNode, content, callFile)
+	return containsFunction(functions, functionNode)
+}
+
+// -----------------------------------------------------------------------------
+// containsFunction - Checks if a function declaration is one of the functions a call is resolved to.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - functions ([]calledFunction): The functions the call is resolved to.
+//   - functionNode (*sitter.Node): The function declaration node.
+//
+// Returns:
+//   - (bool): True if the declaration is one of the functions.
+//
+// -----------------------------------------------------------------------------
+func containsFunction(functions []calledFunction, functionNode *sitter.Node) bool {
+	for _, function := range functions {
+		if function.node.Equal(functionNode) {
+			return true
+		}
+	}
+	return false
+}
+
+// -----------------------------------------------------------------------------
+// filterByOwner - Keeps the functions declared for a type.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - functions ([]calledFunction): The functions to filter.
+//   - owner (string): The type of the methods to keep, empty to keep the functions declared outside of a type.
+//
+// Returns:
+//   - ([]calledFunction): The functions declared for the type, in the same order.
+//
+// -----------------------------------------------------------------------------
+func filterByOwner(functions []calledFunction, owner string) []calledFunction {
+	var filtered []calledFunction
+	for _, function := range functions {
+		if nodeService.GetMethodOwner(function.language, function.node, function.content) == owner {
+			filtered = append(filtered, function)
+		}
+	}
+	return filtered
+}
+
+// -----------------------------------------------------------------------------
+// label - Describes a function a call may refer to.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - name (string): The name of the function.
+//
+// Returns:
+//   - (string): The type and the name of the function with its location (Store.Open (line 12), Disk.Open
+//     (disk.go:8)).
+//
+// -----------------------------------------------------------------------------
+func (f calledFunction) label(name string) string {
+	if owner := nodeService.GetMethodOwner(f.language, f.node, f.content); owner != "" {
+		name = owner + "." + name
+	}
+	line := f.node.StartPoint().Row + 1
+	if f.file != nil {
+		return fmt.Sprintf("%s (%s:%d)", name, f.file.Path, line)
+	}
+	return fmt.Sprintf("%s (line %d)", name, line)
+}
+
+// -----------------------------------------------------------------------------
+// key - Returns the key of a function in the stack of the visited functions.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - None
+//
+// Returns:
+//   - (string): The name of the function, prefixed by its type for a method (Disk.open).
+//
+// -----------------------------------------------------------------------------
+func (f calledFunction) key() string {
+	return functionKey(f.language, f.node, f.content)
+}
+
+// -----------------------------------------------------------------------------
+// functionKey - Returns the key of a function declaration in the stack of the visited functions.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the file declaring the function.
+//   - functionNode (*sitter.Node): The function declaration node.
+//   - content ([]byte): The content of the file declaring the function.
+//
+// Returns:
+//   - (string): The name of the function, prefixed by its type for a method, so that the methods of the same name
+//     declared for different types are visited separately (Disk.open, Cache.open).
+//
+// -----------------------------------------------------------------------------
+func functionKey(language string, functionNode *sitter.Node, content []byte) string {
+	name := nodeService.IsFunctionDeclaration(language, nil, functionNode, content)
+	if owner := nodeService.GetMethodOwner(language, functionNode, content); owner != "" {
+		return owner + "." + name
+	}
+	return name
+}
+
+// -----------------------------------------------------------------------------
+// isVisiting - Checks if one of the functions a call refers to is being visited.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - functions ([]calledFunction): The functions the call refers to.
+//
+// Returns:
+//   - (bool): True if the stack of the visited functions holds one of them.
+//
+// -----------------------------------------------------------------------------
+func (s *Session) isVisiting(functions []calledFunction) bool {
+	for _, function := range functions {
+		if utilityService.ContainsString(s.visitedFunctionStack, function.key()) {
+			return true
+		}
+	}
+	return false
+}
+
+// -----------------------------------------------------------------------------
+// containsNode - Checks if a node is inside another node of the same file.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - outer (*sitter.Node): The enclosing node (a function declaration).
+//   - inner (*sitter.Node): The node to locate (a call).
+//
+// Returns:
+//   - (bool): True if the inner node lies within the outer node (a recursive call in its function).
+//
+// -----------------------------------------------------------------------------
+func containsNode(outer, inner *sitter.Node) bool {
+	return outer.StartByte() <= inner.StartByte() && inner.EndByte() <= outer.EndByte()
+}
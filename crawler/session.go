@@ -0,0 +1,154 @@
+package crawler
+
+import (
+	"dataflow/logger"
+	"dataflow/models"
+	"dataflow/services/nodeService"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// Session holds the state of one analysis, so that several analyses can run concurrently
+type Session struct {
+	language string
+	logger   *logger.Logger
+
+	// Number of fields and keys kept in the tracked access paths, 0 to track whole objects
+	fieldDepth int
+
+	// Project mode state: the project being analyzed, the file currently crawled and the lines visited in each file
+	project             *models.Project
+	currentFile         *models.SourceFile
+	projectVisitedLines map[*models.SourceFile]map[uint32]bool
+
+	// Lines and functions already analyzed in the start file
+	visitedLines     map[uint32]bool
+	visitedFunctions map[string]*models.VisitInfo
+
+	// Functions currently entered by the analysis, and the call sites the forward analysis entered them through
+	visitedFunctionStack []string
+	forwardCallStack     []forwardCallContext
+
+	// Edges found between data flow steps
+	edges []models.DataFlowEdge
+
+	// Functions whose parameters were reported, and default parameter values already reported, by function and parameter
+	reportedParameters map[string]bool
+
+	// Variables already mapped by a paired assignment (a, b = b, a), which the same assignment must not map back
+	pairedVariables map[pairedVariable]bool
+
+	// Symbol tables of the crawled files, and the declarations of the tracked variables by name
+	symbolTables        map[*sitter.Node]*models.SymbolTable
+	trackedDeclarations map[string][]*models.Declaration
+}
+
+// -----------------------------------------------------------------------------
+// NewSession - Creates the state of a new analysis.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the analyzed code.
+//   - log (*logger.Logger): The logger of the analysis.
+//   - project (*models.Project): The project to analyze, or nil to analyze a single file.
+//   - startFile (*models.SourceFile): The project file containing the start line, or nil to analyze a single file.
+//   - fieldDepth (int): The number of fields and keys kept in the tracked access paths (e.g. 1 for req.Path), 0 to track whole objects.
+//
+// Returns:
+//   - (*Session): The session, ready to crawl.
+//
+// -----------------------------------------------------------------------------
+func NewSession(language string, log *logger.Logger, project *models.Project, startFile *models.SourceFile, fieldDepth int) *Session {
+	session := &Session{
+		language:            language,
+		logger:              log,
+		fieldDepth:          fieldDepth,
+		project:             project,
+		currentFile:         startFile,
+		projectVisitedLines: make(map[*models.SourceFile]map[uint32]bool),
+		visitedLines:        make(map[uint32]bool),
+		visitedFunctions:    make(map[string]*models.VisitInfo),
+		reportedParameters:  make(map[string]bool),
+		pairedVariables:     make(map[pairedVariable]bool),
+		symbolTables:        make(map[*sitter.Node]*models.SymbolTable),
+		trackedDeclarations: make(map[string][]*models.Declaration),
+	}
+	if startFile != nil {
+		session.projectVisitedLines[startFile] = session.visitedLines
+	}
+	return session
+}
+
+// -----------------------------------------------------------------------------
+// Crawl - Performs data flow analysis starting from a line of the start file.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - root (*sitter.Node): The root node of the syntax tree.
+//   - node (*sitter.Node): The node where the analysis starts.
+//   - content ([]byte): The content of the file being analyzed.
+//   - variablesToTrack (map[string]bool): A map of variables to track during the analysis.
+//   - startLine (uint32): The line number to start the analysis from.
+//   - startFromEnd (bool): Flag indicating whether the analysis goes backward.
+//
+// Returns:
+//   - ([]models.DataFlowStep): A slice of data flow steps identified during the analysis.
+//
+// -----------------------------------------------------------------------------
+func (s *Session) Crawl(root, node *sitter.Node, content []byte, variablesToTrack map[string]bool, startLine uint32, startFromEnd bool) []models.DataFlowStep {
+	// The variables are the declarations their names refer to on the start line, followed up to the field depth of the session
+	trackedVariables := make(map[string]bool)
+	for variable := range variablesToTrack {
+		trackedVariables[s.accessPath(variable)] = true
+		s.trackOccurrencesAtLine(root, content, variable, startLine)
+	}
+	return s.CrawlFromLine(root, node, content, trackedVariables, startLine, startFromEnd, s.visitedLines, s.visitedFunctions)
+}
+
+// -----------------------------------------------------------------------------
+// accessPath - Cuts an access path to the field depth of the session.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - path (string): The variable or access path to track (e.g. req.Header["Host"]).
+//
+// Returns:
+//   - (string): The tracked path, which also holds the fields beyond the depth (e.g. req.Header with a depth of 1).
+//
+// -----------------------------------------------------------------------------
+func (s *Session) accessPath(path string) string {
+	if path == "" {
+		return path
+	}
+	return nodeService.TruncateAccessPath(path, s.fieldDepth)
+}
+
+// -----------------------------------------------------------------------------
+// Language - Returns the language of the analyzed code.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - None
+//
+// Returns:
+//   - (string): The language of the session.
+//
+// -----------------------------------------------------------------------------
+func (s *Session) Language() string {
+	return s.language
+}
+
+// -----------------------------------------------------------------------------
+// Edges - Returns the edges recorded by the session.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - None
+//
+// Returns:
+//   - ([]models.DataFlowEdge): The edges between data flow steps, in the order they were found.
+//
+// -----------------------------------------------------------------------------
+func (s *Session) Edges() []models.DataFlowEdge {
+	return s.edges
+}
@@ -0,0 +1,309 @@
+package crawler
+
+import (
+	"dataflow/models"
+	"dataflow/services/nodeService"
+	"dataflow/services/symbolService"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// -----------------------------------------------------------------------------
+// symbolTable - Returns the symbol table of a crawled file, building it on first use.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - root (*sitter.Node): The root node of the syntax tree of the file.
+//   - content ([]byte): The content of the file.
+//
+// Returns:
+//   - (*models.SymbolTable): The symbol table, or nil if the language has no scopes query.
+//
+// -----------------------------------------------------------------------------
+func (s *Session) symbolTable(root *sitter.Node, content []byte) *models.SymbolTable {
+	if table, exists := s.symbolTables[root]; exists {
+		return table
+	}
+	table := symbolService.BuildSymbolTable(s.language, root, content, s.logger)
+	s.symbolTables[root] = table
+	return table
+}
+
+// -----------------------------------------------------------------------------
+// trackOccurrences - Records the declarations of the occurrences of a tracked variable in a node.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - node (*sitter.Node): The node where the variable starts to be tracked (assignment, call site...).
+//   - content ([]byte): The content of the file containing the node.
+//   - variable (string): The tracked variable, or access path whose variable is recorded.
+//
+// Returns:
+//   - None
+//
+// -----------------------------------------------------------------------------
+func (s *Session) trackOccurrences(node *sitter.Node, content []byte, variable string) {
+	table := s.symbolTable(treeRoot(node), content)
+	if table == nil {
+		return
+	}
+	for _, occurrence := range symbolService.FindOccurrences(s.language, node, content, nodeService.AccessPathBase(variable)) {
+		s.trackDeclaration(symbolService.Resolve(table, occurrence))
+	}
+}
+
+// -----------------------------------------------------------------------------
+// trackOccurrencesAtLine - Records the declarations of the occurrences of a tracked variable on a line.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - root (*sitter.Node): The root node of the syntax tree of the file.
+//   - content ([]byte): The content of the file.
+//   - variable (string): The tracked variable, or access path whose variable is recorded.
+//   - line (uint32): The line where the variable starts to be tracked.
+//
+// Returns:
+//   - None
+//
+// -----------------------------------------------------------------------------
+func (s *Session) trackOccurrencesAtLine(root *sitter.Node, content []byte, variable string, line uint32) {
+	table := s.symbolTable(root, content)
+	if table == nil {
+		return
+	}
+	for _, occurrence := range symbolService.FindOccurrencesAtLine(s.language, root, content, nodeService.AccessPathBase(variable), line) {
+		s.trackDeclaration(symbolService.Resolve(table, occurrence))
+	}
+}
+
+// -----------------------------------------------------------------------------
+// trackParameter - Records the declaration of the parameter of a function receiving a tracked variable.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - functionNode (*sitter.Node): The function declaration node.
+//   - content ([]byte): The content of the file containing the function.
+//   - parameter (string): The name of the parameter, or the access path of one of its fields.
+//
+// Returns:
+//   - None
+//
+// -----------------------------------------------------------------------------
+func (s *Session) trackParameter(functionNode *sitter.Node, content []byte, parameter string) {
+	root := treeRoot(functionNode)
+	table := s.symbolTable(root, content)
+	if table == nil {
+		return
+	}
+	if declaration := symbolService.FindDeclaration(table, functionNode, nodeService.AccessPathBase(parameter)); declaration != nil {
+		s.trackDeclaration(declaration)
+		return
+	}
+	s.trackOccurrencesAtLine(root, content, parameter, functionNode.StartPoint().Row+1)
+}
+
+// -----------------------------------------------------------------------------
+// trackDeclaration - Records that a declaration is one of the tracked variables.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - declaration (*models.Declaration): The declaration, or nil for a variable not declared in its file.
+//
+// Returns:
+//   - None
+//
+// -----------------------------------------------------------------------------
+func (s *Session) trackDeclaration(declaration *models.Declaration) {
+	if declaration == nil || containsDeclaration(s.trackedDeclarations[declaration.Name], declaration) {
+		return
+	}
+	s.logger.PrintDebug("Tracking the declaration of '%s' from line %d.", declaration.Name, declaration.Line)
+	s.trackedDeclarations[declaration.Name] = append(s.trackedDeclarations[declaration.Name], declaration)
+}
+
+// -----------------------------------------------------------------------------
+// mayReferToTrackedVariable - Checks if the occurrences of a name in a node can be the tracked variable.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - root (*sitter.Node): The root node of the syntax tree.
+//   - node (*sitter.Node): The node being analyzed.
+//   - content ([]byte): The content of the source code.
+//   - variable (string): The tracked variable or access path.
+//
+// Returns:
+//   - (bool): False if every occurrence of the name in the node is another variable shadowing a tracked declaration,
+//     true otherwise, and when the declarations of the name are unknown (the variable is then matched by name).
+//
+// -----------------------------------------------------------------------------
+func (s *Session) mayReferToTrackedVariable(root, node *sitter.Node, content []byte, variable string) bool {
+	// The fields of an access path belong to the declaration of its variable
+	variable = nodeService.AccessPathBase(variable)
+	if len(s.trackedDeclarations[variable]) == 0 {
+		return true
+	}
+	table := s.symbolTable(root, content)
+	if table == nil {
+		return true
+	}
+	tracked := trackedDeclarationsIn(table, s.trackedDeclarations[variable])
+	if len(tracked) == 0 {
+		return true
+	}
+
+	for _, occurrence := range symbolService.FindOccurrences(s.language, node, content, variable) {
+		declaration := symbolService.Resolve(table, occurrence)
+		if declaration == nil || containsDeclaration(tracked, declaration) || !shadowsDeclaration(declaration, tracked) {
+			return true
+		}
+	}
+	s.logger.PrintDebug("'%s' at line %d is another variable than the tracked one. Skipping the node.", variable, node.StartPoint().Row+1)
+	return false
+}
+
+// -----------------------------------------------------------------------------
+// isVariableInScope - Checks if a called function sees a tracked variable of its caller.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - variable (string): The tracked variable or access path.
+//   - functionNode (*sitter.Node): The declaration of the called function.
+//   - content ([]byte): The content of the file containing the function.
+//
+// Returns:
+//   - (bool): True if an occurrence of the name in the function refers to a tracked declaration,
+//     or if the variable is in the scope of the function when its declarations are unknown.
+//
+// -----------------------------------------------------------------------------
+func (s *Session) isVariableInScope(variable string, functionNode *sitter.Node, content []byte) bool {
+	variable = nodeService.AccessPathBase(variable)
+	table := s.symbolTable(treeRoot(functionNode), content)
+	if table == nil {
+		return nodeService.IsVariableInScope(s.language, variable, functionNode, content)
+	}
+	tracked := trackedDeclarationsIn(table, s.trackedDeclarations[variable])
+	if len(tracked) == 0 {
+		return nodeService.IsVariableInScope(s.language, variable, functionNode, content)
+	}
+
+	for _, occurrence := range symbolService.FindOccurrences(s.language, functionNode, content, variable) {
+		if containsDeclaration(tracked, symbolService.Resolve(table, occurrence)) {
+			return true
+		}
+	}
+	return false
+}
+
+// -----------------------------------------------------------------------------
+// isDeclaredAtLine - Checks if a name used on a line refers to a declaration of its file.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - root (*sitter.Node): The root node of the syntax tree of the file.
+//   - content ([]byte): The content of the file.
+//   - variable (string): The variable or access path.
+//   - line (uint32): The line where the name is used.
+//
+// Returns:
+//   - (bool): False if no occurrence of the name on the line resolves to a declaration, as for the imported packages
+//     (os in os.ReadFile), true otherwise and when the declarations of the file are unknown.
+//
+// -----------------------------------------------------------------------------
+func (s *Session) isDeclaredAtLine(root *sitter.Node, content []byte, variable string, line uint32) bool {
+	table := s.symbolTable(root, content)
+	if table == nil {
+		return true
+	}
+	occurrences := symbolService.FindOccurrencesAtLine(s.language, root, content, nodeService.AccessPathBase(variable), line)
+	if len(occurrences) == 0 {
+		return true
+	}
+	for _, occurrence := range occurrences {
+		if symbolService.Resolve(table, occurrence) != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// -----------------------------------------------------------------------------
+// trackedDeclarationsIn - Keeps the tracked declarations of a file.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - table (*models.SymbolTable): The symbol table of the file.
+//   - declarations ([]*models.Declaration): The tracked declarations of a name.
+//
+// Returns:
+//   - ([]*models.Declaration): The declarations belonging to the scopes of the file.
+//
+// -----------------------------------------------------------------------------
+func trackedDeclarationsIn(table *models.SymbolTable, declarations []*models.Declaration) []*models.Declaration {
+	var inTable []*models.Declaration
+	for _, declaration := range declarations {
+		if symbolService.IsScopeWithin(declaration.Scope, table.Root) {
+			inTable = append(inTable, declaration)
+		}
+	}
+	return inTable
+}
+
+// -----------------------------------------------------------------------------
+// shadowsDeclaration - Checks if a declaration hides one of the tracked declarations.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - declaration (*models.Declaration): The declaration of an occurrence.
+//   - tracked ([]*models.Declaration): The tracked declarations of the same name.
+//
+// Returns:
+//   - (bool): True if the declaration is in the function of a tracked declaration or in a function nested in it.
+//
+// -----------------------------------------------------------------------------
+func shadowsDeclaration(declaration *models.Declaration, tracked []*models.Declaration) bool {
+	for _, trackedDeclaration := range tracked {
+		if symbolService.IsScopeWithin(declaration.Scope, symbolService.FunctionScope(trackedDeclaration.Scope)) {
+			return true
+		}
+	}
+	return false
+}
+
+// -----------------------------------------------------------------------------
+// containsDeclaration - Checks if a declaration is in a list.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - declarations ([]*models.Declaration): The list of declarations.
+//   - declaration (*models.Declaration): The declaration to find.
+//
+// Returns:
+//   - (bool): True if the declaration is in the list.
+//
+// -----------------------------------------------------------------------------
+func containsDeclaration(declarations []*models.Declaration, declaration *models.Declaration) bool {
+	for _, current := range declarations {
+		if current == declaration {
+			return true
+		}
+	}
+	return false
+}
+
+// -----------------------------------------------------------------------------
+// treeRoot - Returns the root of the syntax tree containing a node.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - node (*sitter.Node): The node.
+//
+// Returns:
+//   - (*sitter.Node): The root node of its syntax tree.
+//
+// -----------------------------------------------------------------------------
+func treeRoot(node *sitter.Node) *sitter.Node {
+	for node.Parent() != nil {
+		node = node.Parent()
+	}
+	return node
+}
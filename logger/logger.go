@@ -1,16 +1,76 @@
 package logger
 
+import "log"
+
+// Process-wide logging functions, used by the stateless services. Errors are printed until Setup is called.
 var (
-    PrintInfo   func(format string, v ...interface{})
-    PrintWarning func(format string, v ...interface{})
-    PrintError   func(format string, v ...interface{})
-    PrintDebug   func(format string, v ...interface{})
+	PrintInfo    func(format string, v ...interface{}) = discard
+	PrintWarning func(format string, v ...interface{}) = discard
+	PrintError   func(format string, v ...interface{}) = func(format string, v ...interface{}) { log.Printf("[ERROR] "+format+"\n", v...) }
+	PrintDebug   func(format string, v ...interface{}) = discard
 )
 
+// Output writes a log line, prefix included, for the loggers of the analyses. It is replaced by SetOutput.
+var Output func(format string, v ...interface{}) = func(format string, v ...interface{}) { log.Printf(format+"\n", v...) }
+
+// Logger holds the logging functions of one analysis, so that concurrent analyses can log with their own verbosity
+type Logger struct {
+	info    func(format string, v ...interface{})
+	warning func(format string, v ...interface{})
+	error   func(format string, v ...interface{})
+	debug   func(format string, v ...interface{})
+}
+
 // Setup initializes the logging functions
 func Setup(infoFunc, warningFunc, errorFunc, debugFunc func(format string, v ...interface{})) {
-    PrintInfo = infoFunc
-    PrintWarning = warningFunc
-    PrintError = errorFunc
-    PrintDebug = debugFunc
+	PrintInfo = infoFunc
+	PrintWarning = warningFunc
+	PrintError = errorFunc
+	PrintDebug = debugFunc
+}
+
+// SetOutput replaces the function writing the log lines of the loggers of the analyses
+func SetOutput(outputFunc func(format string, v ...interface{})) {
+	Output = outputFunc
+}
+
+// New creates a logger with its own logging functions, a nil function discards the messages
+func New(infoFunc, warningFunc, errorFunc, debugFunc func(format string, v ...interface{})) *Logger {
+	return &Logger{
+		info:    orDiscard(infoFunc),
+		warning: orDiscard(warningFunc),
+		error:   orDiscard(errorFunc),
+		debug:   orDiscard(debugFunc),
+	}
+}
+
+// PrintInfo logs an information message
+func (l *Logger) PrintInfo(format string, v ...interface{}) {
+	l.info(format, v...)
+}
+
+// PrintWarning logs a warning message
+func (l *Logger) PrintWarning(format string, v ...interface{}) {
+	l.warning(format, v...)
+}
+
+// PrintError logs an error message
+func (l *Logger) PrintError(format string, v ...interface{}) {
+	l.error(format, v...)
+}
+
+// PrintDebug logs a debug message
+func (l *Logger) PrintDebug(format string, v ...interface{}) {
+	l.debug(format, v...)
+}
+
+// discard ignores a message
+func discard(format string, v ...interface{}) {}
+
+// orDiscard returns the logging function, or discard if it is nil
+func orDiscard(logFunc func(format string, v ...interface{})) func(format string, v ...interface{}) {
+	if logFunc == nil {
+		return discard
+	}
+	return logFunc
 }
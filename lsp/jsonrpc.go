@@ -0,0 +1,119 @@
+package lsp
+
+import (
+	"dataflow/logger"
+	"dataflow/models"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// -----------------------------------------------------------------------------
+// readMessage - Reads the body of the next JSON-RPC message.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - None
+//
+// Returns:
+//   - ([]byte): The JSON body of the message.
+//   - (error): io.EOF at the end of the input, or an error if the headers are invalid.
+//
+// -----------------------------------------------------------------------------
+func (s *Server) readMessage() ([]byte, error) {
+	contentLength := -1
+
+	// Headers end with an empty line
+	for {
+		line, err := s.reader.ReadString('\n')
+		if err != nil {
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			break
+		}
+
+		name, value, found := strings.Cut(line, ":")
+		if found && strings.EqualFold(strings.TrimSpace(name), "Content-Length") {
+			contentLength, err = strconv.Atoi(strings.TrimSpace(value))
+			if err != nil {
+				return nil, fmt.Errorf("invalid Content-Length header: %v", err)
+			}
+		}
+	}
+
+	if contentLength < 0 {
+		return nil, fmt.Errorf("missing Content-Length header")
+	}
+
+	body := make([]byte, contentLength)
+	if _, err := io.ReadFull(s.reader, body); err != nil {
+		return nil, err
+	}
+	return body, nil
+}
+
+// -----------------------------------------------------------------------------
+// writeMessage - Writes a JSON-RPC message with its Content-Length header.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - response (models.LspResponse): The message to write.
+//
+// Returns:
+//   - None
+//
+// -----------------------------------------------------------------------------
+func (s *Server) writeMessage(response models.LspResponse) {
+	response.JSONRPC = "2.0"
+	body, err := json.Marshal(response)
+	if err != nil {
+		logger.PrintError("error encoding LSP response: %v", err)
+		return
+	}
+
+	if _, err := fmt.Fprintf(s.writer, "Content-Length: %d\r\n\r\n%s", len(body), body); err != nil {
+		logger.PrintError("error writing LSP response: %v", err)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// writeResult - Answers a request with a result.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - id (*json.RawMessage): The identifier of the request.
+//   - result (interface{}): The result, encoded as null when nil.
+//
+// Returns:
+//   - None
+//
+// -----------------------------------------------------------------------------
+func (s *Server) writeResult(id *json.RawMessage, result interface{}) {
+	body, err := json.Marshal(result)
+	if err != nil {
+		s.writeError(id, errorInternal, fmt.Sprintf("error encoding result: %v", err))
+		return
+	}
+	s.writeMessage(models.LspResponse{ID: id, Result: body})
+}
+
+// -----------------------------------------------------------------------------
+// writeError - Answers a request with an error.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - id (*json.RawMessage): The identifier of the request, or nil if it could not be read.
+//   - code (int): The JSON-RPC error code.
+//   - message (string): The error message.
+//
+// Returns:
+//   - None
+//
+// -----------------------------------------------------------------------------
+func (s *Server) writeError(id *json.RawMessage, code int, message string) {
+	s.writeMessage(models.LspResponse{ID: id, Error: &models.LspError{Code: code, Message: message}})
+}
@@ -0,0 +1,832 @@
+// Language Server Protocol server tracing variables from the editor: custom trace command, code lenses and call hierarchy.
+
+package lsp
+
+import (
+	"bufio"
+	"dataflow/core"
+	"dataflow/logger"
+	"dataflow/models"
+	"dataflow/services/languageService"
+	"dataflow/services/nodeService"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// Command executed by the editor to trace a variable
+const TraceCommand = "dataflow.trace"
+
+// JSON-RPC error codes
+const (
+	errorParse          = -32700
+	errorInvalidParams  = -32602
+	errorMethodNotFound = -32601
+	errorInternal       = -32603
+)
+
+// Symbol kind of the call hierarchy items (LSP SymbolKind.Variable)
+const symbolKindVariable = 13
+
+// document represents a text document opened in the editor.
+type document struct {
+	path     string
+	language string
+	content  []byte
+}
+
+// Server represents a language server connected to an editor.
+type Server struct {
+	reader      *bufio.Reader
+	writer      io.Writer
+	documents   map[string]*document
+	projectRoot string
+	shutdown    bool
+}
+
+// -----------------------------------------------------------------------------
+// NewServer - Creates a language server reading and writing JSON-RPC messages.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - reader (io.Reader): The input of the server (the standard input of the process).
+//   - writer (io.Writer): The output of the server (the standard output of the process).
+//
+// Returns:
+//   - (*Server): The language server.
+//
+// -----------------------------------------------------------------------------
+func NewServer(reader io.Reader, writer io.Writer) *Server {
+	return &Server{
+		reader:    bufio.NewReader(reader),
+		writer:    writer,
+		documents: make(map[string]*document),
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Run - Handles the messages of the editor until the exit notification or the end of the input.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - None
+//
+// Returns:
+//   - (error): An error object if a message could not be read.
+//
+// -----------------------------------------------------------------------------
+func (s *Server) Run() error {
+	for {
+		body, err := s.readMessage()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		var message models.LspMessage
+		if err := json.Unmarshal(body, &message); err != nil {
+			s.writeError(nil, errorParse, fmt.Sprintf("invalid message: %v", err))
+			continue
+		}
+
+		if message.Method == "exit" {
+			if !s.shutdown {
+				logger.PrintWarning("Exit notification received before the shutdown request")
+			}
+			return nil
+		}
+
+		result, rpcErr := s.handle(message)
+
+		// Notifications do not get any answer
+		if message.ID == nil {
+			continue
+		}
+		if rpcErr != nil {
+			s.writeError(message.ID, rpcErr.Code, rpcErr.Message)
+		} else {
+			s.writeResult(message.ID, result)
+		}
+	}
+}
+
+// -----------------------------------------------------------------------------
+// handle - Dispatches a message to the handler of its method.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - message (models.LspMessage): The request or notification.
+//
+// Returns:
+//   - (interface{}): The result of the request.
+//   - (*models.LspError): The error of the request, if any.
+//
+// -----------------------------------------------------------------------------
+func (s *Server) handle(message models.LspMessage) (interface{}, *models.LspError) {
+	logger.PrintDebug("LSP message '%s'", message.Method)
+
+	switch message.Method {
+	case "initialize":
+		return s.initialize(message.Params)
+	case "initialized", "$/cancelRequest", "$/setTrace":
+		return nil, nil
+	case "shutdown":
+		s.shutdown = true
+		return nil, nil
+	case "textDocument/didOpen":
+		var params models.LspDidOpenParams
+		if err := json.Unmarshal(message.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		s.openDocument(params.TextDocument)
+		return nil, nil
+	case "textDocument/didChange":
+		var params models.LspDidChangeParams
+		if err := json.Unmarshal(message.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		// Full synchronization: the last change holds the whole text
+		if doc := s.documents[params.TextDocument.URI]; doc != nil && len(params.ContentChanges) > 0 {
+			doc.content = []byte(params.ContentChanges[len(params.ContentChanges)-1].Text)
+		}
+		return nil, nil
+	case "textDocument/didClose":
+		var params models.LspCodeLensParams
+		if err := json.Unmarshal(message.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		delete(s.documents, params.TextDocument.URI)
+		return nil, nil
+	case "textDocument/codeLens":
+		var params models.LspCodeLensParams
+		if err := json.Unmarshal(message.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		return s.codeLenses(params.TextDocument.URI)
+	case "workspace/executeCommand":
+		var params models.LspExecuteCommandParams
+		if err := json.Unmarshal(message.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		return s.executeCommand(params)
+	case "textDocument/prepareCallHierarchy":
+		var params models.LspTextDocumentPositionParams
+		if err := json.Unmarshal(message.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		return s.prepareCallHierarchy(params)
+	case "callHierarchy/incomingCalls":
+		var params models.LspCallHierarchyCallsParams
+		if err := json.Unmarshal(message.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		return s.hierarchyCalls(params.Item, models.DirectionBackward)
+	case "callHierarchy/outgoingCalls":
+		var params models.LspCallHierarchyCallsParams
+		if err := json.Unmarshal(message.Params, &params); err != nil {
+			return nil, invalidParams(err)
+		}
+		return s.hierarchyCalls(params.Item, models.DirectionForward)
+	}
+
+	if message.ID == nil {
+		return nil, nil
+	}
+	return nil, &models.LspError{Code: errorMethodNotFound, Message: "method not found: " + message.Method}
+}
+
+// -----------------------------------------------------------------------------
+// initialize - Answers the initialize request with the capabilities of the server.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - params (json.RawMessage): The initialize parameters of the editor.
+//
+// Returns:
+//   - (interface{}): The initialize result.
+//   - (*models.LspError): The error of the request, if any.
+//
+// -----------------------------------------------------------------------------
+func (s *Server) initialize(params json.RawMessage) (interface{}, *models.LspError) {
+	var initializeParams struct {
+		RootURI               string `json:"rootUri"`
+		InitializationOptions struct {
+			Project bool `json:"project"`
+		} `json:"initializationOptions"`
+	}
+	if err := json.Unmarshal(params, &initializeParams); err != nil {
+		return nil, invalidParams(err)
+	}
+
+	// The cross-file analysis is enabled by the editor, on the workspace root
+	if initializeParams.InitializationOptions.Project && initializeParams.RootURI != "" {
+		s.projectRoot = uriToPath(initializeParams.RootURI)
+		logger.PrintInfo("Project mode enabled on '%s'", s.projectRoot)
+	}
+
+	return map[string]interface{}{
+		"capabilities": map[string]interface{}{
+			"textDocumentSync":       1,
+			"codeLensProvider":       map[string]bool{"resolveProvider": false},
+			"callHierarchyProvider":  true,
+			"executeCommandProvider": map[string][]string{"commands": {TraceCommand}},
+		},
+		"serverInfo": map[string]string{"name": "variable-dataflow-tracer"},
+	}, nil
+}
+
+// -----------------------------------------------------------------------------
+// openDocument - Keeps the content and the language of a document opened in the editor.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - item (models.LspTextDocumentItem): The opened document.
+//
+// Returns:
+//   - None
+//
+// -----------------------------------------------------------------------------
+func (s *Server) openDocument(item models.LspTextDocumentItem) {
+	path := uriToPath(item.URI)
+
+	content := []byte(item.Text)
+
+	language := languageService.LanguageFromEditorID(item.LanguageID)
+	if language == "" {
+		language = languageService.DetectLanguage(path, content)
+	}
+
+	s.documents[item.URI] = &document{
+		path:     path,
+		language: language,
+		content:  content,
+	}
+}
+
+// -----------------------------------------------------------------------------
+// getDocument - Returns an opened document, or reads it from the disk.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - uri (string): The URI of the document.
+//
+// Returns:
+//   - (*document): The document.
+//   - (*models.LspError): An error if the document could not be read or its language is not supported.
+//
+// -----------------------------------------------------------------------------
+func (s *Server) getDocument(uri string) (*document, *models.LspError) {
+	if doc := s.documents[uri]; doc != nil {
+		if doc.language == "" {
+			return nil, &models.LspError{Code: errorInvalidParams, Message: "unsupported language for " + uri}
+		}
+		return doc, nil
+	}
+
+	path := uriToPath(uri)
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, &models.LspError{Code: errorInvalidParams, Message: fmt.Sprintf("error reading file: %v", err)}
+	}
+
+	language := languageService.DetectLanguage(path, content)
+	if language == "" {
+		return nil, &models.LspError{Code: errorInvalidParams, Message: "unsupported language for " + uri}
+	}
+	return &document{path: path, language: language, content: content}, nil
+}
+
+// -----------------------------------------------------------------------------
+// codeLenses - Adds a "trace" code lens above every assignment of the document.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - uri (string): The URI of the document.
+//
+// Returns:
+//   - (interface{}): The code lenses of the document.
+//   - (*models.LspError): The error of the request, if any.
+//
+// -----------------------------------------------------------------------------
+func (s *Server) codeLenses(uri string) (interface{}, *models.LspError) {
+	doc, rpcErr := s.getDocument(uri)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	tree := languageService.ParseContent(doc.content, doc.language)
+	if tree == nil {
+		return []models.LspCodeLens{}, nil
+	}
+	lines := strings.Split(string(doc.content), "\n")
+
+	lenses := []models.LspCodeLens{}
+	seen := make(map[string]bool)
+	var traverse func(node *sitter.Node)
+	traverse = func(node *sitter.Node) {
+		lhsVars, _, isAssignment := nodeService.ExtractAssignmentVariables(doc.language, node, doc.content)
+		if isAssignment {
+			line := int(node.StartPoint().Row)
+			for _, variable := range lhsVars {
+				key := strconv.Itoa(line) + "\x00" + variable
+				if variable == "" || seen[key] {
+					continue
+				}
+				seen[key] = true
+
+				lensRange := variableRange(lines, line, variable)
+				lenses = append(lenses, models.LspCodeLens{
+					Range: lensRange,
+					Command: &models.LspCommand{
+						Title:   fmt.Sprintf("Trace '%s'", variable),
+						Command: TraceCommand,
+						Arguments: []interface{}{models.LspTraceArguments{
+							URI:       uri,
+							Line:      line,
+							Character: lensRange.Start.Character,
+							Variable:  variable,
+							Direction: models.DirectionForward,
+						}},
+					},
+				})
+			}
+		}
+
+		for i := 0; i < int(node.NamedChildCount()); i++ {
+			traverse(node.NamedChild(i))
+		}
+	}
+	traverse(tree.RootNode())
+
+	return lenses, nil
+}
+
+// -----------------------------------------------------------------------------
+// executeCommand - Executes the trace command and returns the steps as locations.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - params (models.LspExecuteCommandParams): The command and its arguments.
+//
+// Returns:
+//   - (interface{}): The locations of the data flow steps, in the order of the analysis.
+//   - (*models.LspError): The error of the request, if any.
+//
+// -----------------------------------------------------------------------------
+func (s *Server) executeCommand(params models.LspExecuteCommandParams) (interface{}, *models.LspError) {
+	if params.Command != TraceCommand {
+		return nil, &models.LspError{Code: errorInvalidParams, Message: "unknown command: " + params.Command}
+	}
+	if len(params.Arguments) == 0 {
+		return nil, &models.LspError{Code: errorInvalidParams, Message: "missing trace arguments"}
+	}
+
+	var arguments models.LspTraceArguments
+	if err := json.Unmarshal(params.Arguments[0], &arguments); err != nil {
+		return nil, invalidParams(err)
+	}
+
+	dataflow, rpcErr := s.trace(arguments)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	locations := []models.LspLocation{}
+	for _, step := range dataflow {
+		locations = append(locations, stepLocation(step))
+	}
+	return locations, nil
+}
+
+// -----------------------------------------------------------------------------
+// prepareCallHierarchy - Returns the variable under the cursor as the root of the call hierarchy.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - params (models.LspTextDocumentPositionParams): The document and the cursor position.
+//
+// Returns:
+//   - (interface{}): The call hierarchy item of the variable, or null when the cursor is not on a variable.
+//   - (*models.LspError): The error of the request, if any.
+//
+// -----------------------------------------------------------------------------
+func (s *Server) prepareCallHierarchy(params models.LspTextDocumentPositionParams) (interface{}, *models.LspError) {
+	doc, rpcErr := s.getDocument(params.TextDocument.URI)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	variable, variableRange := identifierAtPosition(doc, params.Position)
+	if variable == "" {
+		return nil, nil
+	}
+
+	return []models.LspCallHierarchyItem{{
+		Name:           variable,
+		Kind:           symbolKindVariable,
+		Detail:         fmt.Sprintf("line %d", params.Position.Line+1),
+		URI:            params.TextDocument.URI,
+		Range:          variableRange,
+		SelectionRange: variableRange,
+		Data: &models.LspTraceArguments{
+			URI:       params.TextDocument.URI,
+			Line:      params.Position.Line,
+			Character: params.Position.Character,
+			Variable:  variable,
+		},
+	}}, nil
+}
+
+// -----------------------------------------------------------------------------
+// hierarchyCalls - Answers the incoming (origins) and outgoing (uses) calls of a variable.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - item (models.LspCallHierarchyItem): The call hierarchy item of the variable.
+//   - direction (string): The direction of the analysis: backward for the incoming calls, forward for the outgoing calls.
+//
+// Returns:
+//   - (interface{}): The incoming or outgoing calls, one per data flow step.
+//   - (*models.LspError): The error of the request, if any.
+//
+// -----------------------------------------------------------------------------
+func (s *Server) hierarchyCalls(item models.LspCallHierarchyItem, direction string) (interface{}, *models.LspError) {
+	if item.Data == nil {
+		return nil, &models.LspError{Code: errorInvalidParams, Message: "missing call hierarchy data"}
+	}
+
+	arguments := *item.Data
+	arguments.Direction = direction
+	dataflow, rpcErr := s.trace(arguments)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	incomingCalls := []models.LspCallHierarchyIncomingCall{}
+	outgoingCalls := []models.LspCallHierarchyOutgoingCall{}
+	for _, step := range dataflow {
+		location := stepLocation(step)
+
+		// The start step is the item itself
+		if location.URI == item.URI && location.Range.Start.Line == arguments.Line && step.NameHighlight == arguments.Variable {
+			continue
+		}
+
+		stepItem := models.LspCallHierarchyItem{
+			Name:           step.NameHighlight,
+			Kind:           symbolKindVariable,
+			Detail:         fmt.Sprintf("%s (line %d)", step.Type, step.Line),
+			URI:            location.URI,
+			Range:          location.Range,
+			SelectionRange: location.Range,
+			Data: &models.LspTraceArguments{
+				URI:       location.URI,
+				Line:      location.Range.Start.Line,
+				Character: location.Range.Start.Character,
+				Variable:  step.NameHighlight,
+			},
+		}
+
+		if direction == models.DirectionBackward {
+			incomingCalls = append(incomingCalls, models.LspCallHierarchyIncomingCall{From: stepItem, FromRanges: []models.LspRange{location.Range}})
+		} else {
+			outgoingCalls = append(outgoingCalls, models.LspCallHierarchyOutgoingCall{To: stepItem, FromRanges: []models.LspRange{location.Range}})
+		}
+	}
+
+	if direction == models.DirectionBackward {
+		return incomingCalls, nil
+	}
+	return outgoingCalls, nil
+}
+
+// -----------------------------------------------------------------------------
+// trace - Runs the data flow analysis of a variable of a document.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - arguments (models.LspTraceArguments): The document, the position and optionally the variable and the direction.
+//
+// Returns:
+//   - ([]models.DataFlow): The data flow of the variable.
+//   - (*models.LspError): The error of the analysis, if any.
+//
+// -----------------------------------------------------------------------------
+func (s *Server) trace(arguments models.LspTraceArguments) ([]models.DataFlow, *models.LspError) {
+	doc, rpcErr := s.getDocument(arguments.URI)
+	if rpcErr != nil {
+		return nil, rpcErr
+	}
+
+	variable := arguments.Variable
+	if variable == "" {
+		variable, _ = identifierAtPosition(doc, models.LspPosition{Line: arguments.Line, Character: arguments.Character})
+		if variable == "" {
+			return nil, &models.LspError{Code: errorInvalidParams, Message: "no variable at the given position"}
+		}
+	}
+
+	direction := arguments.Direction
+	if direction == "" {
+		direction = models.DirectionBackward
+	}
+
+	config := models.Config{
+		FilePath:    doc.path,
+		StartLine:   arguments.Line + 1,
+		Language:    doc.language,
+		Variable:    variable,
+		Direction:   direction,
+		ProjectRoot: s.projectRoot,
+		Content:     doc.content,
+	}
+
+	dataflow, err := core.RunDataflowAnalysis(config)
+	if err != nil {
+		return nil, &models.LspError{Code: errorInternal, Message: err.Error()}
+	}
+	return dataflow, nil
+}
+
+// -----------------------------------------------------------------------------
+// identifierAtPosition - Finds the identifier under an LSP position.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - doc (*document): The document.
+//   - position (models.LspPosition): The position, in UTF-16 code units.
+//
+// Returns:
+//   - (string): The name of the identifier, or an empty string if the position is not on an identifier.
+//   - (models.LspRange): The range of the identifier.
+//
+// -----------------------------------------------------------------------------
+func identifierAtPosition(doc *document, position models.LspPosition) (string, models.LspRange) {
+	lines := strings.Split(string(doc.content), "\n")
+	if position.Line < 0 || position.Line >= len(lines) {
+		return "", models.LspRange{}
+	}
+
+	tree := languageService.ParseContent(doc.content, doc.language)
+	if tree == nil {
+		return "", models.LspRange{}
+	}
+
+	point := sitter.Point{
+		Row:    uint32(position.Line),
+		Column: uint32(utf16ToByteColumn(lines[position.Line], position.Character)),
+	}
+	node := tree.RootNode().NamedDescendantForPointRange(point, point)
+	if node == nil {
+		return "", models.LspRange{}
+	}
+
+	// The identifier is named the way the analysis tracks it, PHP variables with their '$'
+	node = nodeService.GetIdentifierNode(doc.language, node)
+	if !nodeService.IsIdentifierNode(doc.language, node) {
+		return "", models.LspRange{}
+	}
+
+	return node.Content(doc.content), nodeRange(lines, node)
+}
+
+// -----------------------------------------------------------------------------
+// stepLocation - Converts a data flow step into an LSP location.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - step (models.DataFlow): The data flow step.
+//
+// Returns:
+//   - (models.LspLocation): The location of the variable on the line of the step.
+//
+// -----------------------------------------------------------------------------
+func stepLocation(step models.DataFlow) models.LspLocation {
+	line := step.Line - 1
+	lines := make([]string, line+1)
+	for _, codeLine := range step.Code {
+		if codeLine.Line == step.Line {
+			lines[line] = codeLine.Content
+		}
+	}
+
+	return models.LspLocation{
+		URI:   pathToURI(step.Path),
+		Range: variableRange(lines, line, step.NameHighlight),
+	}
+}
+
+// -----------------------------------------------------------------------------
+// variableRange - Returns the range of the first occurrence of a variable on a line.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - lines ([]string): The lines of the document.
+//   - line (int): The line, starting at 0.
+//   - variable (string): The variable to find.
+//
+// Returns:
+//   - (models.LspRange): The range of the variable, or the beginning of the line if it is not found.
+//
+// -----------------------------------------------------------------------------
+func variableRange(lines []string, line int, variable string) models.LspRange {
+	if line < 0 || line >= len(lines) {
+		return models.LspRange{}
+	}
+
+	text := lines[line]
+	index := findVariableColumn(text, variable)
+	if index < 0 {
+		return models.LspRange{Start: models.LspPosition{Line: line}, End: models.LspPosition{Line: line}}
+	}
+
+	return models.LspRange{
+		Start: models.LspPosition{Line: line, Character: byteToUTF16Column(text, index)},
+		End:   models.LspPosition{Line: line, Character: byteToUTF16Column(text, index+len(variable))},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// findVariableColumn - Finds the byte column of a variable on a line of code.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - text (string): The line of code.
+//   - variable (string): The variable to find.
+//
+// Returns:
+//   - (int): The column of the first occurrence that is a whole name outside of a string literal,
+//     of the first whole name otherwise, or -1 if the variable is not on the line.
+//
+// -----------------------------------------------------------------------------
+func findVariableColumn(text, variable string) int {
+	if variable == "" {
+		return -1
+	}
+
+	isNameCharacter := func(char byte) bool {
+		return char == '_' || char == '$' || (char >= 'a' && char <= 'z') || (char >= 'A' && char <= 'Z') || (char >= '0' && char <= '9')
+	}
+
+	firstWholeName := -1
+	for offset := 0; offset < len(text); {
+		index := strings.Index(text[offset:], variable)
+		if index < 0 {
+			break
+		}
+		index += offset
+		offset = index + 1
+
+		end := index + len(variable)
+		if (index > 0 && isNameCharacter(text[index-1])) || (end < len(text) && isNameCharacter(text[end])) {
+			continue
+		}
+		if firstWholeName < 0 {
+			firstWholeName = index
+		}
+
+		// An odd number of quotes before the name means it is inside a string literal
+		prefix := text[:index]
+		if strings.Count(prefix, "\"")%2 == 0 && strings.Count(prefix, "'")%2 == 0 {
+			return index
+		}
+	}
+
+	return firstWholeName
+}
+
+// -----------------------------------------------------------------------------
+// nodeRange - Converts the position of a syntax tree node into an LSP range.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - lines ([]string): The lines of the document.
+//   - node (*sitter.Node): The node.
+//
+// Returns:
+//   - (models.LspRange): The range of the node, in UTF-16 code units.
+//
+// -----------------------------------------------------------------------------
+func nodeRange(lines []string, node *sitter.Node) models.LspRange {
+	toPosition := func(point sitter.Point) models.LspPosition {
+		position := models.LspPosition{Line: int(point.Row)}
+		if int(point.Row) < len(lines) {
+			position.Character = byteToUTF16Column(lines[point.Row], int(point.Column))
+		}
+		return position
+	}
+	return models.LspRange{Start: toPosition(node.StartPoint()), End: toPosition(node.EndPoint())}
+}
+
+// -----------------------------------------------------------------------------
+// byteToUTF16Column - Converts a byte column of tree-sitter into an LSP column.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - line (string): The text of the line.
+//   - byteColumn (int): The column in bytes.
+//
+// Returns:
+//   - (int): The column in UTF-16 code units.
+//
+// -----------------------------------------------------------------------------
+func byteToUTF16Column(line string, byteColumn int) int {
+	column := 0
+	for index, char := range line {
+		if index >= byteColumn {
+			break
+		}
+		column++
+		if char > 0xFFFF {
+			column++ // Surrogate pair
+		}
+	}
+	return column
+}
+
+// -----------------------------------------------------------------------------
+// utf16ToByteColumn - Converts an LSP column into a byte column of tree-sitter.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - line (string): The text of the line.
+//   - utf16Column (int): The column in UTF-16 code units.
+//
+// Returns:
+//   - (int): The column in bytes.
+//
+// -----------------------------------------------------------------------------
+func utf16ToByteColumn(line string, utf16Column int) int {
+	column := 0
+	for index, char := range line {
+		if column >= utf16Column {
+			return index
+		}
+		column++
+		if char > 0xFFFF {
+			column++ // Surrogate pair
+		}
+	}
+	return len(line)
+}
+
+// -----------------------------------------------------------------------------
+// uriToPath - Converts a file URI into a file path.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - uri (string): The URI (e.g. file:///home/user/app.go).
+//
+// Returns:
+//   - (string): The file path.
+//
+// -----------------------------------------------------------------------------
+func uriToPath(uri string) string {
+	parsed, err := url.Parse(uri)
+	if err != nil || parsed.Scheme != "file" {
+		return uri
+	}
+	return filepath.FromSlash(parsed.Path)
+}
+
+// -----------------------------------------------------------------------------
+// pathToURI - Converts a file path into a file URI.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - path (string): The file path.
+//
+// Returns:
+//   - (string): The URI of the file.
+//
+// -----------------------------------------------------------------------------
+func pathToURI(path string) string {
+	if absolutePath, err := filepath.Abs(path); err == nil {
+		path = absolutePath
+	}
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
+
+// -----------------------------------------------------------------------------
+// invalidParams - Creates the error of a request with invalid parameters.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - err (error): The decoding error.
+//
+// Returns:
+//   - (*models.LspError): The JSON-RPC error.
+//
+// -----------------------------------------------------------------------------
+func invalidParams(err error) *models.LspError {
+	return &models.LspError{Code: errorInvalidParams, Message: fmt.Sprintf("invalid parameters: %v", err)}
+}
@@ -0,0 +1,191 @@
+package lsp
+
+import (
+	"bufio"
+	"bytes"
+	"dataflow/models"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// -----------------------------------------------------------------------------
+// TestOpenDocumentLanguage - Checks that the language of the opened documents is resolved from the languageId of the editor.
+// -----------------------------------------------------------------------------
+func TestOpenDocumentLanguage(t *testing.T) {
+	tests := []struct {
+		uri        string
+		languageID string
+		language   string
+	}{
+		{"file:///src/main.py", "python", "python"},
+		{"file:///src/App.jsx", "javascriptreact", "javascript"},
+		{"file:///src/App.tsx", "typescriptreact", "tsx"},
+		{"file:///src/deploy.sh", "shellscript", "bash"},
+		{"file:///src/Main.cs", "csharp", "csharp"},
+		{"file:///src/main.go", "plaintext", "go"},
+	}
+
+	server := NewServer(strings.NewReader(""), io.Discard)
+	for _, test := range tests {
+		server.openDocument(models.LspTextDocumentItem{URI: test.uri, LanguageID: test.languageID, Text: ""})
+		if got := server.documents[test.uri].language; got != test.language {
+			t.Errorf("%s (%s): got language %q, want %q", test.uri, test.languageID, got, test.language)
+		}
+	}
+}
+
+// -----------------------------------------------------------------------------
+// exchange - Sends JSON-RPC messages to a server and returns its responses.
+// -----------------------------------------------------------------------------
+func exchange(t *testing.T, server *Server, output *bytes.Buffer, messages ...string) []models.LspResponse {
+	t.Helper()
+
+	var input strings.Builder
+	for _, message := range messages {
+		fmt.Fprintf(&input, "Content-Length: %d\r\n\r\n%s", len(message), message)
+	}
+	server.reader = bufio.NewReader(strings.NewReader(input.String()))
+	if err := server.Run(); err != nil {
+		t.Fatalf("server stopped: %v", err)
+	}
+
+	var responses []models.LspResponse
+	server.reader = bufio.NewReader(output)
+	for {
+		body, err := server.readMessage()
+		if err == io.EOF {
+			return responses
+		}
+		if err != nil {
+			t.Fatalf("invalid response: %v", err)
+		}
+		var response models.LspResponse
+		if err := json.Unmarshal(body, &response); err != nil {
+			t.Fatalf("invalid response %s: %v", body, err)
+		}
+		responses = append(responses, response)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TestInitialize - Checks the capabilities answered to the editor and the project mode enabled on the workspace root.
+// -----------------------------------------------------------------------------
+func TestInitialize(t *testing.T) {
+	var output bytes.Buffer
+	server := NewServer(strings.NewReader(""), &output)
+	responses := exchange(t, server, &output,
+		`{"jsonrpc":"2.0","id":1,"method":"initialize","params":{"rootUri":"file:///workspace","initializationOptions":{"project":true}}}`,
+		`{"jsonrpc":"2.0","method":"initialized","params":{}}`,
+		`{"jsonrpc":"2.0","id":2,"method":"shutdown"}`,
+		`{"jsonrpc":"2.0","method":"exit"}`,
+	)
+
+	// The notification is not answered
+	if len(responses) != 2 {
+		t.Fatalf("got %d responses, want 2", len(responses))
+	}
+	var result struct {
+		Capabilities struct {
+			TextDocumentSync       int             `json:"textDocumentSync"`
+			CodeLensProvider       json.RawMessage `json:"codeLensProvider"`
+			CallHierarchyProvider  bool            `json:"callHierarchyProvider"`
+			ExecuteCommandProvider struct {
+				Commands []string `json:"commands"`
+			} `json:"executeCommandProvider"`
+		} `json:"capabilities"`
+	}
+	if err := json.Unmarshal(responses[0].Result, &result); err != nil {
+		t.Fatalf("invalid initialize result %s: %v", responses[0].Result, err)
+	}
+	capabilities := result.Capabilities
+	if capabilities.TextDocumentSync != 1 || capabilities.CodeLensProvider == nil || !capabilities.CallHierarchyProvider {
+		t.Errorf("capabilities %s, want full synchronization, code lenses and call hierarchy", responses[0].Result)
+	}
+	if fmt.Sprint(capabilities.ExecuteCommandProvider.Commands) != "["+TraceCommand+"]" {
+		t.Errorf("commands %v, want [%s]", capabilities.ExecuteCommandProvider.Commands, TraceCommand)
+	}
+	if server.projectRoot != "/workspace" {
+		t.Errorf("project root %q, want /workspace", server.projectRoot)
+	}
+	if string(*responses[1].ID) != "2" || responses[1].Error != nil || !server.shutdown {
+		t.Errorf("shutdown answered %+v, want a result for the request 2", responses[1])
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TestIdentifierAtPosition - Checks that the identifier under the cursor is named the way the analysis tracks it.
+// -----------------------------------------------------------------------------
+func TestIdentifierAtPosition(t *testing.T) {
+	tests := []struct {
+		language  string
+		text      string
+		character int
+		name      string
+	}{
+		{"go", "content, err := ioutil.ReadFile(newPath)", 33, "newPath"},
+		{"python", "data = open(path).read()", 13, "path"},
+		// The PHP variables keep their '$', from the sign as from the name
+		{"php", "<?php $content = file_get_contents($newPath);", 35, "$newPath"},
+		{"php", "<?php $content = file_get_contents($newPath);", 38, "$newPath"},
+		// The shell variables are read by their name
+		{"bash", `cat "$newPath"`, 7, "newPath"},
+		// A keyword or a literal is not an identifier
+		{"go", "return \"cached\"", 2, ""},
+		{"python", "data = open('data.txt')", 15, ""},
+	}
+
+	for _, test := range tests {
+		doc := &document{language: test.language, content: []byte(test.text)}
+		name, nameRange := identifierAtPosition(doc, models.LspPosition{Line: 0, Character: test.character})
+		if name != test.name {
+			t.Errorf("%s %q at %d: got %q, want %q", test.language, test.text, test.character, name, test.name)
+			continue
+		}
+		if name != "" && test.text[nameRange.Start.Character:nameRange.End.Character] != name {
+			t.Errorf("%s %q at %d: range %+v does not cover %q", test.language, test.text, test.character, nameRange, name)
+		}
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TestTraceCommand - Checks that the trace command answers the locations of the steps of the variable under the cursor.
+// -----------------------------------------------------------------------------
+func TestTraceCommand(t *testing.T) {
+	path, err := filepath.Abs(filepath.Join("..", "tests", "php", "example1.php"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	uri := pathToURI(path)
+
+	// The cursor is on $newPath in $content = file_get_contents($newPath); at line 13
+	var output bytes.Buffer
+	server := NewServer(strings.NewReader(""), &output)
+	responses := exchange(t, server, &output,
+		fmt.Sprintf(`{"jsonrpc":"2.0","id":1,"method":"workspace/executeCommand","params":{"command":%q,"arguments":[{"uri":%q,"line":12,"character":40}]}}`, TraceCommand, uri),
+	)
+	if len(responses) != 1 || responses[0].Error != nil {
+		t.Fatalf("got responses %+v, want the locations of the steps", responses)
+	}
+
+	var locations []models.LspLocation
+	if err := json.Unmarshal(responses[0].Result, &locations); err != nil {
+		t.Fatalf("invalid trace result %s: %v", responses[0].Result, err)
+	}
+	lines := make(map[int]bool)
+	for _, location := range locations {
+		if location.URI != uri {
+			t.Errorf("location in %s, want %s", location.URI, uri)
+		}
+		lines[location.Range.Start.Line+1] = true
+	}
+	// The value of $newPath comes from the return of functionTest() and from $filePath before it
+	for _, line := range []int{13, 6, 5, 4} {
+		if !lines[line] {
+			t.Errorf("no location at line %d, got %+v", line, locations)
+		}
+	}
+}
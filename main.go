@@ -1,59 +1,315 @@
 package main
 
 import (
+	"bytes"
 	"dataflow/core"
 	"dataflow/logger"
+	"dataflow/lsp"
 	"dataflow/models"
+	"dataflow/server"
+	"dataflow/services/graphService"
+	"dataflow/services/languageService"
+	"dataflow/services/outputService"
+	"dataflow/services/sarifService"
+	"dataflow/services/variableService"
 	"flag"
 	"fmt"
+	"os"
 	"strings"
 	"time"
+
+	"github.com/joho/godotenv"
 )
 
 func main() {
-	// Initialisation du logger
-	logger.Setup(
-		func(format string, v ...interface{}) { fmt.Printf("[INFO] "+format, v...) },
-		func(format string, v ...interface{}) { fmt.Printf("[WARNING] "+format, v...) },
-		func(format string, v ...interface{}) { fmt.Printf("[ERROR] "+format, v...) },
-		func(format string, v ...interface{}) { fmt.Printf("[DEBUG] "+format, v...) },
-	)
+	// Sous-commande du serveur HTTP
+	if len(os.Args) > 1 && os.Args[1] == "serve" {
+		runServer(os.Args[2:])
+		return
+	}
+
+	// Sous-commande du serveur LSP (la sortie standard est réservée au protocole)
+	if len(os.Args) > 1 && os.Args[1] == "lsp" {
+		setupLogger(true, false)
+		loadQueries(os.Getenv("DATAFLOW_QUERIES"))
+		if err := lsp.NewServer(os.Stdin, os.Stdout).Run(); err != nil {
+			logger.PrintError("Error: %v\n", err)
+			os.Exit(1)
+		}
+		return
+	}
 
 	start := time.Now()
 
 	// Définir les flags CLI
 	filePath := flag.String("f", "", "Path to the code file to analyze")
-	startLine := flag.Int("l", 0, "Line number to start the dataflow analysis")
-	language := flag.String("lang", "go", "Programming language of the file (e.g., go, python, java)")
-	variable := flag.String("var", "", "Variable to analyze")
+	startLine := flag.Int("l", 0, "Line number to start the dataflow analysis (in its cell for a notebook)")
+	cell := flag.Int("cell", 0, "Index of the notebook cell of the start line (0-based, .ipynb files only)")
+	language := flag.String("lang", "", "Programming language of the file (e.g., go, python, java, or an alias such as py), detected from the file otherwise")
+	variable := flag.String("var", "", "Variable to analyze (every variable of the line otherwise)")
+	listVariables := flag.Bool("list-vars", false, "List the identifiers of the line, classified as variables, functions, fields, modules or literals, without running the analysis")
+	projectRoot := flag.String("project", "", "Root directory of the project, enables the cross-file analysis")
+	direction := flag.String("direction", models.DirectionBackward, "Direction of the analysis: backward (sink to source) or forward (source to sinks)")
+	taint := flag.Bool("taint", false, "Classify the data flow as tainted, sanitized or clean")
+	rulesFile := flag.String("rules", "", "Path to a JSON file declaring the taint sources, sinks and sanitizers (default rules of the language otherwise)")
+	format := flag.String("format", models.OutputFormatText, "Output format: json, ndjson, text or table")
+	outputFile := flag.String("o", "", "Path of the file to write the output to (standard output otherwise)")
+	withSteps := flag.Bool("steps", false, "Include the raw steps of the crawler in the output")
+	graphOutput := flag.String("graph", "", "Path of a file to write the graph of the data flow to")
+	graphFormat := flag.String("graph-format", "", "Format of the graph: dot, mermaid or graphml (guessed from the file extension otherwise)")
+	sarifOutput := flag.String("sarif", "", "Path of a SARIF 2.1.0 file to write the data flow to")
+	fieldDepth := flag.Int("field-depth", models.DefaultFieldDepth, "Number of fields and map keys tracked after a variable (e.g. 1 for req.Path), 0 or a negative depth tracks whole objects")
+	failOnParseError := flag.Bool("fail-on-parse-error", false, "Abort the analysis when the file has syntax errors (they are reported with the result otherwise)")
+	queryDirectory := flag.String("queries", os.Getenv("DATAFLOW_QUERIES"), "Directory of <language>/<kind>.scm files overriding the extraction queries (DATAFLOW_QUERIES)")
 	verbose := flag.Bool("verbose", false, "Enable verbose output")
 	debug := flag.Bool("debug", false, "Enable debug output")
 	flag.Parse()
 
+	setupLogger(*verbose, *debug)
+	loadQueries(*queryDirectory)
+
 	// Vérification des arguments
-	if *filePath == "" || *startLine == 0 || *language == "" || *variable == "" {
-		logger.PrintError("Usage: go run main.go -f <file_path> -l <line_number> -lang <language> -var <variable> [-verbose] [-debug]")
-		return
+	if *filePath == "" || *startLine == 0 {
+		logger.PrintError("Usage: go run main.go -f <file_path> -l <line_number> [-cell <notebook_cell>] [-lang <language>] [-var <variable> | -list-vars] [-project <root_dir>] [-direction backward|forward] [-taint [-rules <rules_file>]] [-format json|ndjson|text|table] [-o <output_file>] [-steps] [-graph <output_file> [-graph-format dot|mermaid|graphml]] [-sarif <output_file>] [-queries <query_dir>] [-field-depth <depth>] [-fail-on-parse-error] [-verbose] [-debug]")
+		os.Exit(2)
+	}
+
+	// Langage demandé, ou détecté depuis l'extension, le shebang ou le contenu du fichier (dans un template, celui du bloc de la ligne)
+	resolvedLanguage, err := languageService.ResolveLanguageAt(*language, *filePath, nil, *startLine)
+	if err != nil {
+		logger.PrintError("Error: %v\n", err)
+		os.Exit(2)
+	}
+	if *language == "" {
+		logger.PrintInfo("Detected language: %s\n", resolvedLanguage)
+	}
+
+	*format = strings.ToLower(*format)
+	if !outputService.IsSupportedFormat(*format) {
+		logger.PrintError("Unsupported output format: %s\n", *format)
+		os.Exit(2)
+	}
+
+	*graphFormat = strings.ToLower(*graphFormat)
+	if *graphFormat == "" {
+		*graphFormat = graphService.FormatFromPath(*graphOutput)
+	}
+	if !graphService.IsSupportedFormat(*graphFormat) {
+		logger.PrintError("Unsupported graph format: %s\n", *graphFormat)
+		os.Exit(2)
 	}
 
 	// Construire la configuration
 	config := models.Config{
-		FilePath:  *filePath,
-		StartLine: *startLine,
-		Language:  strings.ToLower(*language),
-		Verbose:   *verbose,
-		Debug:     *debug,
-		Variable:  *variable,
+		FilePath:         *filePath,
+		StartLine:        *startLine,
+		Language:         resolvedLanguage,
+		Verbose:          *verbose,
+		Debug:            *debug,
+		Variable:         *variable,
+		ProjectRoot:      *projectRoot,
+		Direction:        strings.ToLower(*direction),
+		RulesFile:        *rulesFile,
+		Cell:             *cell,
+		FailOnParseError: *failOnParseError,
+		FieldDepth:       fieldDepth,
+	}
+
+	// Le résultat est rendu en mémoire : le fichier demandé n'est écrit (et écrasé) qu'une fois l'analyse réussie
+	var rendered bytes.Buffer
+
+	analyzer := core.NewAnalyzer(config)
+
+	// Lister les identifiants de la ligne sans lancer l'analyse
+	if *listVariables {
+		candidates, err := analyzer.DetectVariables()
+		if err != nil {
+			logger.PrintError("Error: %v\n", err)
+			os.Exit(1)
+		}
+		if err := outputService.WriteVariables(&rendered, *format, candidates); err != nil {
+			logger.PrintError("Error: %v\n", err)
+			os.Exit(1)
+		}
+		writeResult(*outputFile, rendered.Bytes())
+		return
 	}
 
 	// Exécuter l'analyse du flux de données
-	result, err := core.RunDataflowAnalysis(config)
+	dataflow, steps, err := analyzer.Run()
 	if err != nil {
 		logger.PrintError("Error: %v\n", err)
-		return
+		os.Exit(1)
+	}
+
+	// Sans variable, toutes les variables de la ligne ont été analysées
+	if config.Variable == "" {
+		candidates, err := analyzer.DetectVariables()
+		if err != nil {
+			logger.PrintError("Error: %v\n", err)
+			os.Exit(1)
+		}
+		config.Variable = strings.Join(variableService.GetVariableNames(candidates), ", ")
+	}
+
+	output := models.AnalysisOutput{
+		Variable:  config.Variable,
+		Language:  config.Language,
+		Direction: config.Direction,
+		Dataflow:  dataflow,
+	}
+	if *withSteps {
+		output.Steps = steps
+	}
+	output.Diagnostics = analyzer.Diagnostics()
+
+	var sarifResult models.SarifResult
+
+	// Exécuter l'analyse de teinte si elle est demandée
+	if *taint {
+		report, err := core.ApplyTaintRules(config, dataflow)
+		if err != nil {
+			logger.PrintError("Error: %v\n", err)
+			os.Exit(1)
+		}
+		logger.PrintInfo("Taint status of '%s': %s\n", report.Variable, report.Status)
+		if report.SourceStep != nil {
+			logger.PrintInfo("Source at line %d\n", report.SourceStep.Line)
+		}
+		if report.SanitizerStep != nil {
+			logger.PrintInfo("Sanitized at line %d\n", report.SanitizerStep.Line)
+		}
+		if report.SinkStep != nil {
+			logger.PrintInfo("Sink at line %d\n", report.SinkStep.Line)
+		}
+		output.TaintStatus = report.Status
+		output.Dataflow = report.Dataflow
+		sarifResult = sarifService.CreateTaintSarifResult(report, config.Direction)
+	} else {
+		sarifResult = sarifService.CreateSarifResult(dataflow, config.Variable, config.Direction)
+	}
+
+	if err := outputService.WriteOutput(&rendered, *format, output); err != nil {
+		logger.PrintError("Error: %v\n", err)
+		os.Exit(1)
+	}
+	writeResult(*outputFile, rendered.Bytes())
+
+	// Exporter le graphe du flux de données
+	if *graphOutput != "" {
+		graph := analyzer.Graph(steps)
+		if err := graphService.WriteGraphFile(graph, *graphFormat, *graphOutput); err != nil {
+			logger.PrintError("Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	// Exporter le flux de données au format SARIF
+	if *sarifOutput != "" {
+		sarifLog := sarifService.CreateSarifLog([]models.SarifResult{sarifResult})
+		if err := sarifService.WriteSarifFile(sarifLog, *sarifOutput); err != nil {
+			logger.PrintError("Error: %v\n", err)
+			os.Exit(1)
+		}
 	}
-	_ = result
 
 	elapsed := time.Since(start)
 	logger.PrintInfo("Total execution time: %s\n", elapsed)
 }
+
+// writeResult écrit le résultat rendu sur la sortie standard, ou dans le fichier demandé.
+func writeResult(outputFile string, result []byte) {
+	var err error
+	if outputFile == "" {
+		_, err = os.Stdout.Write(result)
+	} else {
+		err = os.WriteFile(outputFile, result, 0644)
+	}
+	if err != nil {
+		logger.PrintError("Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// runServer lance l'API HTTP avec les options de la ligne de commande, du fichier .env et de l'environnement.
+func runServer(args []string) {
+	// Le fichier .env est facultatif
+	envLoaded := godotenv.Load() == nil
+
+	address := os.Getenv("DATAFLOW_ADDR")
+	if address == "" {
+		address = ":8080"
+	}
+
+	flags := flag.NewFlagSet("serve", flag.ExitOnError)
+	addr := flags.String("addr", address, "Address the server listens on (DATAFLOW_ADDR)")
+	sourceRoot := flags.String("root", os.Getenv("DATAFLOW_SOURCE_ROOT"), "Directory the analyzed file paths must belong to, only file contents are accepted when empty (DATAFLOW_SOURCE_ROOT)")
+	queryDirectory := flags.String("queries", os.Getenv("DATAFLOW_QUERIES"), "Directory of <language>/<kind>.scm files overriding the extraction queries (DATAFLOW_QUERIES)")
+	verbose := flags.Bool("verbose", false, "Enable verbose output")
+	debug := flags.Bool("debug", false, "Enable debug output")
+	flags.Parse(args)
+
+	// Les messages d'information du serveur sont toujours affichés
+	setupLogger(true, *debug)
+
+	if envLoaded {
+		logger.PrintInfo("Environment loaded from .env\n")
+	}
+	loadQueries(*queryDirectory)
+
+	settings := server.Settings{
+		Address:    *addr,
+		SourceRoot: *sourceRoot,
+		Verbose:    *verbose,
+		Debug:      *debug,
+	}
+	if err := server.Start(settings); err != nil {
+		logger.PrintError("Error: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// loadQueries remplace les requêtes d'extraction livrées par celles du répertoire, s'il est renseigné.
+func loadQueries(directory string) {
+	if directory == "" {
+		return
+	}
+	if err := languageService.SetQueryDirectory(directory); err != nil {
+		logger.PrintError("Error: %v\n", err)
+		os.Exit(2)
+	}
+	logger.PrintInfo("Extraction queries loaded from %s\n", directory)
+}
+
+// setupLogger initialise le logger du processus sur la sortie d'erreur (la sortie standard est réservée au résultat).
+func setupLogger(verbose, debug bool) {
+	// Les journaux des analyses (dont la verbosité dépend de leur configuration) passent par la même sortie
+	logger.SetOutput(printLogLine)
+	logger.Setup(
+		func(format string, v ...interface{}) {
+			if verbose {
+				printLogLine("[INFO] "+format, v...)
+			}
+		},
+		func(format string, v ...interface{}) {
+			if verbose {
+				printLogLine("[WARNING] "+format, v...)
+			}
+		},
+		func(format string, v ...interface{}) { printLogLine("[ERROR] "+format, v...) },
+		func(format string, v ...interface{}) {
+			if debug {
+				printLogLine("[DEBUG] "+format, v...)
+			}
+		},
+	)
+}
+
+// printLogLine écrit un message sur la sortie d'erreur, terminé par un retour à la ligne (les messages des services n'en ont pas).
+func printLogLine(format string, v ...interface{}) {
+	message := fmt.Sprintf(format, v...)
+	if !strings.HasSuffix(message, "\n") {
+		message += "\n"
+	}
+	fmt.Fprint(os.Stderr, message)
+}
@@ -2,23 +2,94 @@ package models
 
 import (
 	"dataflow/logger"
+	"encoding/json"
 	"fmt"
+	"os"
 
 	sitter "github.com/smacker/go-tree-sitter"
 )
 
-var (
-	GlobalLanguage string
+// Taint status of a data flow
+const (
+	TaintStatusTainted   = "tainted"
+	TaintStatusSanitized = "sanitized"
+	TaintStatusClean     = "clean"
+)
+
+// Taint roles of a data flow step
+const (
+	TaintRoleSource    = "source"
+	TaintRoleSink      = "sink"
+	TaintRoleSanitizer = "sanitizer"
+)
+
+// Directions of the data flow analysis
+const (
+	DirectionBackward = "backward"
+	DirectionForward  = "forward"
+)
+
+// Number of fields and keys kept in the tracked access paths (req.Header["Host"] has 2) when the configuration sets no depth
+const DefaultFieldDepth = 3
+
+// Kinds of the edges between data flow steps
+const (
+	EdgeKindAssignment = "assignment"
+	EdgeKindArgument   = "argument"
+	EdgeKindReturn     = "return"
+	EdgeKindGlobal     = "global"
+	EdgeKindUse        = "use"
+)
+
+// Graph formats of the command line
+const (
+	GraphFormatDOT     = "dot"
+	GraphFormatMermaid = "mermaid"
+	GraphFormatGraphML = "graphml"
+)
+
+// Output formats of the command line
+const (
+	OutputFormatJSON   = "json"
+	OutputFormatNDJSON = "ndjson"
+	OutputFormatText   = "text"
+	OutputFormatTable  = "table"
+)
+
+// Kinds of the parse diagnostics
+const (
+	DiagnosticKindError   = "error"
+	DiagnosticKindMissing = "missing"
+)
+
+// Kinds of the scopes of a symbol table
+const (
+	ScopeKindModule   = "module"
+	ScopeKindFunction = "function"
+	ScopeKindClass    = "class"
+	ScopeKindBlock    = "block"
+)
+
+// Kinds of the identifiers detected on a line
+const (
+	IdentifierKindVariable = "variable"
+	IdentifierKindFunction = "function"
+	IdentifierKindField    = "field"
+	IdentifierKindModule   = "module"
+	IdentifierKindLiteral  = "literal"
 )
 
 // DataFlowStep représente une étape dans le flux de données d'une variable.
 type DataFlowStep struct {
-	Line     uint32
-	Type     string
-	Method   string
-	Function string
-	Value    string
-	Variable string
+	Line       uint32   `json:"line"`
+	Type       string   `json:"type"`
+	Method     string   `json:"method,omitempty"`
+	Function   string   `json:"function"`
+	Value      string   `json:"value"`
+	Variable   string   `json:"variable"`
+	FilePath   string   `json:"filePath,omitempty"`
+	Cell       *int     `json:"cell,omitempty"`       // Cellule de l'étape dans un notebook, la ligne étant alors celle de la cellule
+	Candidates []string `json:"candidates,omitempty"` // Déclarations auxquelles peut renvoyer un appel ambigu, toutes suivies par l'analyse
 }
 
 type CodeLine struct {
@@ -34,6 +105,10 @@ type DataFlow struct {
 	Path          string     `json:"path"`
 	Type          string     `json:"type"`
 	Order         int        `json:"order"`
+	TaintRole     string     `json:"taintRole,omitempty"`
+	Cell          *int       `json:"cell,omitempty"`       // Cellule de l'étape dans un notebook, la ligne étant alors celle de la cellule
+	ParseError    bool       `json:"parseError,omitempty"` // L'étape se trouve dans une région que l'analyseur syntaxique n'a pas reconnue
+	Candidates    []string   `json:"candidates,omitempty"` // Déclarations auxquelles peut renvoyer l'appel ambigu de l'étape
 }
 
 type VisitInfo struct {
@@ -48,12 +123,256 @@ type FunctionCallSite struct {
 }
 
 type Config struct {
-	FilePath  string
-	StartLine int
+	FilePath         string
+	StartLine        int
+	Language         string
+	Verbose          bool
+	Debug            bool
+	Variable         string
+	ProjectRoot      string
+	Direction        string
+	RulesFile        string
+	Content          []byte
+	Cell             int  // Cellule de la ligne de départ dans un notebook (indice à partir de 0)
+	FailOnParseError bool // Abandonner l'analyse lorsque le fichier contient des erreurs de syntaxe
+	FieldDepth       *int // Nombre de champs et de clés suivis après une variable (nil : DefaultFieldDepth, 0 ou négatif : objets entiers)
+}
+
+// EmbeddedRegion représente une région d'un fichier écrite dans un autre langage que le document qui la contient (bloc <script>, îlot PHP).
+type EmbeddedRegion struct {
 	Language  string
-	Verbose   bool
-	Debug     bool
-	Variable  string
+	StartByte uint32
+	EndByte   uint32
+	StartLine int // Lignes de la région dans le fichier d'origine, à partir de 1
+	EndLine   int
+}
+
+// ParseDiagnostic représente une erreur de syntaxe relevée dans l'arbre tree-sitter : un nœud ERROR (code non reconnu) ou MISSING (élément manquant inséré par l'analyseur).
+type ParseDiagnostic struct {
+	Kind      string `json:"kind"` // error ou missing
+	Line      int    `json:"line"`
+	Column    int    `json:"column"` // Colonnes à partir de 1
+	EndLine   int    `json:"endLine"`
+	EndColumn int    `json:"endColumn"`
+	Text      string `json:"text"` // Code de la région en erreur, ou élément manquant
+	Cell      *int   `json:"cell,omitempty"`
+}
+
+// Notebook représente un notebook Jupyter converti en module virtuel : ses cellules de code mises bout à bout.
+type Notebook struct {
+	Language string
+	Content  []byte         // Module virtuel, les commandes magiques et shell d'IPython étant remplacées par des lignes vides
+	Lines    []NotebookLine // Position dans le notebook de chaque ligne du module virtuel
+}
+
+// NotebookLine représente une ligne d'une cellule de code d'un notebook.
+type NotebookLine struct {
+	Cell    int // Indice de la cellule dans le notebook, à partir de 0
+	Line    int // Ligne dans la cellule, à partir de 1
+	Content string
+}
+
+// SourceFile représente un fichier source parsé appartenant à un projet.
+type SourceFile struct {
+	Path     string
+	Language string // Langage du fichier, déduit de son extension
+	Content  []byte
+	Tree     *sitter.Tree
+	Root     *sitter.Node
+}
+
+// FunctionLocation représente la déclaration d'une fonction dans un fichier du projet.
+type FunctionLocation struct {
+	Name string
+	File *SourceFile
+	Node *sitter.Node
+}
+
+// ProjectCallSite représente un appel de fonction dans un fichier du projet.
+type ProjectCallSite struct {
+	File     *SourceFile
+	CallSite FunctionCallSite
+}
+
+// Project représente l'ensemble des fichiers d'un projet et son index de symboles.
+type Project struct {
+	RootDir   string
+	Files     []*SourceFile
+	Functions map[string][]FunctionLocation
+}
+
+// SymbolTable représente les portées d'un fichier et les déclarations de ses variables.
+type SymbolTable struct {
+	Root        *Scope
+	Content     []byte
+	Scopes      map[*sitter.Node]*Scope       // Portée ouverte par chaque nœud
+	Definitions map[*sitter.Node]*Declaration // Déclaration introduite par chaque nœud de définition
+}
+
+// Scope représente une portée lexicale : le module, une classe, une fonction ou un bloc.
+type Scope struct {
+	Kind         string // module, function, class ou block
+	Node         *sitter.Node
+	Parent       *Scope
+	Children     []*Scope
+	Declarations map[string][]*Declaration // Déclarations de la portée par nom, dans l'ordre du source
+	Externals    map[string]bool           // Noms désignant la variable d'une portée englobante (global, nonlocal)
+}
+
+// Declaration représente la déclaration d'une variable, à laquelle se rapportent ses occurrences.
+type Declaration struct {
+	Name    string
+	Scope   *Scope
+	Line    uint32 // Ligne de la première définition, à partir de 1
+	Visible uint32 // Octet à partir duquel la variable est visible dans sa portée
+	Hoisted bool   // Variable visible dans toute sa portée (var JavaScript, affectation Python...)
+}
+
+// AssignmentPair représente une cible d'une affectation et la partie de la valeur qu'elle reçoit.
+type AssignmentPair struct {
+	Target  string   // Variable ou chemin d'accès affecté (path dans const {path} = opts)
+	Sources []string // Variables et chemins d'accès lus pour calculer la valeur de la cible
+	Copied  string   // Chemin d'accès copié tel quel dans la cible (opts.path), vide si la valeur est une autre expression
+}
+
+// TaintRules représente les sources, sinks et sanitizers déclarés pour un langage.
+type TaintRules struct {
+	Language   string   `json:"language"`
+	Sources    []string `json:"sources"`
+	Sinks      []string `json:"sinks"`
+	Sanitizers []string `json:"sanitizers"`
+}
+
+// TaintReport représente le résultat de l'analyse de teinte d'un flux de données.
+type TaintReport struct {
+	Variable      string     `json:"variable"`
+	Status        string     `json:"status"`
+	SourceStep    *DataFlow  `json:"sourceStep,omitempty"`
+	SinkStep      *DataFlow  `json:"sinkStep,omitempty"`
+	SanitizerStep *DataFlow  `json:"sanitizerStep,omitempty"`
+	Dataflow      []DataFlow `json:"dataflow"`
+}
+
+// DataFlowEdge représente le passage de la valeur d'une étape du flux de données à une autre.
+type DataFlowEdge struct {
+	From DataFlowStep `json:"from"`
+	To   DataFlowStep `json:"to"`
+	Kind string       `json:"kind"`
+}
+
+// DataFlowGraphNode représente un nœud du graphe de flux de données.
+type DataFlowGraphNode struct {
+	ID       string `json:"id"`
+	FilePath string `json:"filePath,omitempty"`
+	Cell     *int   `json:"cell,omitempty"`
+	Line     uint32 `json:"line"`
+	Variable string `json:"variable"`
+	Type     string `json:"type"`
+	Function string `json:"function"`
+}
+
+// DataFlowGraphEdge représente un arc du graphe de flux de données.
+type DataFlowGraphEdge struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+	Kind string `json:"kind"`
+}
+
+// DataFlowGraph représente le graphe de flux de données d'une variable.
+type DataFlowGraph struct {
+	Variable string              `json:"variable"`
+	Nodes    []DataFlowGraphNode `json:"nodes"`
+	Edges    []DataFlowGraphEdge `json:"edges"`
+}
+
+// AnalysisOutput représente le résultat d'une analyse tel qu'il est émis par la ligne de commande.
+type AnalysisOutput struct {
+	Variable    string            `json:"variable"`
+	Language    string            `json:"language"`
+	Direction   string            `json:"direction"`
+	TaintStatus string            `json:"taintStatus,omitempty"`
+	Dataflow    []DataFlow        `json:"dataflow"`
+	Steps       []DataFlowStep    `json:"steps,omitempty"`
+	Diagnostics []ParseDiagnostic `json:"diagnostics,omitempty"`
+}
+
+// SarifLog représente un fichier SARIF 2.1.0.
+type SarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []SarifRun `json:"runs"`
+}
+
+type SarifRun struct {
+	Tool    SarifTool     `json:"tool"`
+	Results []SarifResult `json:"results"`
+}
+
+type SarifTool struct {
+	Driver SarifDriver `json:"driver"`
+}
+
+type SarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri,omitempty"`
+	Rules          []SarifRule `json:"rules,omitempty"`
+}
+
+type SarifRule struct {
+	ID               string       `json:"id"`
+	Name             string       `json:"name,omitempty"`
+	ShortDescription SarifMessage `json:"shortDescription"`
+}
+
+type SarifResult struct {
+	RuleID    string          `json:"ruleId"`
+	Level     string          `json:"level"`
+	Message   SarifMessage    `json:"message"`
+	Locations []SarifLocation `json:"locations"`
+	CodeFlows []SarifCodeFlow `json:"codeFlows,omitempty"`
+}
+
+type SarifMessage struct {
+	Text string `json:"text"`
+}
+
+type SarifLocation struct {
+	PhysicalLocation SarifPhysicalLocation `json:"physicalLocation"`
+	Message          *SarifMessage         `json:"message,omitempty"`
+}
+
+type SarifPhysicalLocation struct {
+	ArtifactLocation SarifArtifactLocation `json:"artifactLocation"`
+	Region           SarifRegion           `json:"region"`
+}
+
+type SarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type SarifRegion struct {
+	StartLine   int           `json:"startLine"`
+	StartColumn int           `json:"startColumn,omitempty"`
+	EndColumn   int           `json:"endColumn,omitempty"`
+	Snippet     *SarifSnippet `json:"snippet,omitempty"`
+}
+
+type SarifSnippet struct {
+	Text string `json:"text"`
+}
+
+type SarifCodeFlow struct {
+	Message     *SarifMessage     `json:"message,omitempty"`
+	ThreadFlows []SarifThreadFlow `json:"threadFlows"`
+}
+
+type SarifThreadFlow struct {
+	Locations []SarifThreadFlowLocation `json:"locations"`
+}
+
+type SarifThreadFlowLocation struct {
+	Location       SarifLocation `json:"location"`
+	ExecutionOrder int           `json:"executionOrder"`
 }
 
 type AIRequestBody struct {
@@ -76,6 +395,150 @@ type AIResponse struct {
 	} `json:"choices"`
 }
 
+// AnalyzeRequest représente une demande d'analyse reçue par le serveur HTTP.
+type AnalyzeRequest struct {
+	FilePath         string `json:"filePath"`
+	Content          string `json:"content"`
+	Line             int    `json:"line"`
+	Cell             int    `json:"cell"`
+	Variable         string `json:"variable"`
+	Language         string `json:"language"`
+	Direction        string `json:"direction"`
+	Taint            bool   `json:"taint"`
+	Steps            bool   `json:"steps"`
+	FailOnParseError bool   `json:"failOnParseError"`
+	FieldDepth       *int   `json:"fieldDepth"`
+}
+
+// LanguageInfo représente un langage supporté et les extensions de ses fichiers.
+type LanguageInfo struct {
+	Name       string   `json:"name"`
+	Extensions []string `json:"extensions"`
+}
+
+// ErrorResponse représente une erreur renvoyée par le serveur HTTP.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// LspMessage représente un message JSON-RPC reçu par le serveur LSP (requête ou notification).
+type LspMessage struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id,omitempty"`
+	Method  string           `json:"method"`
+	Params  json.RawMessage  `json:"params,omitempty"`
+}
+
+// LspResponse représente une réponse JSON-RPC du serveur LSP.
+type LspResponse struct {
+	JSONRPC string           `json:"jsonrpc"`
+	ID      *json.RawMessage `json:"id"`
+	Result  json.RawMessage  `json:"result,omitempty"`
+	Error   *LspError        `json:"error,omitempty"`
+}
+
+// LspError représente une erreur JSON-RPC.
+type LspError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// LspPosition représente une position LSP (ligne à partir de 0, colonne en unités UTF-16).
+type LspPosition struct {
+	Line      int `json:"line"`
+	Character int `json:"character"`
+}
+
+type LspRange struct {
+	Start LspPosition `json:"start"`
+	End   LspPosition `json:"end"`
+}
+
+type LspLocation struct {
+	URI   string   `json:"uri"`
+	Range LspRange `json:"range"`
+}
+
+type LspTextDocumentIdentifier struct {
+	URI string `json:"uri"`
+}
+
+type LspTextDocumentItem struct {
+	URI        string `json:"uri"`
+	LanguageID string `json:"languageId"`
+	Version    int    `json:"version"`
+	Text       string `json:"text"`
+}
+
+type LspTextDocumentPositionParams struct {
+	TextDocument LspTextDocumentIdentifier `json:"textDocument"`
+	Position     LspPosition               `json:"position"`
+}
+
+type LspDidOpenParams struct {
+	TextDocument LspTextDocumentItem `json:"textDocument"`
+}
+
+type LspDidChangeParams struct {
+	TextDocument   LspTextDocumentIdentifier `json:"textDocument"`
+	ContentChanges []struct {
+		Text string `json:"text"`
+	} `json:"contentChanges"`
+}
+
+type LspCodeLensParams struct {
+	TextDocument LspTextDocumentIdentifier `json:"textDocument"`
+}
+
+type LspCommand struct {
+	Title     string        `json:"title"`
+	Command   string        `json:"command"`
+	Arguments []interface{} `json:"arguments,omitempty"`
+}
+
+type LspCodeLens struct {
+	Range   LspRange    `json:"range"`
+	Command *LspCommand `json:"command,omitempty"`
+}
+
+type LspExecuteCommandParams struct {
+	Command   string            `json:"command"`
+	Arguments []json.RawMessage `json:"arguments"`
+}
+
+// LspTraceArguments représente les arguments de la commande LSP de traçage d'une variable.
+type LspTraceArguments struct {
+	URI       string `json:"uri"`
+	Line      int    `json:"line"`
+	Character int    `json:"character"`
+	Variable  string `json:"variable,omitempty"`
+	Direction string `json:"direction,omitempty"`
+}
+
+type LspCallHierarchyItem struct {
+	Name           string             `json:"name"`
+	Kind           int                `json:"kind"`
+	Detail         string             `json:"detail,omitempty"`
+	URI            string             `json:"uri"`
+	Range          LspRange           `json:"range"`
+	SelectionRange LspRange           `json:"selectionRange"`
+	Data           *LspTraceArguments `json:"data,omitempty"`
+}
+
+type LspCallHierarchyCallsParams struct {
+	Item LspCallHierarchyItem `json:"item"`
+}
+
+type LspCallHierarchyIncomingCall struct {
+	From       LspCallHierarchyItem `json:"from"`
+	FromRanges []LspRange           `json:"fromRanges"`
+}
+
+type LspCallHierarchyOutgoingCall struct {
+	To         LspCallHierarchyItem `json:"to"`
+	FromRanges []LspRange           `json:"fromRanges"`
+}
+
 type IdentifyVariableRequest struct {
 	Name string `json:"name"`
 	Code string `json:"code"`
@@ -84,6 +547,7 @@ type IdentifyVariableRequest struct {
 type IdentifyVariableResponse struct {
 	VariableOrValue string `json:"variableOrValue"`
 	IsVariable      bool   `json:"isVariable"`
+	Kind            string `json:"kind,omitempty"` // variable, function, field, module ou literal
 }
 
 /**
@@ -105,18 +569,19 @@ func PrintDataFlow(dataFlow []DataFlowStep) {
 		return
 	}
 
-	fmt.Printf("Flux de données pour la variable '%s':\n", dataFlow[0].Variable)
-	fmt.Println("----------------------------------------")
+	// Les journaux vont sur la sortie d'erreur pour ne pas polluer la sortie de l'analyse
+	fmt.Fprintf(os.Stderr, "Flux de données pour la variable '%s':\n", dataFlow[0].Variable)
+	fmt.Fprintln(os.Stderr, "----------------------------------------")
 	for i, step := range dataFlow {
-		fmt.Printf("Étape %d:\n", i+1)
-		fmt.Printf(" Ligne: %d\n", step.Line)
-		fmt.Printf(" Type: %s\n", step.Type)
+		fmt.Fprintf(os.Stderr, "Étape %d:\n", i+1)
+		fmt.Fprintf(os.Stderr, " Ligne: %d\n", step.Line)
+		fmt.Fprintf(os.Stderr, " Type: %s\n", step.Type)
 		if step.Method != "" {
-			fmt.Printf(" Méthode: %s\n", step.Method)
+			fmt.Fprintf(os.Stderr, " Méthode: %s\n", step.Method)
 		}
-		fmt.Printf(" Fonction: %s\n", step.Function)
-		fmt.Printf(" Valeur: %s\n", step.Value)
-		fmt.Printf(" Variable: %s\n", step.Variable)
-		fmt.Println()
+		fmt.Fprintf(os.Stderr, " Fonction: %s\n", step.Function)
+		fmt.Fprintf(os.Stderr, " Valeur: %s\n", step.Value)
+		fmt.Fprintf(os.Stderr, " Variable: %s\n", step.Variable)
+		fmt.Fprintln(os.Stderr)
 	}
 }
@@ -0,0 +1,438 @@
+// HTTP API exposing the data flow analysis, so that other tools can run analyses without starting the CLI for each query.
+
+package server
+
+import (
+	"bytes"
+	"dataflow/core"
+	"dataflow/logger"
+	"dataflow/models"
+	"dataflow/services/languageService"
+	"dataflow/services/variableService"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// Maximum size of the body of an analysis request
+const maxRequestSize = 10 << 20
+
+// Settings represents the configuration of the HTTP server.
+type Settings struct {
+	Address    string // Address the server listens on (e.g. ":8080")
+	SourceRoot string // Directory the analyzed file paths must belong to, file paths are refused when empty
+	Verbose    bool
+	Debug      bool
+}
+
+// -----------------------------------------------------------------------------
+// Start - Starts the HTTP server and blocks until it stops.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - settings (Settings): The configuration of the server.
+//
+// Returns:
+//   - (error): The error that stopped the server.
+//
+// -----------------------------------------------------------------------------
+func Start(settings Settings) error {
+	server := &http.Server{
+		Addr:              settings.Address,
+		Handler:           NewRouter(settings),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	logger.PrintInfo("Dataflow API listening on %s\n", settings.Address)
+	return server.ListenAndServe()
+}
+
+// -----------------------------------------------------------------------------
+// NewRouter - Creates the router of the HTTP API.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - settings (Settings): The configuration of the server.
+//
+// Returns:
+//   - (*httprouter.Router): The router with the routes of the API.
+//
+// -----------------------------------------------------------------------------
+func NewRouter(settings Settings) *httprouter.Router {
+	router := httprouter.New()
+	router.GET("/health", handleHealth)
+	router.GET("/languages", handleLanguages)
+	router.POST("/analyze", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		handleAnalyze(w, r, settings)
+	})
+	router.POST("/variables", func(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+		handleVariables(w, r, settings)
+	})
+	return router
+}
+
+// -----------------------------------------------------------------------------
+// handleHealth - Answers the health checks of the server.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - w (http.ResponseWriter): The response writer.
+//   - r (*http.Request): The request.
+//   - _ (httprouter.Params): The route parameters (unused).
+//
+// Returns:
+//   - None
+//
+// -----------------------------------------------------------------------------
+func handleHealth(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// -----------------------------------------------------------------------------
+// handleLanguages - Lists the supported languages and their file extensions.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - w (http.ResponseWriter): The response writer.
+//   - r (*http.Request): The request.
+//   - _ (httprouter.Params): The route parameters (unused).
+//
+// Returns:
+//   - None
+//
+// -----------------------------------------------------------------------------
+func handleLanguages(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	var languages []models.LanguageInfo
+	for _, language := range languageService.GetSupportedLanguages() {
+		languages = append(languages, models.LanguageInfo{
+			Name:       language,
+			Extensions: languageService.GetFileExtensions(language),
+		})
+	}
+	writeJSON(w, http.StatusOK, languages)
+}
+
+// -----------------------------------------------------------------------------
+// handleAnalyze - Runs the data flow analysis of a file path or of a file content.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - w (http.ResponseWriter): The response writer.
+//   - r (*http.Request): The request, with a models.AnalyzeRequest JSON body.
+//   - settings (Settings): The configuration of the server.
+//
+// Returns:
+//   - None
+//
+// -----------------------------------------------------------------------------
+func handleAnalyze(w http.ResponseWriter, r *http.Request, settings Settings) {
+	request, config, ok := decodeRequest(w, r, settings)
+	if !ok {
+		return
+	}
+
+	// Each request has its own analyzer, requests are analyzed concurrently
+	analyzer := core.NewAnalyzer(config)
+	dataflow, steps, err := analyzer.Run()
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	// Without a variable, every variable of the line was analyzed
+	if config.Variable == "" {
+		candidates, err := analyzer.DetectVariables()
+		if err != nil {
+			writeError(w, http.StatusUnprocessableEntity, err.Error())
+			return
+		}
+		config.Variable = strings.Join(variableService.GetVariableNames(candidates), ", ")
+	}
+
+	output := models.AnalysisOutput{
+		Variable:  config.Variable,
+		Language:  config.Language,
+		Direction: config.Direction,
+		Dataflow:  dataflow,
+	}
+	if request.Steps {
+		output.Steps = steps
+	}
+	output.Diagnostics = analyzer.Diagnostics()
+
+	if request.Taint {
+		report, err := core.ApplyTaintRules(config, dataflow)
+		if err != nil {
+			writeError(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+		output.TaintStatus = report.Status
+		output.Dataflow = report.Dataflow
+	}
+
+	if output.Dataflow == nil {
+		output.Dataflow = []models.DataFlow{}
+	}
+	writeJSON(w, http.StatusOK, output)
+}
+
+// -----------------------------------------------------------------------------
+// handleVariables - Lists the identifiers of a line, so that the caller can pick the variable to analyze.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - w (http.ResponseWriter): The response writer.
+//   - r (*http.Request): The request, with a models.AnalyzeRequest JSON body (the variable is ignored).
+//   - settings (Settings): The configuration of the server.
+//
+// Returns:
+//   - None
+//
+// -----------------------------------------------------------------------------
+func handleVariables(w http.ResponseWriter, r *http.Request, settings Settings) {
+	_, config, ok := decodeRequest(w, r, settings)
+	if !ok {
+		return
+	}
+
+	candidates, err := core.DetectVariables(config)
+	if err != nil {
+		writeError(w, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	if candidates == nil {
+		candidates = []models.IdentifyVariableResponse{}
+	}
+	writeJSON(w, http.StatusOK, candidates)
+}
+
+// -----------------------------------------------------------------------------
+// decodeRequest - Decodes the JSON body of a request and converts it into an analysis configuration.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - w (http.ResponseWriter): The response writer, answered with an error when the request is refused.
+//   - r (*http.Request): The request, with a models.AnalyzeRequest JSON body.
+//   - settings (Settings): The configuration of the server.
+//
+// Returns:
+//   - (models.AnalyzeRequest): The decoded request.
+//   - (models.Config): The configuration of the analysis.
+//   - (bool): False if the request was refused and the error already written.
+//
+// -----------------------------------------------------------------------------
+func decodeRequest(w http.ResponseWriter, r *http.Request, settings Settings) (models.AnalyzeRequest, models.Config, bool) {
+	var request models.AnalyzeRequest
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestSize))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("invalid request body: %v", err))
+		return request, models.Config{}, false
+	}
+
+	config, status, err := buildConfig(request, settings)
+	if err != nil {
+		writeError(w, status, err.Error())
+		return request, config, false
+	}
+
+	return request, config, true
+}
+
+// -----------------------------------------------------------------------------
+// buildConfig - Validates an analysis request and converts it into an analysis configuration.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - request (models.AnalyzeRequest): The analysis request.
+//   - settings (Settings): The configuration of the server.
+//
+// Returns:
+//   - (models.Config): The configuration of the analysis.
+//   - (int): The HTTP status to answer with when the request is refused.
+//   - (error): An error object if the request is invalid.
+//
+// -----------------------------------------------------------------------------
+func buildConfig(request models.AnalyzeRequest, settings Settings) (models.Config, int, error) {
+	config := models.Config{
+		FilePath:         request.FilePath,
+		StartLine:        request.Line,
+		Cell:             request.Cell,
+		Variable:         request.Variable,
+		Direction:        strings.ToLower(request.Direction),
+		Verbose:          settings.Verbose,
+		Debug:            settings.Debug,
+		FailOnParseError: request.FailOnParseError,
+		FieldDepth:       request.FieldDepth,
+	}
+	if config.Direction == "" {
+		config.Direction = models.DirectionBackward
+	}
+
+	if config.StartLine <= 0 {
+		return config, http.StatusBadRequest, fmt.Errorf("line is required")
+	}
+	if config.Direction != models.DirectionBackward && config.Direction != models.DirectionForward {
+		return config, http.StatusBadRequest, fmt.Errorf("unsupported direction: %s", request.Direction)
+	}
+
+	// The content is analyzed as is, the file path is then only used to label the steps and detect the language
+	if request.Content != "" {
+		config.Content = []byte(request.Content)
+		if config.FilePath == "" {
+			config.FilePath = "input"
+		}
+	} else {
+		if request.FilePath == "" {
+			return config, http.StatusBadRequest, fmt.Errorf("either filePath or content is required")
+		}
+
+		path, err := resolvePath(settings.SourceRoot, request.FilePath)
+		if err != nil {
+			return config, http.StatusForbidden, err
+		}
+		config.FilePath = path
+	}
+
+	// Without a language, it is detected from the file path and the content (in a template, from the region of the line)
+	language, err := languageService.ResolveLanguageAt(request.Language, config.FilePath, config.Content, config.StartLine)
+	if err != nil {
+		return config, http.StatusBadRequest, err
+	}
+	config.Language = language
+
+	if err := checkStartLine(config); err != nil {
+		return config, http.StatusBadRequest, err
+	}
+
+	return config, http.StatusOK, nil
+}
+
+// -----------------------------------------------------------------------------
+// checkStartLine - Checks that the start line of an analysis request is in the analyzed file.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - config (models.Config): The configuration built from the request.
+//
+// Returns:
+//   - (error): An error object if the line is past the end of the file (of its cell for a notebook). A file that cannot
+//     be read is left to the analysis, which reports it.
+//
+// -----------------------------------------------------------------------------
+func checkStartLine(config models.Config) error {
+	content := config.Content
+	if len(content) == 0 {
+		var err error
+		content, err = os.ReadFile(config.FilePath)
+		if err != nil {
+			return nil
+		}
+	}
+
+	// The line of a notebook is a line of its cell
+	if languageService.IsNotebookFile(config.FilePath) {
+		notebook, err := languageService.LoadNotebook(content)
+		if err != nil {
+			return err
+		}
+		_, err = languageService.GetNotebookModuleLine(notebook, config.Cell, config.StartLine)
+		return err
+	}
+
+	lineCount := bytes.Count(content, []byte("\n"))
+	if !bytes.HasSuffix(content, []byte("\n")) {
+		lineCount++
+	}
+	if config.StartLine > lineCount {
+		return fmt.Errorf("line %d is past the end of the file (%d lines)", config.StartLine, lineCount)
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// resolvePath - Resolves a requested file path inside the source root of the server.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - sourceRoot (string): The directory the file must belong to.
+//   - path (string): The requested path, relative to the source root or absolute.
+//
+// Returns:
+//   - (string): The absolute path of the file.
+//   - (error): An error object if file paths are disabled or the file is outside the source root.
+//
+// -----------------------------------------------------------------------------
+func resolvePath(sourceRoot, path string) (string, error) {
+	if sourceRoot == "" {
+		return "", fmt.Errorf("file paths are disabled on this server, send the content of the file instead")
+	}
+
+	root, err := filepath.Abs(sourceRoot)
+	if err != nil {
+		return "", fmt.Errorf("invalid source root: %v", err)
+	}
+	if evaluatedRoot, err := filepath.EvalSymlinks(root); err == nil {
+		root = evaluatedRoot
+	}
+
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(root, path)
+	}
+	path = filepath.Clean(path)
+	if evaluatedPath, err := filepath.EvalSymlinks(path); err == nil {
+		path = evaluatedPath
+	}
+
+	relative, err := filepath.Rel(root, path)
+	if err != nil || relative == ".." || strings.HasPrefix(relative, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("file '%s' is outside the source root of the server", path)
+	}
+
+	return path, nil
+}
+
+// -----------------------------------------------------------------------------
+// writeJSON - Writes a JSON response.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - w (http.ResponseWriter): The response writer.
+//   - status (int): The HTTP status of the response.
+//   - value (interface{}): The value to encode.
+//
+// Returns:
+//   - None
+//
+// -----------------------------------------------------------------------------
+func writeJSON(w http.ResponseWriter, status int, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		logger.PrintError("error encoding response: %v", err)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// writeError - Writes a JSON error response.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - w (http.ResponseWriter): The response writer.
+//   - status (int): The HTTP status of the response.
+//   - message (string): The error message.
+//
+// Returns:
+//   - None
+//
+// -----------------------------------------------------------------------------
+func writeError(w http.ResponseWriter, status int, message string) {
+	writeJSON(w, status, models.ErrorResponse{Error: message})
+}
@@ -0,0 +1,117 @@
+package server
+
+import (
+	"dataflow/models"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// -----------------------------------------------------------------------------
+// TestResolvePath - Checks that the requested file paths are kept inside the source root.
+// -----------------------------------------------------------------------------
+func TestResolvePath(t *testing.T) {
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	outside := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, "src"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(root, "src", "main.py"), []byte("x = 1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(outside, filepath.Join(root, "link")); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		sourceRoot string
+		path       string
+		want       string // Resolved path, empty if the path is refused
+	}{
+		{"relative path", root, "src/main.py", filepath.Join(root, "src", "main.py")},
+		{"absolute path inside the root", root, filepath.Join(root, "src", "main.py"), filepath.Join(root, "src", "main.py")},
+		{"cleaned path inside the root", root, "src/../src/main.py", filepath.Join(root, "src", "main.py")},
+		{"parent directory", root, "../etc/passwd", ""},
+		{"absolute path outside the root", root, filepath.Join(outside, "secret.py"), ""},
+		{"symbolic link leaving the root", root, "link", ""},
+		{"file paths disabled", "", "src/main.py", ""},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			got, err := resolvePath(test.sourceRoot, test.path)
+			switch {
+			case test.want == "" && err == nil:
+				t.Errorf("path accepted as %s", got)
+			case test.want != "" && err != nil:
+				t.Errorf("path refused: %v", err)
+			case got != test.want:
+				t.Errorf("got %s, want %s", got, test.want)
+			}
+		})
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TestAnalyzeValidation - Checks the status of the analysis requests, the invalid ones being refused before the analysis.
+// -----------------------------------------------------------------------------
+func TestAnalyzeValidation(t *testing.T) {
+	root := t.TempDir()
+	if err := os.WriteFile(filepath.Join(root, "main.py"), []byte("def f(base):\n    full = base\n    return full\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	router := NewRouter(Settings{SourceRoot: root})
+
+	tests := []struct {
+		name   string
+		body   string
+		status int
+	}{
+		{"content", `{"content": "def f(base):\n    full = base\n    return full\n", "language": "python", "line": 3, "variable": "full"}`, http.StatusOK},
+		{"file path", `{"filePath": "main.py", "line": 3, "variable": "full"}`, http.StatusOK},
+		{"invalid body", `{"line": `, http.StatusBadRequest},
+		{"unknown field", `{"content": "x = 1", "language": "python", "line": 1, "lines": 2}`, http.StatusBadRequest},
+		{"missing line", `{"content": "x = 1", "language": "python"}`, http.StatusBadRequest},
+		{"unsupported direction", `{"content": "x = 1", "language": "python", "line": 1, "direction": "sideways"}`, http.StatusBadRequest},
+		{"unsupported language", `{"content": "x = 1", "language": "cobol", "line": 1}`, http.StatusBadRequest},
+		{"line past the end", `{"content": "x = 1\n", "language": "python", "line": 5}`, http.StatusBadRequest},
+		{"missing file and content", `{"line": 1}`, http.StatusBadRequest},
+		{"path outside the root", `{"filePath": "../main.py", "line": 1}`, http.StatusForbidden},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			response := httptest.NewRecorder()
+			router.ServeHTTP(response, httptest.NewRequest(http.MethodPost, "/analyze", strings.NewReader(test.body)))
+
+			if response.Code != test.status {
+				t.Fatalf("status: got %d, want %d (%s)", response.Code, test.status, response.Body.String())
+			}
+			if test.status != http.StatusOK {
+				var errorResponse models.ErrorResponse
+				if err := json.Unmarshal(response.Body.Bytes(), &errorResponse); err != nil || errorResponse.Error == "" {
+					t.Errorf("no error message in %s", response.Body.String())
+				}
+				return
+			}
+
+			var output models.AnalysisOutput
+			if err := json.Unmarshal(response.Body.Bytes(), &output); err != nil {
+				t.Fatal(err)
+			}
+			if output.Variable != "full" || len(output.Dataflow) == 0 {
+				t.Errorf("no data flow for 'full': %s", response.Body.String())
+			}
+		})
+	}
+}
@@ -40,6 +40,7 @@ func CreateDataflow(dataflow []models.DataFlowStep, content []byte, startLine in
 			Path:          filePath,
 			Type:          dataflow[i].Type,
 			Order:         i + 1,
+			Candidates:    step.Candidates,
 		}
 
 		// Identify the lines of code around the relevant line
@@ -60,6 +61,230 @@ func CreateDataflow(dataflow []models.DataFlowStep, content []byte, startLine in
 	return Dataflows
 }
 
+// -----------------------------------------------------------------------------
+// CreateProjectDataflow - Creates a data flow whose steps may come from several files of a project.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - dataflow ([]models.DataFlowStep): List of data flow steps.
+//   - project (*models.Project): The project the steps belong to.
+//   - startLine (int): Starting line number in the start file.
+//   - language (string): Programming language of the project.
+//   - filePath (string): Path to the start file, used for steps without a file.
+//   - variable (string): Variable name to highlight.
+//
+// Returns:
+//   - ([]models.DataFlow): List of created data flow objects.
+//
+// -----------------------------------------------------------------------------
+func CreateProjectDataflow(dataflow []models.DataFlowStep, project *models.Project, startLine int, language, filePath, variable string) []models.DataFlow {
+	var Dataflows []models.DataFlow
+
+	// Index the file contents by path
+	contents := make(map[string][]byte)
+	for _, file := range project.Files {
+		contents[file.Path] = file.Content
+	}
+
+	for i, step := range dataflow {
+		stepPath := step.FilePath
+		if stepPath == "" {
+			stepPath = filePath
+		}
+
+		content, exists := contents[stepPath]
+		if !exists {
+			// A file that cannot be read gives a step without code
+			content, _ = os.ReadFile(stepPath)
+			contents[stepPath] = content
+		}
+
+		// Reuse the single file builder for the step and fix its order
+		dto := CreateDataflow([]models.DataFlowStep{step}, content, startLine, language, stepPath, variable)[0]
+		dto.Order = i + 1
+
+		Dataflows = append(Dataflows, dto)
+	}
+
+	return Dataflows
+}
+
+// -----------------------------------------------------------------------------
+// FlagParseErrors - Marks the data flow steps that fall inside a region the parser could not recognize.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - dataflow ([]models.DataFlow): The data flow.
+//   - diagnostics ([]models.ParseDiagnostic): The parse diagnostics of the file.
+//   - filePath (string): The path of the file of the diagnostics, the steps of the other files are not marked.
+//
+// Returns:
+//   - ([]models.DataFlow): The data flow, with ParseError set on the steps on the lines of a diagnostic.
+//
+// -----------------------------------------------------------------------------
+func FlagParseErrors(dataflow []models.DataFlow, diagnostics []models.ParseDiagnostic, filePath string) []models.DataFlow {
+	for i := range dataflow {
+		if dataflow[i].Path != filePath {
+			continue
+		}
+		for _, diagnostic := range diagnostics {
+			if dataflow[i].Line >= diagnostic.Line && dataflow[i].Line <= diagnostic.EndLine {
+				dataflow[i].ParseError = true
+				break
+			}
+		}
+	}
+	return dataflow
+}
+
+// -----------------------------------------------------------------------------
+// MapNotebookDataflow - Moves a data flow computed on the virtual module of a notebook to the cells of the notebook.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - dataflow ([]models.DataFlow): The data flow, at the lines of the virtual module.
+//   - notebook (*models.Notebook): The notebook.
+//
+// Returns:
+//   - ([]models.DataFlow): The data flow at the cells and lines of the notebook, with the code of the cell of each step.
+//
+// -----------------------------------------------------------------------------
+func MapNotebookDataflow(dataflow []models.DataFlow, notebook *models.Notebook) []models.DataFlow {
+	mapped := make([]models.DataFlow, 0, len(dataflow))
+	for _, step := range dataflow {
+		if step.Line < 1 || step.Line > len(notebook.Lines) {
+			mapped = append(mapped, step)
+			continue
+		}
+		position := notebook.Lines[step.Line-1]
+
+		// The code around the step is limited to its cell, with the lines as written (magics included)
+		var code []models.CodeLine
+		for _, codeLine := range step.Code {
+			if codeLine.Line < 1 || codeLine.Line > len(notebook.Lines) {
+				continue
+			}
+			if notebookLine := notebook.Lines[codeLine.Line-1]; notebookLine.Cell == position.Cell {
+				code = append(code, models.CodeLine{Line: notebookLine.Line, Content: notebookLine.Content})
+			}
+		}
+
+		cell := position.Cell
+		step.Line = position.Line
+		step.Cell = &cell
+		step.Code = code
+		mapped = append(mapped, step)
+	}
+	return mapped
+}
+
+// -----------------------------------------------------------------------------
+// MapNotebookSteps - Moves the steps found on the virtual module of a notebook to the cells of the notebook.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - steps ([]models.DataFlowStep): The steps, at the lines of the virtual module.
+//   - notebook (*models.Notebook): The notebook.
+//
+// Returns:
+//   - ([]models.DataFlowStep): The steps at the cells and lines of the notebook.
+//
+// -----------------------------------------------------------------------------
+func MapNotebookSteps(steps []models.DataFlowStep, notebook *models.Notebook) []models.DataFlowStep {
+	mapped := make([]models.DataFlowStep, 0, len(steps))
+	for _, step := range steps {
+		mapped = append(mapped, mapNotebookStep(step, notebook))
+	}
+	return mapped
+}
+
+// -----------------------------------------------------------------------------
+// MapNotebookEdges - Moves the edges found on the virtual module of a notebook to the cells of the notebook.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - edges ([]models.DataFlowEdge): The edges, between steps at the lines of the virtual module.
+//   - notebook (*models.Notebook): The notebook.
+//
+// Returns:
+//   - ([]models.DataFlowEdge): The edges between steps at the cells and lines of the notebook.
+//
+// -----------------------------------------------------------------------------
+func MapNotebookEdges(edges []models.DataFlowEdge, notebook *models.Notebook) []models.DataFlowEdge {
+	mapped := make([]models.DataFlowEdge, 0, len(edges))
+	for _, edge := range edges {
+		edge.From = mapNotebookStep(edge.From, notebook)
+		edge.To = mapNotebookStep(edge.To, notebook)
+		mapped = append(mapped, edge)
+	}
+	return mapped
+}
+
+// -----------------------------------------------------------------------------
+// MapNotebookDiagnostics - Moves the parse diagnostics of the virtual module of a notebook to the cells of the notebook.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - diagnostics ([]models.ParseDiagnostic): The diagnostics, at the lines of the virtual module.
+//   - notebook (*models.Notebook): The notebook.
+//
+// Returns:
+//   - ([]models.ParseDiagnostic): The diagnostics at the cell of their first line, and their lines in this cell.
+//
+// -----------------------------------------------------------------------------
+func MapNotebookDiagnostics(diagnostics []models.ParseDiagnostic, notebook *models.Notebook) []models.ParseDiagnostic {
+	mapped := make([]models.ParseDiagnostic, 0, len(diagnostics))
+	for _, diagnostic := range diagnostics {
+		if diagnostic.Line < 1 || diagnostic.Line > len(notebook.Lines) {
+			mapped = append(mapped, diagnostic)
+			continue
+		}
+		position := notebook.Lines[diagnostic.Line-1]
+		cell := position.Cell
+
+		// A region running into the next cells ends with its first cell
+		endLine := position.Line
+		for line := diagnostic.Line; line <= diagnostic.EndLine && line <= len(notebook.Lines); line++ {
+			if notebook.Lines[line-1].Cell != cell {
+				break
+			}
+			endLine = notebook.Lines[line-1].Line
+		}
+		if endLine-position.Line != diagnostic.EndLine-diagnostic.Line {
+			diagnostic.EndColumn = len(notebook.Lines[diagnostic.Line-1+endLine-position.Line].Content) + 1
+		}
+
+		diagnostic.Line = position.Line
+		diagnostic.EndLine = endLine
+		diagnostic.Cell = &cell
+		mapped = append(mapped, diagnostic)
+	}
+	return mapped
+}
+
+// -----------------------------------------------------------------------------
+// mapNotebookStep - Moves a step found on the virtual module of a notebook to its cell.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - step (models.DataFlowStep): The step, at a line of the virtual module.
+//   - notebook (*models.Notebook): The notebook.
+//
+// Returns:
+//   - (models.DataFlowStep): The step at its cell and its line in the cell.
+//
+// -----------------------------------------------------------------------------
+func mapNotebookStep(step models.DataFlowStep, notebook *models.Notebook) models.DataFlowStep {
+	if step.Line < 1 || int(step.Line) > len(notebook.Lines) {
+		return step
+	}
+	position := notebook.Lines[step.Line-1]
+	cell := position.Cell
+	step.Line = uint32(position.Line)
+	step.Cell = &cell
+	return step
+}
+
 // -----------------------------------------------------------------------------
 // RemoveDuplicateDataFlowStep - Removes duplicates in the data flow while preserving the order.
 // -----------------------------------------------------------------------------
@@ -68,16 +293,17 @@ func CreateDataflow(dataflow []models.DataFlowStep, content []byte, startLine in
 //   - elements ([]models.DataFlowStep): List of data flow steps.
 //   - startLine (uint32): Starting line number in the file.
 //   - variable (string): Variable name to check for duplicates.
+//   - log (*logger.Logger): The logger of the analysis.
 //
 // Returns:
 //   - ([]models.DataFlowStep): List of data flow steps without duplicates.
 //
 // -----------------------------------------------------------------------------
-func RemoveDuplicateDataFlowStep(elements []models.DataFlowStep, startLine uint32, variable string) []models.DataFlowStep {
-	// Map to keep track of entries per variable per line
+func RemoveDuplicateDataFlowStep(elements []models.DataFlowStep, startLine uint32, variable string, log *logger.Logger) []models.DataFlowStep {
+	// Map to keep track of entries per variable per line (the key includes the file in project mode)
 	varLineMap := make(map[string]map[uint32]models.DataFlowStep)
-	// Map to keep count of entries per line
-	lineCountMap := make(map[uint32]int)
+	// Map to keep count of entries per line of each file
+	lineCountMap := make(map[string]map[uint32]int)
 	// Result slice to store the filtered data flow steps
 	var result []models.DataFlowStep
 
@@ -87,14 +313,18 @@ func RemoveDuplicateDataFlowStep(elements []models.DataFlowStep, startLine uint3
 	for _, element := range elements {
 		line := element.Line
 		variableName := element.Variable
+		variableKey := element.FilePath + "\x00" + variableName
 
-		// Initialize the map for this variable if it doesn't exist
-		if varLineMap[variableName] == nil {
-			varLineMap[variableName] = make(map[uint32]models.DataFlowStep)
+		// Initialize the maps for this variable and this file if they don't exist
+		if varLineMap[variableKey] == nil {
+			varLineMap[variableKey] = make(map[uint32]models.DataFlowStep)
+		}
+		if lineCountMap[element.FilePath] == nil {
+			lineCountMap[element.FilePath] = make(map[uint32]int)
 		}
 
 		// Check if an entry for this variable on this line already exists
-		existingElement, exists := varLineMap[variableName][line]
+		existingElement, exists := varLineMap[variableKey][line]
 		if exists {
 			// Compare priorities to decide whether to replace the existing element
 			priorityExisting := getTypePriority(existingElement.Type)
@@ -102,10 +332,10 @@ func RemoveDuplicateDataFlowStep(elements []models.DataFlowStep, startLine uint3
 
 			if priorityNew > priorityExisting {
 				// Replace the existing element with the new one
-				varLineMap[variableName][line] = element
+				varLineMap[variableKey][line] = element
 				// Update the element in the result slice
 				for i := range result {
-					if result[i].Line == line && result[i].Variable == variableName {
+					if result[i].Line == line && result[i].Variable == variableName && result[i].FilePath == element.FilePath {
 						result[i] = element
 						break
 					}
@@ -113,13 +343,13 @@ func RemoveDuplicateDataFlowStep(elements []models.DataFlowStep, startLine uint3
 			}
 			// Else, keep the existing element (no action needed)
 		} else {
-			// Check if we've already added two entries for this line
-			if lineCountMap[line] >= 2 {
+			// Check if we've already added two entries for this line, each variable a value is built from keeping its assignment
+			if lineCountMap[element.FilePath][line] >= 2 && element.Type != "Assignment of value" {
 				continue // Skip adding more entries for this line
 			}
 			// Add the new element
-			varLineMap[variableName][line] = element
-			lineCountMap[line]++
+			varLineMap[variableKey][line] = element
+			lineCountMap[element.FilePath][line]++
 			result = append(result, element)
 		}
 	}
@@ -133,11 +363,11 @@ func RemoveDuplicateDataFlowStep(elements []models.DataFlowStep, startLine uint3
 		}
 	}
 
-	logger.PrintDebug("Data flow steps after removing duplicates: %v", stepExistsOnStartLine)
+	log.PrintDebug("Data flow steps after removing duplicates: %v", stepExistsOnStartLine)
 
-	// If no step exists on the start line for the variable, add it
+	// If no step exists on the start line for the variable, add it before the steps it leads to
 	if !stepExistsOnStartLine {
-		logger.PrintDebug("Adding missing step for variable '%s' on start line %d.", variable, startLine)
+		log.PrintDebug("Adding missing step for variable '%s' on start line %d.", variable, startLine)
 		newStep := models.DataFlowStep{
 			Line:     startLine,
 			Type:     "Use of variable",
@@ -146,7 +376,7 @@ func RemoveDuplicateDataFlowStep(elements []models.DataFlowStep, startLine uint3
 			Value:    variable,
 			Variable: variable,
 		}
-		result = append(result, newStep)
+		result = append([]models.DataFlowStep{newStep}, result...)
 	}
 
 	if len(result) == 0 {
@@ -174,11 +404,15 @@ func RemoveDuplicateDataFlowStep(elements []models.DataFlowStep, startLine uint3
 //
 // -----------------------------------------------------------------------------
 func CreateDataflowInitial(config models.Config) []models.DataFlow {
-	// Lire le contenu du fichier et diviser en lignes
-	content, err := os.ReadFile(config.FilePath)
-	if err != nil {
-		logger.PrintError("Failed to read file: %v", err)
-		return nil
+	// Lire le contenu du fichier (sauf s'il est fourni) et diviser en lignes
+	content := config.Content
+	if len(content) == 0 {
+		var err error
+		content, err = os.ReadFile(config.FilePath)
+		if err != nil {
+			// The error is reported once, by the analysis that reads the file
+			return nil
+		}
 	}
 	lines := strings.Split(string(content), "\n")
 
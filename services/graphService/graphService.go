@@ -0,0 +1,408 @@
+// Functions that build the graph of a data flow from its steps and edges, and export it as DOT, Mermaid or GraphML.
+
+package graphService
+
+import (
+	"bytes"
+	"dataflow/logger"
+	"dataflow/models"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// -----------------------------------------------------------------------------
+// BuildGraph - Builds the graph of a data flow from its steps and the edges recorded by the crawler.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - variable (string): The analyzed variable.
+//   - filePath (string): The analyzed file, used for the steps without a file.
+//   - steps ([]models.DataFlowStep): The steps of the data flow.
+//   - edges ([]models.DataFlowEdge): The edges recorded between the steps.
+//
+// Returns:
+//   - (models.DataFlowGraph): The graph, with one node per variable and line and the edges in the direction of the data flow.
+//
+// -----------------------------------------------------------------------------
+func BuildGraph(variable, filePath string, steps []models.DataFlowStep, edges []models.DataFlowEdge) models.DataFlowGraph {
+	graph := models.DataFlowGraph{Variable: variable}
+	nodeIDs := make(map[string]string)
+	edgeKeys := make(map[string]bool)
+
+	addNode := func(step models.DataFlowStep) string {
+		if step.FilePath == "" {
+			step.FilePath = filePath
+		}
+		cell := -1
+		if step.Cell != nil {
+			cell = *step.Cell
+		}
+		key := fmt.Sprintf("%s\x00%d\x00%d\x00%s", step.FilePath, cell, step.Line, step.Variable)
+		if id, exists := nodeIDs[key]; exists {
+			return id
+		}
+
+		id := fmt.Sprintf("n%d", len(graph.Nodes)+1)
+		nodeIDs[key] = id
+		graph.Nodes = append(graph.Nodes, models.DataFlowGraphNode{
+			ID:       id,
+			FilePath: step.FilePath,
+			Cell:     step.Cell,
+			Line:     step.Line,
+			Variable: step.Variable,
+			Type:     step.Type,
+			Function: step.Function,
+		})
+		return id
+	}
+
+	addEdge := func(from, to, kind string) {
+		key := from + "\x00" + to + "\x00" + kind
+		if from == to || edgeKeys[key] {
+			return
+		}
+		edgeKeys[key] = true
+		graph.Edges = append(graph.Edges, models.DataFlowGraphEdge{From: from, To: to, Kind: kind})
+	}
+
+	for _, step := range steps {
+		addNode(step)
+	}
+
+	// Edges recorded by the crawler: assignments, arguments and returned values
+	for _, edge := range edges {
+		addEdge(addNode(edge.From), addNode(edge.To), edge.Kind)
+	}
+
+	// Successive uses of the same variable in the same function, in the order of the lines
+	groups := make(map[string][]models.DataFlowGraphNode)
+	var groupKeys []string
+	for _, node := range graph.Nodes {
+		if node.Function == "Global Scope" {
+			continue
+		}
+		key := node.FilePath + "\x00" + node.Function + "\x00" + node.Variable
+		if _, exists := groups[key]; !exists {
+			groupKeys = append(groupKeys, key)
+		}
+		groups[key] = append(groups[key], node)
+	}
+	for _, key := range groupKeys {
+		group := groups[key]
+		sort.SliceStable(group, func(i, j int) bool { return nodeBefore(group[i], group[j]) })
+		for i := 1; i < len(group); i++ {
+			if nodeBefore(group[i-1], group[i]) {
+				addEdge(group[i-1].ID, group[i].ID, models.EdgeKindUse)
+			}
+		}
+	}
+
+	// Global declarations flow into the first use of the variable in each function
+	for _, node := range graph.Nodes {
+		if node.Function != "Global Scope" {
+			continue
+		}
+		for _, key := range groupKeys {
+			group := groups[key]
+			if group[0].Variable == node.Variable && group[0].FilePath == node.FilePath {
+				addEdge(node.ID, group[0].ID, models.EdgeKindGlobal)
+			}
+		}
+	}
+
+	return graph
+}
+
+// -----------------------------------------------------------------------------
+// IsSupportedFormat - Checks if a graph format is supported.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - format (string): The graph format to check.
+//
+// Returns:
+//   - (bool): True if the format is dot, mermaid or graphml.
+//
+// -----------------------------------------------------------------------------
+func IsSupportedFormat(format string) bool {
+	return format == models.GraphFormatDOT || format == models.GraphFormatMermaid || format == models.GraphFormatGraphML
+}
+
+// -----------------------------------------------------------------------------
+// FormatFromPath - Guesses the graph format from the extension of an output file.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - path (string): The path of the output file.
+//
+// Returns:
+//   - (string): The graph format, DOT if the extension is unknown.
+//
+// -----------------------------------------------------------------------------
+func FormatFromPath(path string) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".mmd", ".mermaid", ".md":
+		return models.GraphFormatMermaid
+	case ".graphml", ".xml":
+		return models.GraphFormatGraphML
+	}
+	return models.GraphFormatDOT
+}
+
+// -----------------------------------------------------------------------------
+// Export - Serializes a data flow graph in the requested format.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - graph (models.DataFlowGraph): The graph to serialize.
+//   - format (string): The graph format (dot, mermaid or graphml).
+//
+// Returns:
+//   - (string): The serialized graph.
+//   - (error): An error object if the format is not supported.
+//
+// -----------------------------------------------------------------------------
+func Export(graph models.DataFlowGraph, format string) (string, error) {
+	switch format {
+	case models.GraphFormatDOT:
+		return ToDOT(graph), nil
+	case models.GraphFormatMermaid:
+		return ToMermaid(graph), nil
+	case models.GraphFormatGraphML:
+		return ToGraphML(graph), nil
+	}
+	return "", fmt.Errorf("unsupported graph format: %s", format)
+}
+
+// -----------------------------------------------------------------------------
+// WriteGraphFile - Writes a data flow graph to a file.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - graph (models.DataFlowGraph): The graph to write.
+//   - format (string): The graph format (dot, mermaid or graphml).
+//   - outputPath (string): The path of the output file.
+//
+// Returns:
+//   - (error): An error object if the graph could not be serialized or written.
+//
+// -----------------------------------------------------------------------------
+func WriteGraphFile(graph models.DataFlowGraph, format, outputPath string) error {
+	data, err := Export(graph, format)
+	if err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(outputPath, []byte(data), 0644); err != nil {
+		return fmt.Errorf("error writing graph file: %v", err)
+	}
+
+	logger.PrintInfo("Data flow graph written to '%s' (%s)", outputPath, format)
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// ToDOT - Serializes a data flow graph in the Graphviz DOT language.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - graph (models.DataFlowGraph): The graph to serialize.
+//
+// Returns:
+//   - (string): The DOT digraph.
+//
+// -----------------------------------------------------------------------------
+func ToDOT(graph models.DataFlowGraph) string {
+	var builder strings.Builder
+
+	builder.WriteString("digraph dataflow {\n")
+	fmt.Fprintf(&builder, "  label=\"%s\";\n", escapeDOT("Data flow of "+graph.Variable))
+	builder.WriteString("  rankdir=TB;\n")
+	builder.WriteString("  node [shape=box, fontname=\"monospace\"];\n")
+	for _, node := range graph.Nodes {
+		fmt.Fprintf(&builder, "  %s [label=\"%s\"];\n", node.ID, escapeDOT(nodeLabel(node, "\n")))
+	}
+	for _, edge := range graph.Edges {
+		style := ""
+		if edge.Kind == models.EdgeKindUse {
+			style = ", style=dashed"
+		}
+		fmt.Fprintf(&builder, "  %s -> %s [label=\"%s\"%s];\n", edge.From, edge.To, edge.Kind, style)
+	}
+	builder.WriteString("}\n")
+
+	return builder.String()
+}
+
+// -----------------------------------------------------------------------------
+// ToMermaid - Serializes a data flow graph as a Mermaid flowchart.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - graph (models.DataFlowGraph): The graph to serialize.
+//
+// Returns:
+//   - (string): The Mermaid flowchart.
+//
+// -----------------------------------------------------------------------------
+func ToMermaid(graph models.DataFlowGraph) string {
+	var builder strings.Builder
+
+	builder.WriteString("flowchart TD\n")
+	for _, node := range graph.Nodes {
+		fmt.Fprintf(&builder, "  %s[\"%s\"]\n", node.ID, escapeMermaid(nodeLabel(node, "\n")))
+	}
+	for _, edge := range graph.Edges {
+		arrow := "-->"
+		if edge.Kind == models.EdgeKindUse {
+			arrow = "-.->"
+		}
+		fmt.Fprintf(&builder, "  %s %s|%s| %s\n", edge.From, arrow, edge.Kind, edge.To)
+	}
+
+	return builder.String()
+}
+
+// -----------------------------------------------------------------------------
+// ToGraphML - Serializes a data flow graph as a GraphML document.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - graph (models.DataFlowGraph): The graph to serialize.
+//
+// Returns:
+//   - (string): The GraphML document, with the step attributes as node data.
+//
+// -----------------------------------------------------------------------------
+func ToGraphML(graph models.DataFlowGraph) string {
+	var builder strings.Builder
+
+	builder.WriteString(xml.Header)
+	builder.WriteString("<graphml xmlns=\"http://graphml.graphdrawing.org/xmlns\">\n")
+	builder.WriteString("  <key id=\"file\" for=\"node\" attr.name=\"file\" attr.type=\"string\"/>\n")
+	builder.WriteString("  <key id=\"cell\" for=\"node\" attr.name=\"cell\" attr.type=\"int\"/>\n")
+	builder.WriteString("  <key id=\"line\" for=\"node\" attr.name=\"line\" attr.type=\"int\"/>\n")
+	builder.WriteString("  <key id=\"variable\" for=\"node\" attr.name=\"variable\" attr.type=\"string\"/>\n")
+	builder.WriteString("  <key id=\"type\" for=\"node\" attr.name=\"type\" attr.type=\"string\"/>\n")
+	builder.WriteString("  <key id=\"function\" for=\"node\" attr.name=\"function\" attr.type=\"string\"/>\n")
+	builder.WriteString("  <key id=\"kind\" for=\"edge\" attr.name=\"kind\" attr.type=\"string\"/>\n")
+	fmt.Fprintf(&builder, "  <graph id=\"%s\" edgedefault=\"directed\">\n", escapeXML(graph.Variable))
+	for _, node := range graph.Nodes {
+		fmt.Fprintf(&builder, "    <node id=\"%s\">\n", node.ID)
+		fmt.Fprintf(&builder, "      <data key=\"file\">%s</data>\n", escapeXML(node.FilePath))
+		if node.Cell != nil {
+			fmt.Fprintf(&builder, "      <data key=\"cell\">%d</data>\n", *node.Cell)
+		}
+		fmt.Fprintf(&builder, "      <data key=\"line\">%d</data>\n", node.Line)
+		fmt.Fprintf(&builder, "      <data key=\"variable\">%s</data>\n", escapeXML(node.Variable))
+		fmt.Fprintf(&builder, "      <data key=\"type\">%s</data>\n", escapeXML(node.Type))
+		fmt.Fprintf(&builder, "      <data key=\"function\">%s</data>\n", escapeXML(node.Function))
+		builder.WriteString("    </node>\n")
+	}
+	for i, edge := range graph.Edges {
+		fmt.Fprintf(&builder, "    <edge id=\"e%d\" source=\"%s\" target=\"%s\">\n", i+1, edge.From, edge.To)
+		fmt.Fprintf(&builder, "      <data key=\"kind\">%s</data>\n", edge.Kind)
+		builder.WriteString("    </edge>\n")
+	}
+	builder.WriteString("  </graph>\n")
+	builder.WriteString("</graphml>\n")
+
+	return builder.String()
+}
+
+// -----------------------------------------------------------------------------
+// nodeLabel - Builds the label of a graph node.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - node (models.DataFlowGraphNode): The graph node.
+//   - separator (string): The line separator of the output format.
+//
+// Returns:
+//   - (string): The label, with the location, the variable and the type of the step.
+//
+// -----------------------------------------------------------------------------
+func nodeLabel(node models.DataFlowGraphNode, separator string) string {
+	location := fmt.Sprintf("line %d", node.Line)
+	if node.FilePath != "" && node.Cell != nil {
+		location = fmt.Sprintf("%s[cell %d]:%d", filepath.Base(node.FilePath), *node.Cell, node.Line)
+	} else if node.FilePath != "" {
+		location = fmt.Sprintf("%s:%d", filepath.Base(node.FilePath), node.Line)
+	} else if node.Cell != nil {
+		location = fmt.Sprintf("cell %d line %d", *node.Cell, node.Line)
+	}
+	return location + separator + node.Variable + separator + node.Type
+}
+
+// -----------------------------------------------------------------------------
+// nodeBefore - Checks if a graph node is on a line before another one, comparing the cells first in a notebook.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - first (models.DataFlowGraphNode): The first node.
+//   - second (models.DataFlowGraphNode): The second node.
+//
+// Returns:
+//   - (bool): True if the first node is strictly before the second one.
+//
+// -----------------------------------------------------------------------------
+func nodeBefore(first, second models.DataFlowGraphNode) bool {
+	if first.Cell != nil && second.Cell != nil && *first.Cell != *second.Cell {
+		return *first.Cell < *second.Cell
+	}
+	return first.Line < second.Line
+}
+
+// -----------------------------------------------------------------------------
+// escapeDOT - Escapes a string for a quoted DOT attribute.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - text (string): The text to escape.
+//
+// Returns:
+//   - (string): The escaped text, with the new lines as DOT line breaks.
+//
+// -----------------------------------------------------------------------------
+func escapeDOT(text string) string {
+	text = strings.ReplaceAll(text, "\\", "\\\\")
+	text = strings.ReplaceAll(text, "\"", "\\\"")
+	return strings.ReplaceAll(text, "\n", "\\n")
+}
+
+// -----------------------------------------------------------------------------
+// escapeMermaid - Escapes a string for a quoted Mermaid label.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - text (string): The text to escape.
+//
+// Returns:
+//   - (string): The escaped text, with the new lines as Mermaid line breaks.
+//
+// -----------------------------------------------------------------------------
+func escapeMermaid(text string) string {
+	// Labels are rendered as HTML: the quotes end them and the angle brackets open elements
+	return strings.NewReplacer("\"", "#quot;", "<", "#lt;", ">", "#gt;", "\n", "<br/>").Replace(text)
+}
+
+// -----------------------------------------------------------------------------
+// escapeXML - Escapes a string for an XML text node or attribute.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - text (string): The text to escape.
+//
+// Returns:
+//   - (string): The escaped text.
+//
+// -----------------------------------------------------------------------------
+func escapeXML(text string) string {
+	var buffer bytes.Buffer
+	xml.EscapeText(&buffer, []byte(text))
+	return buffer.String()
+}
@@ -0,0 +1,61 @@
+package graphService
+
+import (
+	"dataflow/models"
+	"dataflow/tests/golden"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// -----------------------------------------------------------------------------
+// TestWriteGraphFile - Checks the graph of a data flow in each format against its golden file.
+// -----------------------------------------------------------------------------
+func TestWriteGraphFile(t *testing.T) {
+	global := models.DataFlowStep{Line: 2, Type: "Global variable", Function: "Global Scope", Variable: "prefix"}
+	parameter := models.DataFlowStep{Line: 4, Type: "Function parameters", Function: "build", Variable: "name"}
+	assignment := models.DataFlowStep{Line: 5, Type: "Assignment of value", Function: "build", Variable: "full"}
+	use := models.DataFlowStep{Line: 5, Type: "Assignment of value", Function: "build", Variable: "prefix"}
+	returned := models.DataFlowStep{Line: 6, Type: "Variable used in return statement", Function: "build", Variable: "full"}
+	argument := models.DataFlowStep{Line: 9, Type: "Function parameters", Function: "main", Variable: "user <input>", FilePath: "tests/py/main.py"}
+
+	steps := []models.DataFlowStep{returned, assignment, use, parameter, global, argument}
+	edges := []models.DataFlowEdge{
+		{From: parameter, To: assignment, Kind: models.EdgeKindAssignment},
+		{From: argument, To: parameter, Kind: models.EdgeKindArgument},
+	}
+	graph := BuildGraph("full", "tests/py/build.py", steps, edges)
+
+	for _, format := range []string{models.GraphFormatDOT, models.GraphFormatMermaid, models.GraphFormatGraphML} {
+		format := format
+		t.Run(format, func(t *testing.T) {
+			outputPath := filepath.Join(t.TempDir(), "graph."+format)
+			if err := WriteGraphFile(graph, format, outputPath); err != nil {
+				t.Fatal(err)
+			}
+			got, err := os.ReadFile(outputPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			golden.Check(t, filepath.Join("testdata", "graph."+format), got)
+		})
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TestFormatFromPath - Checks that the graph format is guessed from the file extension.
+// -----------------------------------------------------------------------------
+func TestFormatFromPath(t *testing.T) {
+	tests := map[string]string{
+		"flow.dot":     models.GraphFormatDOT,
+		"flow.mmd":     models.GraphFormatMermaid,
+		"FLOW.XML":     models.GraphFormatGraphML,
+		"flow.graphml": models.GraphFormatGraphML,
+		"flow":         models.GraphFormatDOT,
+	}
+	for path, format := range tests {
+		if got := FormatFromPath(path); got != format {
+			t.Errorf("FormatFromPath(%q) = %q, want %q", path, got, format)
+		}
+	}
+}
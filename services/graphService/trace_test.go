@@ -0,0 +1,63 @@
+// The graph of a real trace is built by the core package, which imports graphService: its test lives in an external package.
+
+package graphService_test
+
+import (
+	"dataflow/core"
+	"dataflow/models"
+	"dataflow/services/graphService"
+	"dataflow/tests/golden"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// -----------------------------------------------------------------------------
+// TestExportOfTrace - Checks the edges of the graph exported for a real trace, from the sources of newPath to its start line.
+// -----------------------------------------------------------------------------
+func TestExportOfTrace(t *testing.T) {
+	analyzer := core.NewAnalyzer(models.Config{
+		FilePath:  filepath.Join("..", "..", "tests", "go", "example1.go"),
+		StartLine: 12,
+		Language:  "go",
+		Variable:  "newPath",
+	})
+	_, steps, err := analyzer.Run()
+	if err != nil {
+		t.Fatalf("analysis failed: %v", err)
+	}
+	graph := analyzer.Graph(steps)
+
+	nodes := map[string]models.DataFlowGraphNode{}
+	for _, node := range graph.Nodes {
+		nodes[node.ID] = node
+	}
+	var edges []string
+	for _, edge := range graph.Edges {
+		from, to := nodes[edge.From], nodes[edge.To]
+		edges = append(edges, fmt.Sprintf("%d %s -> %d %s (%s)", from.Line, from.Variable, to.Line, to.Variable, edge.Kind))
+	}
+	want := []string{
+		"11 filePath -> 11 newPath (assignment)",
+		"45 filePathModified -> 9 filePath (argument)",
+		"41 filePathModified -> 54 test (argument)",
+		"11 newPath -> 12 newPath (use)",
+		"9 filePath -> 10 filePath (use)",
+		"10 filePath -> 11 filePath (use)",
+		"36 filePathModified -> 41 filePathModified (use)",
+		"41 filePathModified -> 43 filePathModified (use)",
+		"43 filePathModified -> 45 filePathModified (use)",
+		"54 test -> 55 test (use)",
+		"55 test -> 56 test (use)",
+	}
+	if !reflect.DeepEqual(edges, want) {
+		t.Errorf("edges of newPath:\n%v\nwant:\n%v", edges, want)
+	}
+
+	dot, err := graphService.Export(graph, models.GraphFormatDOT)
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden.Check(t, filepath.Join("testdata", "example1.dot"), []byte(dot))
+}
@@ -0,0 +1,958 @@
+// Specifications of the languages supported out of the box, registered when the package is loaded.
+
+package languageService
+
+import (
+	"strconv"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/bash"
+	"github.com/smacker/go-tree-sitter/c"
+	"github.com/smacker/go-tree-sitter/cpp"
+	"github.com/smacker/go-tree-sitter/csharp"
+	"github.com/smacker/go-tree-sitter/golang"
+	"github.com/smacker/go-tree-sitter/java"
+	"github.com/smacker/go-tree-sitter/javascript"
+	"github.com/smacker/go-tree-sitter/kotlin"
+	"github.com/smacker/go-tree-sitter/php"
+	"github.com/smacker/go-tree-sitter/python"
+	"github.com/smacker/go-tree-sitter/ruby"
+	"github.com/smacker/go-tree-sitter/rust"
+	"github.com/smacker/go-tree-sitter/typescript/tsx"
+	"github.com/smacker/go-tree-sitter/typescript/typescript"
+)
+
+func init() {
+	Register(goSpec{Spec{
+		LanguageName:    "go",
+		GetGrammar:      golang.GetLanguage,
+		FileExtensions:  []string{".go"},
+		FunctionTypes:   []string{"function_declaration", "method_declaration"},
+		CallTypes:       []string{"call_expression"},
+		AssignmentTypes: []string{"short_var_declaration", "assignment_statement", "var_declaration", "const_declaration"},
+		ParameterTypes:  []string{"parameter_declaration"},
+		LiteralTypes:    []string{"interpreted_string_literal", "raw_string_literal", "int_literal", "float_literal", "rune_literal"},
+		ControlTypes: map[string][]string{
+			ControlCondition:   {"if_statement"},
+			ControlLoop:        {"for_statement"},
+			ControlReturn:      {"return_statement"},
+			ControlCall:        {"call_expression"},
+			ControlConcurrency: {"go_statement"},
+			ControlIdentifier:  {"identifier"},
+			ControlAssignment:  {"assignment_statement", "short_var_declaration"},
+		},
+		FunctionScopeTypes: []string{"function_declaration", "method_declaration"},
+		SyntaxTypes: map[string][]string{
+			SyntaxInstantiation:     {"composite_literal"},
+			SyntaxTypeWrapper:       {"parenthesized_expression", "unary_expression", "expression_list"},
+			SyntaxVariadicParameter: {"variadic_parameter_declaration"},
+			SyntaxSpreadArgument:    {"variadic_argument"},
+			SyntaxPositionalPattern: {"expression_list"},
+			SyntaxPositionalValue:   {"expression_list"},
+			SyntaxClosure:           {"func_literal"},
+			SyntaxReturnedValues:    {"expression_list"},
+			SyntaxMemberAccess:      {"selector_expression"},
+			SyntaxSubscript:         {"index_expression"},
+			SyntaxAccessWrapper:     {"expression_list", "parenthesized_expression"},
+			SyntaxStringPart:        {"escape_sequence"},
+			SyntaxIdentifier:        {"identifier"},
+			SyntaxStatement:         {"expression_statement"},
+			SyntaxLocalDeclaration:  {"short_var_declaration", "assignment_statement"},
+			SyntaxValueWrapper:      {"expression_list", "parenthesized_expression"},
+			SyntaxImport:            {"import_declaration"},
+			SyntaxDetectedName:      {"identifier", "field_identifier"},
+			SyntaxModuleName:        {"package_identifier"},
+			SyntaxOccurrence:        {"identifier"},
+			SyntaxBlock:             {"block"},
+		},
+		Fields: map[string][]string{
+			FieldObject:    {"operand", "value", "receiver"},
+			FieldQualifier: {"path"},
+			FieldMember:    {"field", "name"},
+			FieldKey:       {"index"},
+			FieldCallee:    {"function"},
+		},
+		LanguageAliases: []string{"golang"},
+		ContentHints: []string{
+			`(?m)^package \w+\s*$`,
+			`(?m)^func (\(\w+ \*?\w+\) )?\w+\(`,
+			`\w+ := `,
+			`(?m)^import \(`,
+		},
+	}})
+
+	Register(Spec{
+		LanguageName:    "python",
+		GetGrammar:      python.GetLanguage,
+		FileExtensions:  []string{".py"},
+		FunctionTypes:   []string{"function_definition"},
+		CallTypes:       []string{"call"},
+		AssignmentTypes: []string{"assignment"},
+		LiteralTypes:    []string{"string", "integer", "float", "string_content"},
+		ControlTypes: map[string][]string{
+			ControlCondition:  {"if_statement", "conditional_expression"},
+			ControlLoop:       {"for_statement"},
+			ControlWhile:      {"while_statement"},
+			ControlReturn:     {"return_statement"},
+			ControlCall:       {"call"},
+			ControlIdentifier: {"identifier"},
+			ControlException:  {"try_statement", "except_clause", "finally_clause"},
+		},
+		FunctionScopeTypes: []string{"function_definition"},
+		SyntaxTypes: map[string][]string{
+			SyntaxMethodOwner:           {"class_definition"},
+			SyntaxTypeWrapper:           {"parenthesized_expression"},
+			SyntaxVariadicParameter:     {"list_splat_pattern"},
+			SyntaxKeywordsParameter:     {"dictionary_splat_pattern"},
+			SyntaxSpreadArgument:        {"list_splat"},
+			SyntaxKeywordSpreadArgument: {"dictionary_splat"},
+			SyntaxDictionary:            {"dictionary"},
+			SyntaxPositionalPattern:     {"expression_list", "pattern_list", "tuple_pattern", "list_pattern"},
+			SyntaxPositionalValue:       {"expression_list", "tuple", "list"},
+			SyntaxRestPattern:           {"list_splat_pattern"},
+			SyntaxClosure:               {"lambda"},
+			SyntaxReturnedValues:        {"expression_list"},
+			SyntaxMemberAccess:          {"attribute"},
+			SyntaxSubscript:             {"subscript"},
+			SyntaxAccessWrapper:         {"expression_list", "parenthesized_expression"},
+			SyntaxStringPart:            {"string_start", "string_end", "string_content", "escape_sequence"},
+			SyntaxIdentifier:            {"identifier"},
+			SyntaxStatement:             {"expression_statement"},
+			SyntaxValueWrapper:          {"expression_list", "parenthesized_expression"},
+			SyntaxImport:                {"import_statement", "import_from_statement", "future_import_statement"},
+			SyntaxInstanceParameter:     {"class_definition"},
+			SyntaxDecorated:             {"decorated_definition"},
+			SyntaxKeywordArgument:       {"keyword_argument"},
+			SyntaxKeywordSeparator:      {"keyword_separator", "list_splat_pattern"},
+			SyntaxPositionalSeparator:   {"positional_separator"},
+			SyntaxDictionaryEntry:       {"pair"},
+			SyntaxDetectedName:          {"identifier"},
+			SyntaxQualifiedPath:         {"dotted_name"},
+			SyntaxOccurrence:            {"identifier"},
+			SyntaxBlock:                 {"block"},
+		},
+		Fields: map[string][]string{
+			FieldObject: {"object", "value", "argument", "expression"},
+			FieldMember: {"attribute", "name"},
+			FieldKey:    {"subscript"},
+			FieldCallee: {"function"},
+		},
+		LanguageAliases:     []string{"py", "python3", "python2"},
+		ShebangInterpreters: []string{"python", "pypy"},
+		ContentHints: []string{
+			`(?m)^\s*def \w+\(.*\)\s*(->.*)?:\s*$`,
+			`(?m)^(from [\w.]+ )?import [\w.]+( as \w+)?\s*$`,
+			`\bself\.`,
+			`if __name__ == .__main__.:`,
+		},
+	})
+
+	Register(Spec{
+		LanguageName:    "java",
+		GetGrammar:      java.GetLanguage,
+		FileExtensions:  []string{".java"},
+		FunctionTypes:   []string{"method_declaration"},
+		CallTypes:       []string{"method_invocation"},
+		AssignmentTypes: []string{"assignment_expression", "local_variable_declaration"},
+		ParameterTypes:  []string{"formal_parameter"},
+		LiteralTypes: []string{
+			"string_literal", "character_literal", "decimal_integer_literal", "hex_integer_literal", "octal_integer_literal", "binary_integer_literal",
+			"string_fragment",
+		},
+		ControlTypes: map[string][]string{
+			ControlCondition:  {"if_statement"},
+			ControlLoop:       {"for_statement", "enhanced_for_statement", "do_statement"},
+			ControlWhile:      {"while_statement"},
+			ControlSwitch:     {"switch_expression"},
+			ControlReturn:     {"return_statement"},
+			ControlCall:       {"method_invocation"},
+			ControlIdentifier: {"identifier"},
+			ControlException:  {"try_statement", "catch_clause", "finally_clause"},
+			ControlAssignment: {"assignment_expression"},
+		},
+		FunctionScopeTypes: []string{"method_declaration"},
+		ClassScopeTypes:    []string{"class_declaration", "interface_declaration"},
+		SyntaxTypes: map[string][]string{
+			SyntaxMethodOwner:       {"class_declaration", "record_declaration", "interface_declaration"},
+			SyntaxInstantiation:     {"object_creation_expression"},
+			SyntaxTypeWrapper:       {"parenthesized_expression"},
+			SyntaxVariadicParameter: {"spread_parameter"},
+			SyntaxClosure:           {"lambda_expression"},
+			SyntaxMemberAccess:      {"field_access"},
+			SyntaxAccessBase:        {"this"},
+			SyntaxAccessWrapper:     {"parenthesized_expression"},
+			SyntaxScopedName:        {"scoped_identifier"},
+			SyntaxStringPart:        {"string_fragment", "escape_sequence"},
+			SyntaxIdentifier:        {"identifier"},
+			SyntaxStatement:         {"expression_statement"},
+			SyntaxDeclarator:        {"variable_declarator"},
+			SyntaxFieldDeclaration:  {"field_declaration", "constant_declaration"},
+			SyntaxValueWrapper:      {"parenthesized_expression"},
+			SyntaxImport:            {"import_declaration"},
+			SyntaxDetectedName:      {"identifier"},
+			SyntaxQualifiedPath:     {"scoped_identifier"},
+			SyntaxOccurrence:        {"identifier"},
+			SyntaxBlock:             {"block"},
+		},
+		Fields: map[string][]string{
+			FieldObject:    {"object", "operand", "value"},
+			FieldQualifier: {"scope"},
+			FieldMember:    {"field", "name"},
+			FieldKey:       {"index"},
+			FieldCallee:    {"name"},
+		},
+		ContentHints: []string{
+			`(?m)^\s*(public |private |protected )?(final |abstract )?class \w+`,
+			`(?m)^import java\.`,
+			`System\.out\.print`,
+			`public static void main\(String`,
+		},
+	})
+
+	Register(Spec{
+		LanguageName:    "javascript",
+		GetGrammar:      javascript.GetLanguage,
+		FileExtensions:  []string{".js", ".mjs", ".cjs", ".jsx"},
+		FunctionTypes:   []string{"function_declaration"},
+		CallTypes:       []string{"call_expression"},
+		AssignmentTypes: []string{"assignment_expression", "lexical_declaration", "variable_declaration"},
+		LiteralTypes:    []string{"string", "number", "string_fragment"},
+		ControlTypes: map[string][]string{
+			ControlCondition:  {"if_statement"},
+			ControlLoop:       {"for_statement", "for_in_statement", "do_statement"},
+			ControlWhile:      {"while_statement"},
+			ControlSwitch:     {"switch_statement"},
+			ControlReturn:     {"return_statement"},
+			ControlCall:       {"call_expression"},
+			ControlIdentifier: {"identifier"},
+			ControlException:  {"try_statement", "catch_clause", "finally_clause"},
+			ControlAssignment: {"assignment_expression"},
+		},
+		FunctionScopeTypes: []string{"function_declaration"},
+		ClassScopeTypes:    []string{"class_declaration"},
+		SyntaxTypes: map[string][]string{
+			SyntaxMethodOwner:       {"class_declaration", "class"},
+			SyntaxInstantiation:     {"new_expression"},
+			SyntaxTypeWrapper:       {"parenthesized_expression", "await_expression"},
+			SyntaxVariadicParameter: {"rest_pattern"},
+			SyntaxSpreadArgument:    {"spread_element"},
+			SyntaxDictionary:        {"object"},
+			SyntaxPositionalPattern: {"array_pattern"},
+			SyntaxPositionalValue:   {"array"},
+			SyntaxObjectPattern:     {"object_pattern"},
+			SyntaxRestPattern:       {"rest_pattern"},
+			SyntaxClosure:           {"function_expression", "arrow_function", "generator_function"},
+			SyntaxMemberAccess:      {"member_expression"},
+			SyntaxSubscript:         {"subscript_expression"},
+			SyntaxAccessBase:        {"this"},
+			SyntaxAccessWrapper:     {"parenthesized_expression"},
+			SyntaxStringPart:        {"string_fragment", "escape_sequence"},
+			SyntaxIdentifier:        {"identifier"},
+			SyntaxStatement:         {"expression_statement"},
+			SyntaxDeclarator:        {"variable_declarator"},
+			SyntaxValueWrapper:      {"parenthesized_expression", "await_expression"},
+			SyntaxImport:            {"import_statement"},
+			SyntaxDictionaryEntry:   {"pair"},
+			SyntaxShorthandProperty: {"shorthand_property_identifier_pattern"},
+			SyntaxPropertyPattern:   {"pair_pattern"},
+			SyntaxDefaultPattern:    {"assignment_pattern", "object_assignment_pattern"},
+			SyntaxDetectedName:      {"identifier", "property_identifier", "shorthand_property_identifier"},
+			SyntaxMarkupElement:     {"jsx_opening_element", "jsx_closing_element", "jsx_self_closing_element"},
+			SyntaxMarkupAttribute:   {"jsx_attribute"},
+			SyntaxOccurrence:        {"identifier", "shorthand_property_identifier", "shorthand_property_identifier_pattern"},
+		},
+		Fields: map[string][]string{
+			FieldObject: {"object", "value", "argument"},
+			FieldMember: {"property", "attribute", "name"},
+			FieldKey:    {"index"},
+			FieldCallee: {"function"},
+		},
+		LanguageAliases:     []string{"js", "node", "jsx", "mjs", "cjs", "ecmascript"},
+		EditorLanguageIDs:   []string{"javascriptreact"},
+		ShebangInterpreters: []string{"node", "nodejs", "deno", "bun"},
+		ContentHints: []string{
+			`(?m)^\s*(const|let) \w+ = `,
+			`=>`,
+			`console\.log\(`,
+			`\brequire\(.+\)|^import .+ from `,
+		},
+	})
+
+	// TSX is the same grammar as TypeScript with JSX elements, its specification only differs by its name, extensions, hints and JSX elements
+	typescriptSpec := Spec{
+		LanguageName:    "typescript",
+		GetGrammar:      typescript.GetLanguage,
+		FileExtensions:  []string{".ts", ".mts", ".cts"},
+		FunctionTypes:   []string{"function_declaration"},
+		CallTypes:       []string{"call_expression"},
+		AssignmentTypes: []string{"assignment_expression", "lexical_declaration", "variable_declaration"},
+		ParameterTypes:  []string{"required_parameter", "optional_parameter"},
+		LiteralTypes:    []string{"string", "number", "string_fragment"},
+		ControlTypes: map[string][]string{
+			ControlCondition:  {"if_statement"},
+			ControlLoop:       {"for_statement", "for_in_statement", "do_statement"},
+			ControlWhile:      {"while_statement"},
+			ControlSwitch:     {"switch_statement"},
+			ControlReturn:     {"return_statement"},
+			ControlCall:       {"call_expression"},
+			ControlIdentifier: {"identifier"},
+			ControlException:  {"try_statement", "catch_clause", "finally_clause"},
+			ControlAssignment: {"assignment_expression"},
+		},
+		FunctionScopeTypes: []string{"function_declaration"},
+		ClassScopeTypes:    []string{"class_declaration", "abstract_class_declaration", "interface_declaration"},
+		SyntaxTypes: map[string][]string{
+			SyntaxMethodOwner:       {"class_declaration", "abstract_class_declaration", "class", "interface_declaration"},
+			SyntaxInstantiation:     {"new_expression"},
+			SyntaxTypeWrapper:       {"parenthesized_expression", "await_expression"},
+			SyntaxVariadicParameter: {"rest_pattern"},
+			SyntaxSpreadArgument:    {"spread_element"},
+			SyntaxDictionary:        {"object"},
+			SyntaxPositionalPattern: {"array_pattern"},
+			SyntaxPositionalValue:   {"array"},
+			SyntaxObjectPattern:     {"object_pattern"},
+			SyntaxRestPattern:       {"rest_pattern"},
+			SyntaxClosure:           {"function_expression", "arrow_function", "generator_function"},
+			SyntaxMemberAccess:      {"member_expression"},
+			SyntaxSubscript:         {"subscript_expression"},
+			SyntaxAccessBase:        {"this"},
+			SyntaxAccessWrapper:     {"parenthesized_expression"},
+			SyntaxStringPart:        {"string_fragment", "escape_sequence"},
+			SyntaxIdentifier:        {"identifier"},
+			SyntaxStatement:         {"expression_statement"},
+			SyntaxDeclarator:        {"variable_declarator"},
+			SyntaxValueWrapper:      {"parenthesized_expression", "await_expression", "as_expression", "satisfies_expression", "non_null_expression", "type_assertion"},
+			SyntaxImport:            {"import_statement", "import_alias"},
+			SyntaxDictionaryEntry:   {"pair"},
+			SyntaxShorthandProperty: {"shorthand_property_identifier_pattern"},
+			SyntaxPropertyPattern:   {"pair_pattern"},
+			SyntaxDefaultPattern:    {"assignment_pattern", "object_assignment_pattern"},
+			SyntaxDetectedName:      {"identifier", "property_identifier", "shorthand_property_identifier"},
+			SyntaxOccurrence:        {"identifier", "shorthand_property_identifier", "shorthand_property_identifier_pattern"},
+		},
+		Fields: map[string][]string{
+			FieldObject: {"object", "value", "argument"},
+			FieldMember: {"property", "name"},
+			FieldKey:    {"index"},
+			FieldCallee: {"function"},
+		},
+		LanguageAliases:     []string{"ts", "mts", "cts"},
+		ShebangInterpreters: []string{"ts-node", "tsx"},
+		ContentHints: []string{
+			`(?m)^\s*(const|let) \w+ = `,
+			`=>`,
+			`console\.log\(`,
+			`\brequire\(.+\)|^import .+ from `,
+			`\w\??: (string|number|boolean|any|unknown|void)\b`,
+			`(?m)^\s*(export )?(interface|type) \w+`,
+			`\bas (string|number|any|unknown|const|[A-Z]\w*)\b`,
+			`(?m)^import type `,
+		},
+	}
+	Register(typescriptSpec)
+
+	tsxSpec := typescriptSpec
+	tsxSpec.LanguageName = "tsx"
+	tsxSpec.GetGrammar = tsx.GetLanguage
+	tsxSpec.FileExtensions = []string{".tsx"}
+	tsxSpec.LanguageAliases = []string{"typescriptreact"}
+	tsxSpec.EditorLanguageIDs = []string{"typescriptreact"}
+	tsxSpec.ShebangInterpreters = nil
+	tsxSpec.SyntaxTypes = map[string][]string{
+		SyntaxMarkupElement:   {"jsx_opening_element", "jsx_closing_element", "jsx_self_closing_element"},
+		SyntaxMarkupAttribute: {"jsx_attribute"},
+	}
+	for construct, constructTypes := range typescriptSpec.SyntaxTypes {
+		tsxSpec.SyntaxTypes[construct] = constructTypes
+	}
+	tsxSpec.Fields = map[string][]string{
+		FieldMember: {"property", "attribute", "name"},
+	}
+	for role, fields := range typescriptSpec.Fields {
+		if tsxSpec.Fields[role] == nil {
+			tsxSpec.Fields[role] = fields
+		}
+	}
+	tsxSpec.ContentHints = append([]string{
+		`<[A-Z]\w*[\s/>]`,
+		`(?m)return \(\s*$`,
+		`className=`,
+	}, typescriptSpec.ContentHints...)
+	Register(tsxSpec)
+
+	Register(Spec{
+		LanguageName:    "c",
+		GetGrammar:      c.GetLanguage,
+		FileExtensions:  []string{".c", ".h"},
+		FunctionTypes:   []string{"function_definition"},
+		CallTypes:       []string{"call_expression"},
+		AssignmentTypes: []string{"assignment_expression", "declaration"},
+		ParameterTypes:  []string{"parameter_declaration"},
+		LiteralTypes:    []string{"string_literal", "char_literal", "number_literal", "string_content"},
+		ControlTypes: map[string][]string{
+			ControlCondition:  {"if_statement", "conditional_expression"},
+			ControlLoop:       {"for_statement", "do_statement"},
+			ControlWhile:      {"while_statement"},
+			ControlSwitch:     {"switch_statement"},
+			ControlReturn:     {"return_statement"},
+			ControlCall:       {"call_expression"},
+			ControlIdentifier: {"identifier"},
+			ControlAssignment: {"assignment_expression"},
+		},
+		FunctionScopeTypes: []string{"function_definition"},
+		SyntaxTypes: map[string][]string{
+			SyntaxTypeWrapper:       {"parenthesized_expression"},
+			SyntaxVariadicParameter: {"variadic_parameter"},
+			SyntaxMemberAccess:      {"field_expression"},
+			SyntaxSubscript:         {"subscript_expression"},
+			SyntaxAccessWrapper:     {"parenthesized_expression"},
+			SyntaxStringPart:        {"string_content", "escape_sequence"},
+			SyntaxIdentifier:        {"identifier"},
+			SyntaxStatement:         {"expression_statement"},
+			SyntaxDeclarator:        {"init_declarator"},
+			SyntaxValueWrapper:      {"parenthesized_expression"},
+			SyntaxDetectedName:      {"identifier", "field_identifier"},
+			SyntaxImport:            {"preproc_include"},
+			SyntaxOccurrence:        {"identifier"},
+			SyntaxBlock:             {"compound_statement"},
+		},
+		Fields: map[string][]string{
+			FieldObject:    {"operand", "value", "argument"},
+			FieldQualifier: {"path"},
+			FieldMember:    {"field", "name"},
+			FieldKey:       {"index"},
+			FieldCallee:    {"function"},
+		},
+		ContentHints: []string{
+			`(?m)^#include <\w+\.h>`,
+			`\bprintf\(`,
+			`\bmalloc\(`,
+			`(?m)^int main\(`,
+		},
+	})
+
+	Register(Spec{
+		LanguageName:    "cpp",
+		GetGrammar:      cpp.GetLanguage,
+		FileExtensions:  []string{".cpp", ".cc", ".cxx", ".hpp", ".hh", ".hxx", ".h"},
+		FunctionTypes:   []string{"function_definition"},
+		CallTypes:       []string{"call_expression"},
+		AssignmentTypes: []string{"assignment_expression", "declaration"},
+		ParameterTypes:  []string{"parameter_declaration"},
+		LiteralTypes:    []string{"string_literal", "raw_string_literal", "char_literal", "number_literal", "string_content"},
+		ControlTypes: map[string][]string{
+			ControlCondition:  {"if_statement", "conditional_expression"},
+			ControlLoop:       {"for_statement", "do_statement"},
+			ControlWhile:      {"while_statement"},
+			ControlSwitch:     {"switch_statement"},
+			ControlReturn:     {"return_statement"},
+			ControlCall:       {"call_expression"},
+			ControlIdentifier: {"identifier"},
+			ControlException:  {"try_statement", "catch_clause"},
+			ControlAssignment: {"assignment_expression"},
+		},
+		FunctionScopeTypes: []string{"function_definition"},
+		SyntaxTypes: map[string][]string{
+			SyntaxInstantiation:     {"new_expression"},
+			SyntaxTypeWrapper:       {"parenthesized_expression"},
+			SyntaxVariadicParameter: {"variadic_parameter_declaration"},
+			SyntaxClosure:           {"lambda_expression"},
+			SyntaxMemberAccess:      {"field_expression"},
+			SyntaxSubscript:         {"subscript_expression"},
+			SyntaxAccessBase:        {"this"},
+			SyntaxAccessWrapper:     {"parenthesized_expression", "subscript_argument_list"},
+			SyntaxStringPart:        {"string_content", "escape_sequence"},
+			SyntaxIdentifier:        {"identifier"},
+			SyntaxStatement:         {"expression_statement"},
+			SyntaxDeclarator:        {"init_declarator"},
+			SyntaxValueWrapper:      {"parenthesized_expression"},
+			SyntaxDetectedName:      {"identifier", "field_identifier"},
+			SyntaxQualifiedPath:     {"qualified_identifier"},
+			SyntaxImport:            {"preproc_include"},
+			SyntaxOccurrence:        {"identifier"},
+			SyntaxBlock:             {"compound_statement"},
+		},
+		Fields: map[string][]string{
+			FieldObject:    {"operand", "value", "argument"},
+			FieldQualifier: {"scope", "path"},
+			FieldMember:    {"field", "name"},
+			FieldKey:       {"indices"},
+			FieldCallee:    {"function"},
+		},
+		LanguageAliases: []string{"c++", "cxx", "cc", "hpp"},
+		ContentHints: []string{
+			`\bstd::`,
+			`(?m)^#include <\w+>`,
+			`(?m)^\s*(namespace \w+|template\s*<)`,
+			`\bcout\s*<<`,
+		},
+	})
+
+	Register(Spec{
+		LanguageName:    "csharp",
+		GetGrammar:      csharp.GetLanguage,
+		FileExtensions:  []string{".cs"},
+		FunctionTypes:   []string{"method_declaration"},
+		CallTypes:       []string{"invocation_expression"},
+		AssignmentTypes: []string{"assignment_expression", "local_declaration_statement", "variable_declaration"},
+		ParameterTypes:  []string{"parameter"},
+		LiteralTypes: []string{
+			"raw_string_literal", "string_literal", "character_literal", "integer_literal", "real_literal",
+			"string_content", "string_literal_content",
+		},
+		ControlTypes: map[string][]string{
+			ControlCondition:  {"if_statement", "conditional_expression"},
+			ControlLoop:       {"for_statement", "foreach_statement", "do_statement"},
+			ControlWhile:      {"while_statement"},
+			ControlSwitch:     {"switch_statement", "switch_expression"},
+			ControlReturn:     {"return_statement"},
+			ControlCall:       {"invocation_expression"},
+			ControlIdentifier: {"identifier"},
+			ControlException:  {"try_statement", "catch_clause", "finally_clause"},
+			ControlAssignment: {"assignment_expression"},
+		},
+		FunctionScopeTypes: []string{"method_declaration"},
+		ClassScopeTypes:    []string{"class_declaration", "interface_declaration"},
+		SyntaxTypes: map[string][]string{
+			SyntaxMethodOwner:       {"class_declaration", "struct_declaration", "record_declaration", "interface_declaration"},
+			SyntaxInstantiation:     {"object_creation_expression"},
+			SyntaxTypeWrapper:       {"parenthesized_expression", "await_expression"},
+			SyntaxDeclaredType:      {"nullable_type"},
+			SyntaxPositionalPattern: {"tuple_pattern", "list_pattern", "tuple_expression"},
+			SyntaxPositionalValue:   {"tuple_expression"},
+			SyntaxClosure:           {"lambda_expression", "anonymous_method_expression"},
+			SyntaxMemberAccess:      {"member_access_expression"},
+			SyntaxSubscript:         {"element_access_expression"},
+			SyntaxAccessBase:        {"this"},
+			SyntaxAccessWrapper:     {"argument", "parenthesized_expression", "bracketed_argument_list"},
+			SyntaxStringPart:        {"string_content", "string_literal_content", "escape_sequence"},
+			SyntaxIdentifier:        {"identifier"},
+			SyntaxStatement:         {"expression_statement"},
+			SyntaxDeclarator:        {"variable_declarator"},
+			SyntaxTrailingValue:     {"variable_declarator"},
+			SyntaxFieldDeclaration:  {"field_declaration", "property_declaration"},
+			SyntaxValueWrapper:      {"parenthesized_expression", "await_expression", "as_expression"},
+			SyntaxKeywordArgument:   {"argument"},
+			SyntaxVariadicModifier:  {"params"},
+			SyntaxDetectedName:      {"identifier"},
+			SyntaxQualifiedPath:     {"qualified_name"},
+			SyntaxImport:            {"using_directive"},
+			SyntaxOccurrence:        {"identifier", "implicit_parameter"},
+			SyntaxBlock:             {"block"},
+		},
+		Fields: map[string][]string{
+			FieldObject: {"value", "argument", "expression"},
+			FieldMember: {"name"},
+			FieldKey:    {"subscript"},
+			FieldCallee: {"function"},
+		},
+		LanguageAliases: []string{"cs", "c#", "dotnet"},
+		ContentHints: []string{
+			`(?m)^using System`,
+			`(?m)^\s*namespace [\w.]+`,
+			`Console\.Write`,
+			`static void Main\(`,
+		},
+	})
+
+	Register(Spec{
+		LanguageName:    "php",
+		GetGrammar:      php.GetLanguage,
+		FileExtensions:  []string{".php"},
+		FunctionTypes:   []string{"method_declaration", "function_definition"},
+		CallTypes:       []string{"function_call_expression"},
+		AssignmentTypes: []string{"assignment_expression", "reference_assignment_expression", "const_declaration"},
+		ParameterTypes:  []string{"simple_parameter"},
+		LiteralTypes:    []string{"string", "encapsed_string", "integer", "float", "string_content"},
+		ControlTypes: map[string][]string{
+			ControlCondition:  {"if_statement", "conditional_expression"},
+			ControlLoop:       {"for_statement", "foreach_statement", "do_statement"},
+			ControlWhile:      {"while_statement"},
+			ControlSwitch:     {"switch_statement", "match_expression"},
+			ControlReturn:     {"return_statement"},
+			ControlCall:       {"function_call_expression"},
+			ControlIdentifier: {"variable_name"},
+			ControlException:  {"try_statement", "catch_clause", "finally_clause"},
+			ControlAssignment: {"assignment_expression"},
+		},
+		FunctionScopeTypes: []string{"function_definition", "method_declaration", "anonymous_function_creation_expression"},
+		ClassScopeTypes:    []string{"class_declaration", "namespace_name", "interface_declaration", "trait_declaration"},
+		SyntaxTypes: map[string][]string{
+			SyntaxMethodOwner:       {"class_declaration", "interface_declaration", "trait_declaration"},
+			SyntaxInstantiation:     {"object_creation_expression"},
+			SyntaxTypeWrapper:       {"parenthesized_expression"},
+			SyntaxVariadicParameter: {"variadic_parameter"},
+			SyntaxSpreadArgument:    {"variadic_unpacking"},
+			SyntaxPositionalPattern: {"list_literal"},
+			SyntaxPositionalValue:   {"array_creation_expression"},
+			SyntaxClosure:           {"arrow_function", "anonymous_function_creation_expression"},
+			SyntaxMemberAccess:      {"member_access_expression", "nullsafe_member_access_expression"},
+			SyntaxSubscript:         {"subscript_expression"},
+			SyntaxAccessWrapper:     {"argument", "parenthesized_expression", "array_element_initializer"},
+			SyntaxStringPart:        {"string_content", "escape_sequence"},
+			SyntaxIdentifier:        {"variable_name", "name"},
+			SyntaxSigilVariable:     {"variable_name"},
+			SyntaxStatement:         {"expression_statement"},
+			SyntaxFieldDeclaration:  {"property_declaration"},
+			SyntaxValueWrapper:      {"parenthesized_expression"},
+			SyntaxImport:            {"namespace_use_declaration", "use_declaration"},
+			SyntaxKeywordArgument:   {"argument"},
+			SyntaxTemplateMarkup:    {"text", "php_tag"},
+			SyntaxDetectedName:      {"name"},
+			SyntaxQualifiedPath:     {"qualified_name", "namespace_name"},
+			SyntaxMethodCall:        {"member_call_expression", "scoped_call_expression"},
+			SyntaxOccurrence:        {"variable_name"},
+			SyntaxBlock:             {"compound_statement"},
+		},
+		Fields: map[string][]string{
+			FieldObject:    {"object", "value", "argument"},
+			FieldQualifier: {"scope"},
+			FieldMember:    {"name"},
+			FieldCallee:    {"function", "name"},
+		},
+		ShebangInterpreters: []string{"php"},
+		ContentHints: []string{
+			`<\?php`,
+			`\$\w+\s*=`,
+			`\becho\b`,
+			`(?m)^\s*function \w+\(\$`,
+		},
+	})
+
+	Register(Spec{
+		LanguageName:    "ruby",
+		GetGrammar:      ruby.GetLanguage,
+		FileExtensions:  []string{".rb"},
+		FunctionTypes:   []string{"method"},
+		CallTypes:       []string{"call"},
+		AssignmentTypes: []string{"assignment"},
+		LiteralTypes:    []string{"string", "integer", "float", "string_content"},
+		ControlTypes: map[string][]string{
+			ControlCondition:  {"if"},
+			ControlLoop:       {"for"},
+			ControlWhile:      {"while"},
+			ControlCall:       {"call"},
+			ControlIdentifier: {"identifier"},
+		},
+		FunctionScopeTypes: []string{"method"},
+		SyntaxTypes: map[string][]string{
+			SyntaxMethodOwner:           {"class", "module"},
+			SyntaxVariadicParameter:     {"splat_parameter"},
+			SyntaxKeywordsParameter:     {"hash_splat_parameter"},
+			SyntaxSpreadArgument:        {"splat_argument"},
+			SyntaxKeywordSpreadArgument: {"hash_splat_argument"},
+			SyntaxDictionary:            {"hash"},
+			SyntaxPositionalPattern:     {"left_assignment_list", "array_pattern"},
+			SyntaxPositionalValue:       {"right_assignment_list", "array"},
+			SyntaxRestPattern:           {"rest_assignment", "splat_parameter"},
+			SyntaxClosure:               {"lambda"},
+			SyntaxReturnedValues:        {"argument_list"},
+			SyntaxMemberAccess:          {"call"},
+			SyntaxSubscript:             {"element_reference"},
+			SyntaxAccessBase:            {"self", "instance_variable"},
+			SyntaxStringPart:            {"string_content", "escape_sequence"},
+			SyntaxIdentifier:            {"identifier"},
+			SyntaxConstant:              {"constant"},
+			SyntaxInstanceField:         {"instance_variable"},
+			SyntaxKeywordArgument:       {"pair"},
+			SyntaxKeywordHashArgument:   {"hash_splat_argument"},
+			SyntaxKeywordParameter:      {"keyword_parameter"},
+			SyntaxDictionaryEntry:       {"pair"},
+			SyntaxSymbol:                {"simple_symbol", "hash_key_symbol"},
+			SyntaxDetectedName:          {"identifier", "constant"},
+			SyntaxOccurrence:            {"identifier"},
+			SyntaxBlock:                 {"block", "body_statement"},
+		},
+		Fields: map[string][]string{
+			FieldObject:    {"object", "operand", "value", "receiver"},
+			FieldQualifier: {"scope"},
+			FieldMember:    {"name", "method"},
+			FieldCallee:    {"method"},
+		},
+		LanguageAliases:     []string{"rb"},
+		ShebangInterpreters: []string{"ruby", "jruby"},
+		ContentHints: []string{
+			`(?m)^\s*def \w+[^:]*$`,
+			`(?m)^\s*end\s*$`,
+			`(?m)^\s*puts `,
+			`(?m)^require ["\']`,
+		},
+	})
+
+	Register(Spec{
+		LanguageName:    "rust",
+		GetGrammar:      rust.GetLanguage,
+		FileExtensions:  []string{".rs"},
+		FunctionTypes:   []string{"function_item"},
+		CallTypes:       []string{"call_expression", "macro_invocation"},
+		AssignmentTypes: []string{"assignment_expression", "let_declaration", "static_item"},
+		ParameterTypes:  []string{"parameter"},
+		LiteralTypes:    []string{"raw_string_literal", "string_literal", "char_literal", "integer_literal", "float_literal", "string_content"},
+		ControlTypes: map[string][]string{
+			ControlCondition:  {"if_expression"},
+			ControlLoop:       {"for_expression", "loop_expression"},
+			ControlWhile:      {"while_expression"},
+			ControlSwitch:     {"match_expression"},
+			ControlReturn:     {"return_expression"},
+			ControlCall:       {"call_expression"},
+			ControlIdentifier: {"identifier"},
+			ControlAssignment: {"assignment_expression"},
+		},
+		FunctionScopeTypes: []string{"function_item"},
+		SyntaxTypes: map[string][]string{
+			SyntaxMethodOwner:       {"impl_item", "trait_item"},
+			SyntaxInstantiation:     {"struct_expression"},
+			SyntaxTypeWrapper:       {"parenthesized_expression", "reference_expression", "await_expression"},
+			SyntaxVariadicParameter: {"variadic_parameter"},
+			SyntaxPositionalPattern: {"tuple_pattern", "slice_pattern", "tuple_expression"},
+			SyntaxPositionalValue:   {"tuple_expression"},
+			SyntaxObjectPattern:     {"struct_pattern"},
+			SyntaxClosure:           {"closure_expression"},
+			SyntaxMemberAccess:      {"field_expression"},
+			SyntaxSubscript:         {"index_expression"},
+			SyntaxAccessBase:        {"self"},
+			SyntaxAccessWrapper:     {"parenthesized_expression"},
+			SyntaxScopedName:        {"scoped_identifier"},
+			SyntaxStringPart:        {"string_content", "escape_sequence"},
+			SyntaxIdentifier:        {"identifier"},
+			SyntaxStatement:         {"expression_statement"},
+			SyntaxStatic:            {"static_item"},
+			SyntaxValueWrapper:      {"parenthesized_expression", "await_expression", "try_expression"},
+			SyntaxImport:            {"use_declaration"},
+			SyntaxShorthandProperty: {"shorthand_field_identifier"},
+			SyntaxPropertyPattern:   {"field_pattern"},
+			SyntaxDetectedName:      {"identifier", "field_identifier"},
+			SyntaxQualifiedPath:     {"scoped_identifier"},
+			SyntaxOccurrence:        {"identifier", "shorthand_field_identifier"},
+			SyntaxBlock:             {"block"},
+		},
+		Fields: map[string][]string{
+			FieldObject:    {"value", "argument"},
+			FieldQualifier: {"path"},
+			FieldMember:    {"field", "name"},
+			FieldCallee:    {"function", "macro"},
+		},
+		LanguageAliases:     []string{"rs"},
+		ShebangInterpreters: []string{"rust-script"},
+		ContentHints: []string{
+			`(?m)^\s*(pub )?fn \w+`,
+			`\blet mut\b`,
+			`(?m)^use \w+::`,
+			`println!\(`,
+		},
+	})
+
+	Register(kotlinSpec{Spec{
+		LanguageName:    "kotlin",
+		GetGrammar:      kotlin.GetLanguage,
+		FileExtensions:  []string{".kt", ".kts"},
+		FunctionTypes:   []string{"function_declaration"},
+		CallTypes:       []string{"call_expression"},
+		AssignmentTypes: []string{"property_declaration", "assignment"},
+		ParameterTypes:  []string{"parameter", "class_parameter"},
+		LiteralTypes: []string{
+			"string_literal", "character_literal", "integer_literal", "long_literal", "hex_literal", "bin_literal", "real_literal",
+			"string_content",
+		},
+		// The control kinds are shared by node type: "assignment" and "try_expression" keep their meaning in Python, Ruby and Rust
+		ControlTypes: map[string][]string{
+			ControlCondition:  {"if_expression"},
+			ControlLoop:       {"for_statement", "do_while_statement"},
+			ControlWhile:      {"while_statement"},
+			ControlSwitch:     {"when_expression"},
+			ControlReturn:     {"jump_expression"},
+			ControlCall:       {"call_expression"},
+			ControlIdentifier: {"simple_identifier", "interpolated_identifier"},
+			ControlException:  {"catch_block", "finally_block"},
+		},
+		FunctionScopeTypes: []string{"function_declaration"},
+		ClassScopeTypes:    []string{"class_declaration", "object_declaration"},
+		SyntaxTypes: map[string][]string{
+			SyntaxMethodOwner:      {"class_declaration", "object_declaration"},
+			SyntaxTypeWrapper:      {"parenthesized_expression"},
+			SyntaxDeclaredType:     {"user_type", "nullable_type"},
+			SyntaxSpreadArgument:   {"spread_expression"},
+			SyntaxClosure:          {"lambda_literal", "anonymous_function"},
+			SyntaxMemberAccess:     {"navigation_expression", "directly_assignable_expression"},
+			SyntaxSubscript:        {"indexing_expression"},
+			SyntaxAccessBase:       {"this_expression"},
+			SyntaxAccessWrapper:    {"value_argument", "parenthesized_expression", "indexing_suffix"},
+			SyntaxSuffixedAccess:   {"navigation_expression", "directly_assignable_expression", "indexing_expression"},
+			SyntaxMemberSuffix:     {"navigation_suffix"},
+			SyntaxKeySuffix:        {"indexing_suffix"},
+			SyntaxStringPart:       {"string_content"},
+			SyntaxIdentifier:       {"identifier"},
+			SyntaxTrailingValue:    {"property_declaration"},
+			SyntaxValueWrapper:     {"parenthesized_expression", "try_expression", "as_expression"},
+			SyntaxTypeName:         {"type_identifier", "simple_identifier"},
+			SyntaxImport:           {"import_list", "import_header"},
+			SyntaxKeywordArgument:  {"value_argument"},
+			SyntaxVariadicModifier: {"parameter_modifiers"},
+			SyntaxDetectedName:     {"identifier", "simple_identifier", "interpolated_identifier"},
+			SyntaxInterpolation:    {"interpolated_identifier", "interpolated_expression"},
+			SyntaxOccurrence:       {"identifier", "simple_identifier"},
+			SyntaxBlock:            {"statements"},
+		},
+		LanguageAliases:     []string{"kt", "kts"},
+		ShebangInterpreters: []string{"kotlin", "kotlinc"},
+		ContentHints: []string{
+			`(?m)^\s*(private |internal |override |suspend )*fun (\w+\.)?\w+\(`,
+			`(?m)^\s*(val|var) \w+(: [\w<>?]+)? = `,
+			`\bprintln\(`,
+			`(?m)^\s*(data class|object|companion object) \w*`,
+		},
+	}})
+
+	Register(shellSpec{Spec{
+		LanguageName:    "bash",
+		GetGrammar:      bash.GetLanguage,
+		FileExtensions:  []string{".sh", ".bash"},
+		FunctionTypes:   []string{"function_definition"},
+		CallTypes:       []string{"command"},
+		AssignmentTypes: []string{"variable_assignment", "declaration_command"},
+		LiteralTypes:    []string{"string", "raw_string", "ansi_c_string", "number", "string_content"},
+		// "case_statement" is a case label in C and PHP, the control kinds being shared by node type it is left out
+		ControlTypes: map[string][]string{
+			ControlCondition:  {"if_statement", "test_command"},
+			ControlLoop:       {"for_statement", "c_style_for_statement"},
+			ControlWhile:      {"while_statement"},
+			ControlCall:       {"command"},
+			ControlIdentifier: {"variable_name", "special_variable_name"},
+		},
+		FunctionScopeTypes: []string{"function_definition"},
+		SyntaxTypes: map[string][]string{
+			SyntaxPositionalValue: {"array"},
+			SyntaxSubscript:       {"subscript"},
+			SyntaxStringPart:      {"string_content"},
+			SyntaxIdentifier:      {"variable_name"},
+			SyntaxCommandName:     {"command_name"},
+			SyntaxValueWrapper:    {"parenthesized_expression"},
+			SyntaxDetectedName:    {"variable_name", "special_variable_name", "command_name"},
+			SyntaxInterpolation:   {"simple_expansion", "expansion", "command_substitution"},
+			SyntaxOccurrence:      {"variable_name"},
+			SyntaxBlock:           {"compound_statement"},
+		},
+		Fields: map[string][]string{
+			FieldObject: {"value", "argument"},
+			FieldMember: {"name"},
+			FieldKey:    {"index"},
+		},
+		LanguageAliases:     []string{"sh", "shell"},
+		EditorLanguageIDs:   []string{"shellscript"},
+		ShebangInterpreters: []string{"bash", "sh", "dash", "ash", "ksh", "zsh"},
+		ContentHints: []string{
+			`(?m)^\s*(local|export|declare|readonly)( -\w+)* \w+=`,
+			`(?m)^\s*(fi|done|esac)\s*$`,
+			`(?m)^\s*(if|while) \[\[? `,
+			`\$\([a-z]+ `,
+		},
+	}})
+}
+
+// goSpec is the specification of Go, whose struct literals name their fields by identifiers like its variables
+type goSpec struct {
+	Spec
+}
+
+// VariableNodes returns the value of a keyed element naming a field (root: path in Disk{root: path}), the keys of a map literal being values
+func (goSpec) VariableNodes(node *sitter.Node) ([]*sitter.Node, bool) {
+	if node.Type() != "keyed_element" || node.NamedChildCount() != 2 {
+		return nil, false
+	}
+	key := node.NamedChild(0)
+	if key.NamedChildCount() != 1 || key.NamedChild(0).Type() != "identifier" {
+		return nil, false
+	}
+	if literal := node.Parent(); literal != nil && literal.Parent() != nil && literal.Parent().Type() == "composite_literal" {
+		if literalType := literal.Parent().ChildByFieldName("type"); literalType != nil && literalType.Type() == "map_type" {
+			return nil, false
+		}
+	}
+	return []*sitter.Node{node.NamedChild(1)}, true
+}
+
+// kotlinSpec is the specification of Kotlin, whose member names and argument names are simple identifiers like its variables
+type kotlinSpec struct {
+	Spec
+}
+
+// VariableNodes returns the value of a named argument (prefix = value) and nothing for a member name (.uppercase)
+func (kotlinSpec) VariableNodes(node *sitter.Node) ([]*sitter.Node, bool) {
+	switch {
+	case node.Type() == "navigation_suffix":
+		return nil, true
+	case node.Type() == "value_argument" && node.NamedChildCount() > 1:
+		return []*sitter.Node{node.NamedChild(int(node.NamedChildCount()) - 1)}, true
+	}
+	return nil, false
+}
+
+// Builtins of the shell reading variables from the standard input, with their options taking a value
+var shellReadBuiltins = map[string][]string{
+	"read":      {"-d", "-i", "-n", "-N", "-p", "-t", "-u"},
+	"mapfile":   {"-d", "-n", "-O", "-s", "-u", "-C", "-c"},
+	"readarray": {"-d", "-n", "-O", "-s", "-u", "-C", "-c"},
+}
+
+// shellSpec is the specification of the shell scripts, whose functions read their arguments as positional parameters ($1, $@)
+// and whose read builtins assign the variables named by their arguments
+type shellSpec struct {
+	Spec
+}
+
+// PositionalParameterIndex returns the index of the argument read by $1, $2..., and -1 for the whole list ($@, $*)
+func (shellSpec) PositionalParameterIndex(name string) (int, bool) {
+	if name == "@" || name == "*" {
+		return -1, true
+	}
+	position, err := strconv.Atoi(name)
+	if err != nil || position < 1 {
+		return 0, false
+	}
+	return position - 1, true
+}
+
+// PositionalParameterName returns the positional parameter reading the argument at an index ("1" for the first one)
+func (shellSpec) PositionalParameterName(index int) string {
+	return strconv.Itoa(index + 1)
+}
+
+// ExtractAssignment extracts the variables assigned by the read builtins (read -r name), which have no value in the script
+func (shellSpec) ExtractAssignment(node *sitter.Node, content []byte) ([]string, []string, bool) {
+	if node.Type() != "command" {
+		return nil, nil, false
+	}
+	name := node.ChildByFieldName("name")
+	if name == nil {
+		return nil, nil, false
+	}
+	valueOptions, isReadBuiltin := shellReadBuiltins[name.Content(content)]
+	if !isReadBuiltin {
+		return nil, nil, false
+	}
+
+	var lhs []string
+	skipValue := false
+	for i := 0; i < int(node.ChildCount()); i++ {
+		if node.FieldNameForChild(i) != "argument" {
+			continue
+		}
+		argument := node.Child(i)
+		text := argument.Content(content)
+		switch {
+		case skipValue:
+			skipValue = false
+		case strings.HasPrefix(text, "-"):
+			// The value of an option can be attached (-p"Name: ") or be the next argument
+			for _, option := range valueOptions {
+				if text == option {
+					skipValue = true
+				}
+			}
+		case argument.Type() == "word":
+			lhs = append(lhs, text)
+		}
+	}
+	return lhs, nil, true
+}
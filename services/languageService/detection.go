@@ -0,0 +1,345 @@
+// Detection of the language of a file from its name and content: modelines, shebang lines, file extensions and content heuristics. Also resolves the aliases of the language names.
+
+package languageService
+
+import (
+	"dataflow/logger"
+	"dataflow/services/utilityService"
+
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Detectable is implemented by the languages that can be recognized from an alias, a shebang line or their content
+type Detectable interface {
+	Aliases() []string         // Other names of the language (e.g. "py" for python), case-insensitive
+	Interpreters() []string    // Interpreters named by the shebang lines of the scripts, without their version (e.g. "python")
+	ContentPatterns() []string // Regular expressions matching constructs typical of the language
+}
+
+// EditorLanguage is implemented by the languages that editors identify by another name (the languageId of the LSP documents)
+type EditorLanguage interface {
+	LanguageIDs() []string // Identifiers of the language in the editors besides its name (e.g. "javascriptreact")
+}
+
+func (s Spec) Aliases() []string         { return s.LanguageAliases }
+func (s Spec) Interpreters() []string    { return s.ShebangInterpreters }
+func (s Spec) ContentPatterns() []string { return s.ContentHints }
+func (s Spec) LanguageIDs() []string     { return s.EditorLanguageIDs }
+
+// Lines searched for a modeline at the beginning and at the end of a file
+const modelineSearchLines = 5
+
+// Modelines of Vim ("vim: set ft=python:") and Emacs ("-*- mode: python -*-")
+var (
+	vimModeline   = regexp.MustCompile(`(?:^|\s)(?:vi|vim|ex)(?:[<=>]?\d+)?:.*?\b(?:ft|filetype|syntax)=([\w+#-]+)`)
+	emacsModeline = regexp.MustCompile(`-\*-\s*(?:.*?\bmode:\s*)?([\w+#-]+)\s*(?:;.*?)?-\*-`)
+)
+
+// Content patterns compiled on first use, by source
+var contentPatterns = struct {
+	sync.Mutex
+	compiled map[string]*regexp.Regexp
+}{compiled: make(map[string]*regexp.Regexp)}
+
+// -----------------------------------------------------------------------------
+// NormalizeLanguage - Returns the name of a registered language from its name or one of its aliases.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The name or the alias of the language, case-insensitive (e.g. "py", "C++", "golang").
+//
+// Returns:
+//   - (string): The name of the language, or an empty string if no registered language has this name.
+//
+// -----------------------------------------------------------------------------
+func NormalizeLanguage(language string) string {
+	language = strings.ToLower(strings.TrimSpace(language))
+	if language == "" {
+		return ""
+	}
+	for _, spec := range getSpecs() {
+		if spec.Name() == language {
+			return spec.Name()
+		}
+	}
+	for _, spec := range getSpecs() {
+		if detectable, ok := spec.(Detectable); ok {
+			for _, alias := range detectable.Aliases() {
+				if strings.ToLower(alias) == language {
+					return spec.Name()
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// -----------------------------------------------------------------------------
+// LanguageFromEditorID - Returns the name of a registered language from its identifier in an editor.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - languageID (string): The identifier of the language of a document in the editor (e.g. "shellscript", "javascriptreact").
+//
+// Returns:
+//   - (string): The name of the language, or an empty string if no registered language has this identifier, name or alias.
+//
+// -----------------------------------------------------------------------------
+func LanguageFromEditorID(languageID string) string {
+	for _, spec := range getSpecs() {
+		if editorLanguage, ok := spec.(EditorLanguage); ok && utilityService.ContainsString(editorLanguage.LanguageIDs(), languageID) {
+			return spec.Name()
+		}
+	}
+	return NormalizeLanguage(languageID)
+}
+
+// -----------------------------------------------------------------------------
+// ResolveLanguage - Returns the language of an analysis: the given language, or the language detected from the file.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The requested language or one of its aliases, or an empty string to detect it.
+//   - filePath (string): The path of the analyzed file.
+//   - content ([]byte): The content of the file, or nil to read it from the path.
+//
+// Returns:
+//   - (string): The name of the language.
+//   - (error): An error if the requested language is not supported or the language could not be detected.
+//
+// -----------------------------------------------------------------------------
+func ResolveLanguage(language, filePath string, content []byte) (string, error) {
+	if language != "" {
+		name := NormalizeLanguage(language)
+		if name == "" {
+			return "", fmt.Errorf("unsupported language: %s", language)
+		}
+		return name, nil
+	}
+
+	// The file is read for the detection only, an unreadable file is detected from its name
+	if content == nil && filePath != "" {
+		content, _ = os.ReadFile(filePath)
+	}
+	name := DetectLanguage(filePath, content)
+	if name == "" {
+		return "", fmt.Errorf("unable to detect the language of %s, the language must be given", filePath)
+	}
+	return name, nil
+}
+
+// -----------------------------------------------------------------------------
+// DetectLanguage - Detects the language of a file from its modeline, its shebang line, its extension and its content.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - filePath (string): The path of the file, or an empty string.
+//   - content ([]byte): The content of the file, or nil.
+//
+// Returns:
+//   - (string): The name of the detected language, or an empty string if no language was recognized.
+//
+// -----------------------------------------------------------------------------
+func DetectLanguage(filePath string, content []byte) string {
+	if language := detectFromModeline(content); language != "" {
+		return language
+	}
+	if language := detectFromShebang(content); language != "" {
+		return language
+	}
+
+	// An extension shared by several languages (e.g. ".h") is decided by the content
+	candidates := getSpecsFromExtension(filePath)
+	if len(candidates) == 1 {
+		return candidates[0].Name()
+	}
+	if len(candidates) > 1 {
+		if language := detectFromContent(candidates, content); language != "" {
+			return language
+		}
+		return candidates[0].Name()
+	}
+
+	return detectFromContent(getSpecs(), content)
+}
+
+// -----------------------------------------------------------------------------
+// detectFromModeline - Detects the language named by a Vim or Emacs modeline.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - content ([]byte): The content of the file.
+//
+// Returns:
+//   - (string): The name of the language, or an empty string if there is no modeline naming a registered language.
+//
+// -----------------------------------------------------------------------------
+func detectFromModeline(content []byte) string {
+	lines := bytes.Split(content, []byte("\n"))
+	for i, line := range lines {
+		if i >= modelineSearchLines && i < len(lines)-modelineSearchLines {
+			continue
+		}
+		for _, modeline := range []*regexp.Regexp{vimModeline, emacsModeline} {
+			if match := modeline.FindSubmatch(line); match != nil {
+				if language := NormalizeLanguage(string(match[1])); language != "" {
+					return language
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// -----------------------------------------------------------------------------
+// detectFromShebang - Detects the language of a script from the interpreter of its shebang line.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - content ([]byte): The content of the file.
+//
+// Returns:
+//   - (string): The name of the language, or an empty string if there is no shebang naming a known interpreter.
+//
+// -----------------------------------------------------------------------------
+func detectFromShebang(content []byte) string {
+	if !bytes.HasPrefix(content, []byte("#!")) {
+		return ""
+	}
+	line := string(content[2:])
+	if end := strings.IndexByte(line, '\n'); end >= 0 {
+		line = line[:end]
+	}
+
+	// "#!/usr/bin/env -S node --flag" names the interpreter after env and its options
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return ""
+	}
+	interpreter := filepath.Base(fields[0])
+	if interpreter == "env" {
+		interpreter = ""
+		for _, field := range fields[1:] {
+			if !strings.HasPrefix(field, "-") && !strings.Contains(field, "=") {
+				interpreter = filepath.Base(field)
+				break
+			}
+		}
+	}
+
+	// The version of the interpreter is ignored (python3, ruby2.7)
+	interpreter = strings.TrimRight(strings.ToLower(interpreter), "0123456789.")
+	if interpreter == "" {
+		return ""
+	}
+	for _, spec := range getSpecs() {
+		if detectable, ok := spec.(Detectable); ok {
+			for _, name := range detectable.Interpreters() {
+				if name == interpreter {
+					return spec.Name()
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// -----------------------------------------------------------------------------
+// detectFromContent - Finds the language whose content patterns match the most in a content.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - candidates ([]LanguageSpec): The languages to choose from, by order of preference.
+//   - content ([]byte): The content of the file.
+//
+// Returns:
+//   - (string): The name of the best language, or an empty string if no pattern matched.
+//
+// -----------------------------------------------------------------------------
+func detectFromContent(candidates []LanguageSpec, content []byte) string {
+	if len(content) == 0 {
+		return ""
+	}
+
+	bestLanguage := ""
+	bestScore := 0
+	for _, spec := range candidates {
+		detectable, ok := spec.(Detectable)
+		if !ok {
+			continue
+		}
+		score := 0
+		for _, pattern := range detectable.ContentPatterns() {
+			if expression := compileContentPattern(pattern); expression != nil && expression.Match(content) {
+				score++
+			}
+		}
+		if score > bestScore {
+			bestLanguage = spec.Name()
+			bestScore = score
+		}
+	}
+	return bestLanguage
+}
+
+// -----------------------------------------------------------------------------
+// getSpecsFromExtension - Returns the languages whose files have the extension of a path.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - filePath (string): The path of the file.
+//
+// Returns:
+//   - ([]LanguageSpec): The languages of the extension, in registration order.
+//
+// -----------------------------------------------------------------------------
+func getSpecsFromExtension(filePath string) []LanguageSpec {
+	extension := strings.ToLower(filepath.Ext(filePath))
+	if extension == "" {
+		return nil
+	}
+
+	var specs []LanguageSpec
+	for _, spec := range getSpecs() {
+		for _, languageExtension := range spec.Extensions() {
+			if languageExtension == extension {
+				specs = append(specs, spec)
+				break
+			}
+		}
+	}
+	return specs
+}
+
+// -----------------------------------------------------------------------------
+// compileContentPattern - Compiles a content pattern, once.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - pattern (string): The regular expression.
+//
+// Returns:
+//   - (*regexp.Regexp): The compiled expression, or nil if it is invalid.
+//
+// -----------------------------------------------------------------------------
+func compileContentPattern(pattern string) *regexp.Regexp {
+	contentPatterns.Lock()
+	defer contentPatterns.Unlock()
+
+	expression, exists := contentPatterns.compiled[pattern]
+	if !exists {
+		var err error
+		expression, err = regexp.Compile(pattern)
+		if err != nil {
+			logger.PrintWarning("Invalid content pattern %q: %v", pattern, err)
+		}
+		contentPatterns.compiled[pattern] = expression
+	}
+	return expression
+}
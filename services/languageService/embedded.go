@@ -0,0 +1,403 @@
+// Extraction of the code embedded in templates: the <script> blocks of HTML, Vue and Svelte files, and the PHP islands and <script> blocks of PHP files.
+
+package languageService
+
+import (
+	"dataflow/logger"
+	"dataflow/models"
+
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+	"github.com/smacker/go-tree-sitter/html"
+)
+
+// Templates by extension, with the language of the document itself when its grammar parses the HTML around its code (PHP)
+var templateLanguages = map[string]string{
+	".html":   "",
+	".htm":    "",
+	".xhtml":  "",
+	".vue":    "",
+	".svelte": "",
+	".php":    "php",
+	".phtml":  "php",
+}
+
+// Languages of the <script> blocks, by value of their "lang" attribute (Vue, Svelte) or of their "type" attribute
+var scriptLanguages = map[string]string{
+	"":                       "javascript",
+	"js":                     "javascript",
+	"jsx":                    "javascript",
+	"ts":                     "typescript",
+	"tsx":                    "tsx",
+	"module":                 "javascript",
+	"text/javascript":        "javascript",
+	"application/javascript": "javascript",
+	"text/ecmascript":        "javascript",
+	"application/ecmascript": "javascript",
+	"text/babel":             "javascript",
+	"text/jsx":               "javascript",
+	"text/typescript":        "typescript",
+	"application/typescript": "typescript",
+}
+
+// -----------------------------------------------------------------------------
+// IsTemplateFile - Checks if a file is a template embedding the code of other languages (HTML, Vue, Svelte, PHP).
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - filePath (string): The path of the file.
+//
+// Returns:
+//   - (bool): True if the extension of the file is the one of a template.
+//
+// -----------------------------------------------------------------------------
+func IsTemplateFile(filePath string) bool {
+	_, exists := templateLanguages[strings.ToLower(filepath.Ext(filePath))]
+	return exists
+}
+
+// -----------------------------------------------------------------------------
+// ResolveLanguageAt - Returns the language of an analysis starting at a line: in a template, the language of the embedded region of the line, in a notebook, the language of its kernel.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The requested language or one of its aliases, or an empty string to detect it.
+//   - filePath (string): The path of the analyzed file.
+//   - content ([]byte): The content of the file, or nil to read it from the path.
+//   - line (int): The start line of the analysis (1-based, in its cell for a notebook).
+//
+// Returns:
+//   - (string): The name of the language.
+//   - (error): An error if the requested language is not supported or the language could not be detected.
+//
+// -----------------------------------------------------------------------------
+func ResolveLanguageAt(language, filePath string, content []byte, line int) (string, error) {
+	if language != "" || (!IsTemplateFile(filePath) && !IsNotebookFile(filePath)) {
+		return ResolveLanguage(language, filePath, content)
+	}
+
+	if content == nil {
+		var err error
+		content, err = os.ReadFile(filePath)
+		if err != nil {
+			return "", fmt.Errorf("error reading file: %v", err)
+		}
+	}
+
+	// The cells of a notebook are in the language of its kernel
+	if IsNotebookFile(filePath) {
+		notebook, err := LoadNotebook(content)
+		if err != nil {
+			return "", err
+		}
+		if name := NormalizeLanguage(notebook.Language); name != "" {
+			return name, nil
+		}
+		return "", fmt.Errorf("unsupported notebook language: %s", notebook.Language)
+	}
+
+	if region := FindEmbeddedRegion(ExtractEmbeddedRegions(filePath, content), line, ""); region != nil {
+		return region.Language, nil
+	}
+
+	// Outside the embedded regions, only a template with its own language can be analyzed
+	if host := templateLanguages[strings.ToLower(filepath.Ext(filePath))]; host != "" {
+		return host, nil
+	}
+	return "", fmt.Errorf("line %d of %s is not in an embedded script, the language must be given", line, filePath)
+}
+
+// -----------------------------------------------------------------------------
+// ExtractEmbeddedRegions - Finds the regions of a template written in another language: the <script> blocks and the PHP islands.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - filePath (string): The path of the file, whose extension tells if it is a template.
+//   - content ([]byte): The content of the file.
+//
+// Returns:
+//   - ([]models.EmbeddedRegion): The regions in the order of the file (a PHP island may be inside a <script> block), or nil if the file is not a template.
+//
+// -----------------------------------------------------------------------------
+func ExtractEmbeddedRegions(filePath string, content []byte) []models.EmbeddedRegion {
+	host, exists := templateLanguages[strings.ToLower(filepath.Ext(filePath))]
+	if !exists || len(content) == 0 {
+		return nil
+	}
+
+	// The HTML of a PHP file is what remains once its islands are blanked out
+	var islands []models.EmbeddedRegion
+	markup := content
+	if host == "php" {
+		islands = findPhpIslands(content)
+		markup = blankRegions(content, islands)
+	}
+
+	scripts := findScriptBlocks(markup)
+	regions := make([]models.EmbeddedRegion, 0, len(islands)+len(scripts))
+	for len(islands) > 0 || len(scripts) > 0 {
+		if len(scripts) == 0 || (len(islands) > 0 && islands[0].StartByte < scripts[0].StartByte) {
+			regions = append(regions, islands[0])
+			islands = islands[1:]
+		} else {
+			regions = append(regions, scripts[0])
+			scripts = scripts[1:]
+		}
+	}
+
+	return regions
+}
+
+// -----------------------------------------------------------------------------
+// FindEmbeddedRegion - Returns the innermost embedded region containing a line.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - regions ([]models.EmbeddedRegion): The regions of the template.
+//   - line (int): The line (1-based).
+//   - language (string): The language of the region, or an empty string for any language.
+//
+// Returns:
+//   - (*models.EmbeddedRegion): The smallest region containing the line, or nil if the line is outside the regions.
+//
+// -----------------------------------------------------------------------------
+func FindEmbeddedRegion(regions []models.EmbeddedRegion, line int, language string) *models.EmbeddedRegion {
+	var found *models.EmbeddedRegion
+	for i := range regions {
+		region := &regions[i]
+		if line < region.StartLine || line > region.EndLine || (language != "" && region.Language != language) {
+			continue
+		}
+		if found == nil || region.EndByte-region.StartByte < found.EndByte-found.StartByte {
+			found = region
+		}
+	}
+	return found
+}
+
+// -----------------------------------------------------------------------------
+// ExtractEmbeddedSource - Returns the code of a language embedded in a template, at the lines and columns it has in the file.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - filePath (string): The path of the file.
+//   - content ([]byte): The content of the file.
+//   - language (string): The language of the code to extract.
+//
+// Returns:
+//   - ([]byte): The content with everything outside the regions of the language replaced by spaces, or nil if the file is not a template or is analyzed as a whole (PHP).
+//
+// -----------------------------------------------------------------------------
+func ExtractEmbeddedSource(filePath string, content []byte, language string) []byte {
+	host, exists := templateLanguages[strings.ToLower(filepath.Ext(filePath))]
+	if !exists || language == host {
+		return nil
+	}
+
+	// The regions of the other languages nested in the kept ones (PHP islands in a <script> block) are blanked out too
+	var kept, others []models.EmbeddedRegion
+	for _, region := range ExtractEmbeddedRegions(filePath, content) {
+		if region.Language == language {
+			kept = append(kept, region)
+		} else {
+			others = append(others, region)
+		}
+	}
+
+	source := blankRegions(content, []models.EmbeddedRegion{{StartByte: 0, EndByte: uint32(len(content))}})
+	for _, region := range kept {
+		copy(source[region.StartByte:region.EndByte], content[region.StartByte:region.EndByte])
+	}
+	return blankRegions(source, others)
+}
+
+// -----------------------------------------------------------------------------
+// findPhpIslands - Finds the PHP code of a file: everything but the HTML text the PHP grammar leaves around it.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - content ([]byte): The content of the PHP file.
+//
+// Returns:
+//   - ([]models.EmbeddedRegion): The PHP islands, with their opening and closing tags.
+//
+// -----------------------------------------------------------------------------
+func findPhpIslands(content []byte) []models.EmbeddedRegion {
+	tree := ParseContent(content, "php")
+	if tree == nil {
+		return nil
+	}
+
+	var texts []*sitter.Node
+	var collect func(node *sitter.Node)
+	collect = func(node *sitter.Node) {
+		if node.Type() == "text" {
+			texts = append(texts, node)
+			return
+		}
+		for i := 0; i < int(node.NamedChildCount()); i++ {
+			collect(node.NamedChild(i))
+		}
+	}
+	collect(tree.RootNode())
+
+	var islands []models.EmbeddedRegion
+	start := uint32(0)
+	for _, text := range append(texts, nil) {
+		end := uint32(len(content))
+		if text != nil {
+			end = text.StartByte()
+		}
+		if strings.TrimSpace(string(content[start:end])) != "" {
+			islands = append(islands, newEmbeddedRegion(content, "php", start, end))
+		}
+		if text != nil {
+			start = text.EndByte()
+		}
+	}
+	return islands
+}
+
+// -----------------------------------------------------------------------------
+// findScriptBlocks - Finds the <script> blocks of an HTML document whose code is in a supported language.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - content ([]byte): The HTML document (a Vue or Svelte component, or the HTML of a PHP file).
+//
+// Returns:
+//   - ([]models.EmbeddedRegion): The code of the blocks, without the <script> tags.
+//
+// -----------------------------------------------------------------------------
+func findScriptBlocks(content []byte) []models.EmbeddedRegion {
+	root, err := sitter.ParseCtx(context.Background(), content, html.GetLanguage())
+	if err != nil {
+		logger.PrintWarning("Failed to parse the HTML of the template: %v", err)
+		return nil
+	}
+
+	var scripts []models.EmbeddedRegion
+	var collect func(node *sitter.Node)
+	collect = func(node *sitter.Node) {
+		if node.Type() != "script_element" {
+			for i := 0; i < int(node.NamedChildCount()); i++ {
+				collect(node.NamedChild(i))
+			}
+			return
+		}
+
+		var language string
+		var code *sitter.Node
+		for i := 0; i < int(node.NamedChildCount()); i++ {
+			switch child := node.NamedChild(i); child.Type() {
+			case "start_tag":
+				language = getScriptLanguage(child, content)
+			case "raw_text":
+				code = child
+			}
+		}
+
+		// A block loading its code from a file (src) has no code, a data block (JSON, template) no supported language
+		if language != "" && code != nil && strings.TrimSpace(code.Content(content)) != "" {
+			scripts = append(scripts, newEmbeddedRegion(content, language, code.StartByte(), code.EndByte()))
+		}
+	}
+	collect(root)
+	return scripts
+}
+
+// -----------------------------------------------------------------------------
+// getScriptLanguage - Returns the language of a <script> block from the attributes of its start tag.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - startTag (*sitter.Node): The start tag of the block.
+//   - content ([]byte): The HTML document.
+//
+// Returns:
+//   - (string): The name of the language, or an empty string if the block is not code of a supported language.
+//
+// -----------------------------------------------------------------------------
+func getScriptLanguage(startTag *sitter.Node, content []byte) string {
+	attributes := map[string]string{}
+	for i := 0; i < int(startTag.NamedChildCount()); i++ {
+		attribute := startTag.NamedChild(i)
+		if attribute.Type() != "attribute" || attribute.NamedChildCount() == 0 {
+			continue
+		}
+		name := strings.ToLower(attribute.NamedChild(0).Content(content))
+		value := ""
+		if attribute.NamedChildCount() > 1 {
+			value = strings.Trim(attribute.NamedChild(1).Content(content), `"'`)
+		}
+		attributes[name] = strings.ToLower(strings.TrimSpace(value))
+	}
+
+	// The "lang" attribute of the components wins over the MIME type
+	if lang, exists := attributes["lang"]; exists {
+		if language, known := scriptLanguages[lang]; known {
+			return language
+		}
+		return NormalizeLanguage(lang)
+	}
+	return scriptLanguages[attributes["type"]]
+}
+
+// -----------------------------------------------------------------------------
+// newEmbeddedRegion - Creates the embedded region of a byte range of a file.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - content ([]byte): The content of the file.
+//   - language (string): The language of the region.
+//   - start (uint32): The first byte of the region.
+//   - end (uint32): The byte following the region.
+//
+// Returns:
+//   - (models.EmbeddedRegion): The region, with its lines in the file.
+//
+// -----------------------------------------------------------------------------
+func newEmbeddedRegion(content []byte, language string, start, end uint32) models.EmbeddedRegion {
+	// A region ending with a line break does not reach the next line
+	last := end
+	if last > start && content[last-1] == '\n' {
+		last--
+	}
+	return models.EmbeddedRegion{
+		Language:  language,
+		StartByte: start,
+		EndByte:   end,
+		StartLine: strings.Count(string(content[:start]), "\n") + 1,
+		EndLine:   strings.Count(string(content[:last]), "\n") + 1,
+	}
+}
+
+// -----------------------------------------------------------------------------
+// blankRegions - Replaces the characters of regions by spaces, keeping the line breaks so that the lines and columns do not move.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - content ([]byte): The content.
+//   - regions ([]models.EmbeddedRegion): The regions to blank out.
+//
+// Returns:
+//   - ([]byte): A copy of the content with the regions blanked out.
+//
+// -----------------------------------------------------------------------------
+func blankRegions(content []byte, regions []models.EmbeddedRegion) []byte {
+	blanked := make([]byte, len(content))
+	copy(blanked, content)
+	for _, region := range regions {
+		for i := region.StartByte; i < region.EndByte; i++ {
+			if blanked[i] != '\n' && blanked[i] != '\r' {
+				blanked[i] = ' '
+			}
+		}
+	}
+	return blanked
+}
@@ -4,20 +4,32 @@ package languageService
 
 import (
 	"dataflow/logger"
+	"dataflow/models"
+	"path/filepath"
+	"strings"
 
 	sitter "github.com/smacker/go-tree-sitter"
-	"github.com/smacker/go-tree-sitter/c"
-	"github.com/smacker/go-tree-sitter/cpp"
-	"github.com/smacker/go-tree-sitter/csharp"
-	"github.com/smacker/go-tree-sitter/golang"
-	"github.com/smacker/go-tree-sitter/java"
-	"github.com/smacker/go-tree-sitter/javascript"
-	"github.com/smacker/go-tree-sitter/php"
-	"github.com/smacker/go-tree-sitter/python"
-	"github.com/smacker/go-tree-sitter/ruby"
-	"github.com/smacker/go-tree-sitter/rust"
 )
 
+// -----------------------------------------------------------------------------
+// GetSupportedLanguages - Returns the names of the supported languages.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - None
+//
+// Returns:
+//   - ([]string): The names of the supported languages, as accepted by the analysis.
+//
+// -----------------------------------------------------------------------------
+func GetSupportedLanguages() []string {
+	var languages []string
+	for _, spec := range getSpecs() {
+		languages = append(languages, spec.Name())
+	}
+	return languages
+}
+
 // -----------------------------------------------------------------------------
 // GetLanguage - Returns the language configuration for the specified language.
 // -----------------------------------------------------------------------------
@@ -30,31 +42,12 @@ import (
 //
 // -----------------------------------------------------------------------------
 func GetLanguage(language string) *sitter.Language {
-	switch language {
-	case "go":
-		return golang.GetLanguage()
-	case "python":
-		return python.GetLanguage()
-	case "java":
-		return java.GetLanguage()
-	case "javascript":
-		return javascript.GetLanguage()
-	case "c":
-		return c.GetLanguage()
-	case "cpp":
-		return cpp.GetLanguage()
-	case "csharp":
-		return csharp.GetLanguage()
-	case "php":
-		return php.GetLanguage()
-	case "ruby":
-		return ruby.GetLanguage()
-	case "rust":
-		return rust.GetLanguage()
-	default:
+	spec := GetSpec(language)
+	if spec == nil {
 		logger.PrintError("Unsupported language: %s", language)
 		return nil
 	}
+	return spec.Grammar()
 }
 
 // -----------------------------------------------------------------------------
@@ -70,39 +63,124 @@ func GetLanguage(language string) *sitter.Language {
 //
 // -----------------------------------------------------------------------------
 func ParseContent(content []byte, language string) *sitter.Tree {
-	logger.PrintDebug("Parsing content for language: %s", language)
+	spec := GetSpec(language)
+	if spec == nil {
+		// The caller reports the error of the missing tree
+		return nil
+	}
 	parser := sitter.NewParser()
+	parser.SetLanguage(spec.Grammar())
 
-	switch language {
-	case "go":
-		parser.SetLanguage(golang.GetLanguage())
-	case "python":
-		parser.SetLanguage(python.GetLanguage())
-	case "java":
-		parser.SetLanguage(java.GetLanguage())
-	case "javascript":
-		parser.SetLanguage(javascript.GetLanguage())
-	case "c":
-		parser.SetLanguage(c.GetLanguage())
-	case "cpp":
-		parser.SetLanguage(cpp.GetLanguage())
-	case "csharp":
-		parser.SetLanguage(csharp.GetLanguage())
-	case "php":
-		parser.SetLanguage(php.GetLanguage())
-	case "ruby":
-		parser.SetLanguage(ruby.GetLanguage())
-	case "rust":
-		parser.SetLanguage(rust.GetLanguage())
-	default:
-		logger.PrintWarning("Unsupported language: %s", language)
-		return nil
+	return parser.Parse(nil, content)
+}
+
+// Length of the code of an error region kept in its diagnostic
+const diagnosticTextLength = 80
+
+// -----------------------------------------------------------------------------
+// GetParseDiagnostics - Lists the syntax errors of a syntax tree: the regions the parser could not recognize and the elements it had to insert.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - root (*sitter.Node): The root node of the syntax tree.
+//   - content ([]byte): The parsed content.
+//
+// Returns:
+//   - ([]models.ParseDiagnostic): The ERROR and MISSING nodes of the tree, in the order of the content.
+//
+// -----------------------------------------------------------------------------
+func GetParseDiagnostics(root *sitter.Node, content []byte) []models.ParseDiagnostic {
+	var diagnostics []models.ParseDiagnostic
+	var collect func(node *sitter.Node)
+	collect = func(node *sitter.Node) {
+		// Only the branches holding an error are visited
+		if !node.HasError() && !node.IsMissing() {
+			return
+		}
+
+		switch {
+		case node.IsMissing():
+			diagnostics = append(diagnostics, newParseDiagnostic(node, models.DiagnosticKindMissing, node.Type()))
+			return
+		case node.IsError():
+			text := strings.Join(strings.Fields(node.Content(content)), " ")
+			if runes := []rune(text); len(runes) > diagnosticTextLength {
+				text = string(runes[:diagnosticTextLength]) + "..."
+			}
+			diagnostics = append(diagnostics, newParseDiagnostic(node, models.DiagnosticKindError, text))
+			return
+		}
+
+		for i := 0; i < int(node.ChildCount()); i++ {
+			collect(node.Child(i))
+		}
 	}
+	collect(root)
+	return diagnostics
+}
 
-	tree := parser.Parse(nil, content)
-	if tree == nil {
-		logger.PrintError("Failed to parse the input file content for language: %s", language)
+// -----------------------------------------------------------------------------
+// newParseDiagnostic - Creates the diagnostic of an ERROR or MISSING node.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - node (*sitter.Node): The node.
+//   - kind (string): The kind of the diagnostic (models.DiagnosticKindError or models.DiagnosticKindMissing).
+//   - text (string): The code of the region, or the missing element.
+//
+// Returns:
+//   - (models.ParseDiagnostic): The diagnostic, with 1-based lines and columns.
+//
+// -----------------------------------------------------------------------------
+func newParseDiagnostic(node *sitter.Node, kind, text string) models.ParseDiagnostic {
+	return models.ParseDiagnostic{
+		Kind:      kind,
+		Line:      int(node.StartPoint().Row) + 1,
+		Column:    int(node.StartPoint().Column) + 1,
+		EndLine:   int(node.EndPoint().Row) + 1,
+		EndColumn: int(node.EndPoint().Column) + 1,
+		Text:      text,
 	}
+}
 
-	return tree
+// -----------------------------------------------------------------------------
+// GetFileExtensions - Returns the source file extensions for the specified language.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The name of the programming language.
+//
+// Returns:
+//   - ([]string): The file extensions (with the leading dot) or nil if unsupported.
+//
+// -----------------------------------------------------------------------------
+func GetFileExtensions(language string) []string {
+	spec := GetSpec(language)
+	if spec == nil {
+		return nil
+	}
+	return spec.Extensions()
+}
+
+// -----------------------------------------------------------------------------
+// GetLanguageFromExtension - Returns the supported language of a file from its extension.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - filePath (string): The path of the file.
+//
+// Returns:
+//   - (string): The name of the language, or an empty string if the extension is not supported.
+//
+// -----------------------------------------------------------------------------
+func GetLanguageFromExtension(filePath string) string {
+	extension := strings.ToLower(filepath.Ext(filePath))
+	for _, spec := range getSpecs() {
+		for _, languageExtension := range spec.Extensions() {
+			if languageExtension == extension {
+				return spec.Name()
+			}
+		}
+	}
+	return ""
 }
@@ -0,0 +1,712 @@
+// Specification of a language for the analysis, and the registry of the supported languages. Built-in and third-party languages are registered the same way.
+
+package languageService
+
+import (
+	"sync"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// Control kinds of the statements a variable can be used in
+const (
+	ControlCondition   = "condition"
+	ControlLoop        = "loop"
+	ControlWhile       = "while"
+	ControlSwitch      = "switch"
+	ControlReturn      = "return"
+	ControlCall        = "call"
+	ControlConcurrency = "concurrency"
+	ControlIdentifier  = "identifier"
+	ControlException   = "exception"
+	ControlAssignment  = "assignment"
+)
+
+// Constructs the values are followed through, whose node kinds are given by each language
+const (
+	SyntaxMethodOwner           = "methodOwner"           // Classes, types and modules declaring methods (class Store, impl Store)
+	SyntaxInstantiation         = "instantiation"         // Instantiations of the type they name (Store{}, new Store())
+	SyntaxTypeWrapper           = "typeWrapper"           // Expressions wrapping a value of the same type (&Store{}, (new Store()))
+	SyntaxDeclaredType          = "declaredType"          // Declared types without a field (val a: Store)
+	SyntaxVariadicParameter     = "variadicParameter"     // Parameters receiving the positional arguments left (*args, ...rest)
+	SyntaxKeywordsParameter     = "keywordsParameter"     // Parameters receiving the keyword arguments left (**kwargs)
+	SyntaxSpreadArgument        = "spreadArgument"        // Arguments spreading a list (*args, ...args)
+	SyntaxKeywordSpreadArgument = "keywordSpreadArgument" // Arguments spreading a dictionary (**opts)
+	SyntaxDictionary            = "dictionary"            // Dictionary literals of key-value pairs ({"path": p})
+	SyntaxPositionalPattern     = "positionalPattern"     // Targets of a positional destructuring (a, b = ..., [a, b] = ...)
+	SyntaxPositionalValue       = "positionalValue"       // Values assigned by position (b, a in a, b = b, a)
+	SyntaxObjectPattern         = "objectPattern"         // Destructurings of the properties or fields of an object (const {path} = opts)
+	SyntaxRestPattern           = "restPattern"           // Targets receiving the values left by a positional destructuring (...rest, *rest)
+	SyntaxClosure               = "closure"               // Anonymous functions, whose return statements leave the anonymous function only
+	SyntaxReturnedValues        = "returnedValues"        // Lists of the values returned by a statement returning several values (return data, nil)
+	SyntaxMemberAccess          = "memberAccess"          // Member accesses (req.Path, $req->path)
+	SyntaxSubscript             = "subscript"             // Subscripts, whose constant keys are part of an access path (cfg["path"])
+	SyntaxAccessBase            = "accessBase"            // Names of the current object starting an access path (this, self)
+	SyntaxAccessWrapper         = "accessWrapper"         // Nodes wrapping a single expression without changing its value ((path))
+	SyntaxSuffixedAccess        = "suffixedAccess"        // Accesses chaining their members and keys as suffixes of the object, without fields (box.path)
+	SyntaxMemberSuffix          = "memberSuffix"          // Suffixes naming the member of a suffixed access (.path in box.path)
+	SyntaxKeySuffix             = "keySuffix"             // Suffixes giving the key of a suffixed access (["path"] in map["path"])
+	SyntaxScopedName            = "scopedName"            // Names qualified by their type or module (Store::new)
+	SyntaxStringPart            = "stringPart"            // Constant parts of a string literal, its other parts being interpolated ("path", "\n")
+	SyntaxIdentifier            = "identifier"            // Identifiers naming variables, functions and parameters (path, $path)
+	SyntaxConstant              = "constant"              // Names of constants, classes and modules read like variables (PATH, Store)
+	SyntaxCommandName           = "commandName"           // Names of the commands run by a shell (cat in cat $path)
+	SyntaxInstanceField         = "instanceField"         // Fields of the current object named without it (@store)
+	SyntaxSigilVariable         = "sigilVariable"         // Variables written with a sigil before their name ($path)
+	SyntaxStatement             = "statement"             // Statements made of a single expression (path = read();)
+	SyntaxLocalDeclaration      = "localDeclaration"      // Statements declaring or setting the variables of their left field in a function (a := b)
+	SyntaxDeclarator            = "declarator"            // Declarators naming a variable and its value in a declaration (a = b in int a = b)
+	SyntaxTrailingValue         = "trailingValue"         // Declarations ending with their value without a field (val a = b)
+	SyntaxStatic                = "static"                // Static declarations, whose name is matched regardless of case (static PATH: &str = p)
+	SyntaxFieldDeclaration      = "fieldDeclaration"      // Declarations of the fields of a class (private String path = p;)
+	SyntaxValueWrapper          = "valueWrapper"          // Expressions passing on the value they wrap, the value of a call included (await read(), read() as Store)
+	SyntaxTypeName              = "typeName"              // Names of the declared types without a field (class Store)
+	SyntaxImport                = "import"                // Imports of modules and names (import os, use std::fs)
+	SyntaxInstanceParameter     = "instanceParameter"     // Classes whose methods receive the instance as their first parameter (self in def read(self))
+	SyntaxDecorated             = "decorated"             // Declarations preceded by their decorators (@staticmethod def read())
+	SyntaxKeywordArgument       = "keywordArgument"       // Arguments naming the parameter they are passed to (path=p, path: p)
+	SyntaxKeywordHashArgument   = "keywordHashArgument"   // Arguments spreading a dictionary over the keyword parameters only (**opts)
+	SyntaxKeywordSeparator      = "keywordSeparator"      // Parameters after which the parameters are passed by keyword only (*, *args)
+	SyntaxPositionalSeparator   = "positionalSeparator"   // Separators of the parameters passed by position only (/)
+	SyntaxKeywordParameter      = "keywordParameter"      // Parameters passed by keyword only (path: in def read(path:))
+	SyntaxVariadicModifier      = "variadicModifier"      // Modifiers making the parameter they precede variadic when they hold params or vararg
+	SyntaxDictionaryEntry       = "dictionaryEntry"       // Key-value entries of a dictionary literal ("path": p)
+	SyntaxSymbol                = "symbol"                // Symbols naming a key (:path, path:)
+	SyntaxShorthandProperty     = "shorthandProperty"     // Properties of an object pattern named after the field they receive (path in const {path} = opts)
+	SyntaxPropertyPattern       = "propertyPattern"       // Properties of an object pattern naming the field they receive (mode: m)
+	SyntaxDefaultPattern        = "defaultPattern"        // Targets of a pattern with a default value (b = 2 in const {b = 2} = opts)
+	SyntaxTemplateMarkup        = "templateMarkup"        // Markup of a template around its code, copied to the output as it is (<h1> and <?php in <h1><?php echo $x; ?>)
+	SyntaxDetectedName          = "detectedName"          // Names detected on a line as variables, functions, fields or commands (path, .path, cat)
+	SyntaxModuleName            = "moduleName"            // Names given to an imported module besides the identifiers (h in import h "net/http")
+	SyntaxQualifiedPath         = "qualifiedPath"         // Paths made of several names, the last one naming what is imported (java.util.List, std::fs)
+	SyntaxMethodCall            = "methodCall"            // Calls of a method besides the calls of the language, naming it in their callee field ($db->query())
+	SyntaxMarkupElement         = "markupElement"         // Elements of the markup written in the code, named by a component or a tag (<Page />, <div>)
+	SyntaxMarkupAttribute       = "markupAttribute"       // Attributes of the markup elements, named by their first child (className="page")
+	SyntaxInterpolation         = "interpolation"         // Expressions interpolated in a string template ("$path", "${path}")
+	SyntaxOccurrence            = "occurrence"            // Names of the variables in the scopes of a file besides the identifiers ({path} in const {path} = opts)
+	SyntaxBlock                 = "block"                 // Blocks of statements, analyzed from their first child (block, compound_statement)
+)
+
+// Roles of the fields of the syntax tree, whose field names are given by each language
+const (
+	FieldObject    = "object"    // Objects of the member accesses and subscripts (req in req.path)
+	FieldQualifier = "qualifier" // Types, modules and paths qualifying a name (Store in Store::open)
+	FieldMember    = "member"    // Members named by the member accesses (path in req.path)
+	FieldKey       = "key"       // Keys of the subscripts ("path" in cfg["path"])
+	FieldCallee    = "callee"    // Functions and methods called by the calls (read in read(p), query in $db->query())
+)
+
+// LanguageSpec describes what the analysis needs to know about a language: its grammar and the node kinds of its syntax tree
+type LanguageSpec interface {
+	Name() string                          // Name of the language, as given to the analysis (e.g. "go")
+	Grammar() *sitter.Language             // Tree-sitter grammar of the language
+	Extensions() []string                  // Source file extensions, with the leading dot
+	FunctionNodeTypes() []string           // Function and method declarations
+	CallNodeTypes() []string               // Function and method calls
+	AssignmentNodeTypes() []string         // Assignments and declarations with initialization
+	ParameterNodeTypes() []string          // Parameter declarations
+	LiteralNodeTypes() []string            // Literal values, and the content nodes nested in them
+	ControlNodeTypes() map[string][]string // Statements using a variable, by control kind (ControlCondition, ControlLoop...)
+	FunctionScopeNodeTypes() []string      // Nodes opening a function scope, whose variables are not global
+	ClassScopeNodeTypes() []string         // Nodes opening a class or namespace scope, whose fields can be global
+	SyntaxNodeTypes() map[string][]string  // Constructs the values are followed through, by construct (SyntaxMemberAccess, SyntaxSubscript...)
+}
+
+// AssignmentExtractor is implemented by the languages whose assignments need their own extraction
+type AssignmentExtractor interface {
+	// ExtractAssignment returns the identifiers of both sides of an assignment, ok is false if the node is not handled
+	ExtractAssignment(node *sitter.Node, content []byte) (lhs []string, rhs []string, ok bool)
+}
+
+// IdentifierExtractor is implemented by the languages with nodes naming something else than a variable among their identifiers
+type IdentifierExtractor interface {
+	// VariableNodes returns the nodes holding the variables read under a node, ok is false if the node is not handled
+	VariableNodes(node *sitter.Node) (nodes []*sitter.Node, ok bool)
+}
+
+// FieldRoles is implemented by the languages whose syntax tree holds the objects, members, keys and callees in fields
+type FieldRoles interface {
+	// FieldNames returns the fields of each role (FieldObject, FieldMember...), in the order they are looked up
+	FieldNames() map[string][]string
+}
+
+// ParameterExtractor is implemented by the languages whose function parameters are not in a "parameters" field
+type ParameterExtractor interface {
+	// ParametersNode returns the node holding the parameters of a function, or nil if the node is not handled
+	ParametersNode(functionNode *sitter.Node) *sitter.Node
+}
+
+// ArgumentExtractor is implemented by the languages whose call arguments are not in an "arguments" field
+type ArgumentExtractor interface {
+	// ArgumentsNode returns the node holding the arguments of a call, or nil if the node is not handled
+	ArgumentsNode(callNode *sitter.Node) *sitter.Node
+}
+
+// PositionalParameters is implemented by the languages whose functions read their arguments by position instead of declaring parameters
+type PositionalParameters interface {
+	// PositionalParameterIndex returns the index of the argument read by a parameter, -1 for all of them, ok is false if the name is not positional
+	PositionalParameterIndex(name string) (index int, ok bool)
+	// PositionalParameterName returns the name of the parameter reading the argument at an index
+	PositionalParameterName(index int) string
+}
+
+// Spec is a LanguageSpec built from lists of node kinds, used by the built-in languages
+type Spec struct {
+	LanguageName        string
+	GetGrammar          func() *sitter.Language
+	FileExtensions      []string
+	FunctionTypes       []string
+	CallTypes           []string
+	AssignmentTypes     []string
+	ParameterTypes      []string
+	LiteralTypes        []string
+	ControlTypes        map[string][]string
+	FunctionScopeTypes  []string
+	ClassScopeTypes     []string
+	SyntaxTypes         map[string][]string
+	Fields              map[string][]string // Fields of the syntax tree by role (FieldObject, FieldMember...), in the order they are looked up
+	Queries             map[string]string   // Sources of the queries by kind (QueryAssignments...), the shipped queries otherwise
+	LanguageAliases     []string            // Other names accepted for the language (e.g. "py")
+	EditorLanguageIDs   []string            // Identifiers of the language in the editors besides its name (LSP languageId, e.g. "shellscript")
+	ShebangInterpreters []string            // Interpreters of the shebang lines of the scripts (e.g. "python")
+	ContentHints        []string            // Regular expressions recognizing the language in a content
+}
+
+func (s Spec) Name() string                          { return s.LanguageName }
+func (s Spec) Grammar() *sitter.Language             { return s.GetGrammar() }
+func (s Spec) Extensions() []string                  { return s.FileExtensions }
+func (s Spec) FunctionNodeTypes() []string           { return s.FunctionTypes }
+func (s Spec) CallNodeTypes() []string               { return s.CallTypes }
+func (s Spec) AssignmentNodeTypes() []string         { return s.AssignmentTypes }
+func (s Spec) ParameterNodeTypes() []string          { return s.ParameterTypes }
+func (s Spec) LiteralNodeTypes() []string            { return s.LiteralTypes }
+func (s Spec) ControlNodeTypes() map[string][]string { return s.ControlTypes }
+func (s Spec) FunctionScopeNodeTypes() []string      { return s.FunctionScopeTypes }
+func (s Spec) ClassScopeNodeTypes() []string         { return s.ClassScopeTypes }
+func (s Spec) SyntaxNodeTypes() map[string][]string  { return s.SyntaxTypes }
+func (s Spec) FieldNames() map[string][]string       { return s.Fields }
+
+// Categories of node kinds indexed by the registry, besides the constructs (SyntaxMemberAccess...)
+const (
+	functionCategory      = "function"
+	callCategory          = "call"
+	assignmentCategory    = "assignment"
+	parameterCategory     = "parameter"
+	literalCategory       = "literal"
+	functionScopeCategory = "functionScope"
+	classScopeCategory    = "classScope"
+)
+
+// Registered languages, in registration order, and the node kinds of each of them by category and by control kind.
+// The node kinds of all the languages skip the lookups of the nodes no language gives a role to.
+var registry = struct {
+	sync.RWMutex
+	specs        []LanguageSpec
+	nodeTypes    map[string]map[string]map[string]bool // By language, category and node kind
+	controlKinds map[string]map[string]string          // By language and node kind
+	allTypes     map[string]bool                       // Node kinds of a category or a control kind in any language
+}{}
+
+// -----------------------------------------------------------------------------
+// Register - Adds a language to the analysis, or replaces the language with the same name.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - spec (LanguageSpec): The specification of the language.
+//
+// Returns:
+//   - None
+//
+// -----------------------------------------------------------------------------
+func Register(spec LanguageSpec) {
+	registerSpec(spec)
+
+	// The queries compiled for the replaced language are compiled again on first use
+	resetQueries()
+}
+
+// -----------------------------------------------------------------------------
+// registerSpec - Adds a language to the registry and indexes its node kinds.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - spec (LanguageSpec): The specification of the language.
+//
+// Returns:
+//   - None
+//
+// -----------------------------------------------------------------------------
+func registerSpec(spec LanguageSpec) {
+	registry.Lock()
+	defer registry.Unlock()
+
+	replaced := false
+	for i, registered := range registry.specs {
+		if registered.Name() == spec.Name() {
+			registry.specs[i] = spec
+			replaced = true
+		}
+	}
+	if !replaced {
+		registry.specs = append(registry.specs, spec)
+	}
+
+	// The node kinds of a language are only recognized in its own syntax trees
+	nodeTypes := make(map[string]map[string]bool)
+	addNodeTypes(nodeTypes, functionCategory, spec.FunctionNodeTypes())
+	addNodeTypes(nodeTypes, callCategory, spec.CallNodeTypes())
+	addNodeTypes(nodeTypes, assignmentCategory, spec.AssignmentNodeTypes())
+	addNodeTypes(nodeTypes, parameterCategory, spec.ParameterNodeTypes())
+	addNodeTypes(nodeTypes, literalCategory, spec.LiteralNodeTypes())
+	addNodeTypes(nodeTypes, functionScopeCategory, spec.FunctionScopeNodeTypes())
+	addNodeTypes(nodeTypes, classScopeCategory, spec.ClassScopeNodeTypes())
+	for construct, constructTypes := range spec.SyntaxNodeTypes() {
+		addNodeTypes(nodeTypes, construct, constructTypes)
+	}
+
+	controlKinds := make(map[string]string)
+	for kind, controlTypes := range spec.ControlNodeTypes() {
+		for _, nodeType := range controlTypes {
+			controlKinds[nodeType] = kind
+		}
+	}
+
+	if registry.nodeTypes == nil {
+		registry.nodeTypes = make(map[string]map[string]map[string]bool)
+		registry.controlKinds = make(map[string]map[string]string)
+	}
+	registry.nodeTypes[spec.Name()] = nodeTypes
+	registry.controlKinds[spec.Name()] = controlKinds
+
+	registry.allTypes = make(map[string]bool)
+	for _, languageTypes := range registry.nodeTypes {
+		for _, categoryTypes := range languageTypes {
+			for nodeType := range categoryTypes {
+				registry.allTypes[nodeType] = true
+			}
+		}
+	}
+	for _, languageKinds := range registry.controlKinds {
+		for nodeType := range languageKinds {
+			registry.allTypes[nodeType] = true
+		}
+	}
+}
+
+// -----------------------------------------------------------------------------
+// GetSpec - Returns the specification of a registered language.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The name of the language, or one of its aliases.
+//
+// Returns:
+//   - (LanguageSpec): The specification of the language, or nil if it is not registered.
+//
+// -----------------------------------------------------------------------------
+func GetSpec(language string) LanguageSpec {
+	registry.RLock()
+	for _, spec := range registry.specs {
+		if spec.Name() == language {
+			registry.RUnlock()
+			return spec
+		}
+	}
+	registry.RUnlock()
+
+	if name := NormalizeLanguage(language); name != "" && name != language {
+		return GetSpec(name)
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// IsFunctionNode - Checks if a node declares a function or a method in the language of its syntax tree.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): The node to check.
+//
+// Returns:
+//   - (bool): True if the node is a function declaration.
+//
+// -----------------------------------------------------------------------------
+func IsFunctionNode(language string, node *sitter.Node) bool {
+	return hasNodeType(language, node, functionCategory)
+}
+
+// -----------------------------------------------------------------------------
+// IsCallNode - Checks if a node calls a function or a method in the language of its syntax tree.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): The node to check.
+//
+// Returns:
+//   - (bool): True if the node is a call.
+//
+// -----------------------------------------------------------------------------
+func IsCallNode(language string, node *sitter.Node) bool {
+	return hasNodeType(language, node, callCategory)
+}
+
+// -----------------------------------------------------------------------------
+// IsAssignmentNode - Checks if a node is an assignment or an initialized declaration in the language of its syntax tree.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): The node to check.
+//
+// Returns:
+//   - (bool): True if the node is an assignment or a declaration.
+//
+// -----------------------------------------------------------------------------
+func IsAssignmentNode(language string, node *sitter.Node) bool {
+	return hasNodeType(language, node, assignmentCategory)
+}
+
+// -----------------------------------------------------------------------------
+// IsParameterNode - Checks if a node declares a parameter in the language of its syntax tree.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): The node to check.
+//
+// Returns:
+//   - (bool): True if the node is a parameter declaration.
+//
+// -----------------------------------------------------------------------------
+func IsParameterNode(language string, node *sitter.Node) bool {
+	return hasNodeType(language, node, parameterCategory)
+}
+
+// -----------------------------------------------------------------------------
+// IsLiteralNode - Checks if a node is a literal value in the language of its syntax tree.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): The node to check.
+//
+// Returns:
+//   - (bool): True if the node is a literal or the content of a literal.
+//
+// -----------------------------------------------------------------------------
+func IsLiteralNode(language string, node *sitter.Node) bool {
+	return hasNodeType(language, node, literalCategory)
+}
+
+// -----------------------------------------------------------------------------
+// IsFunctionScopeNode - Checks if a node opens a function scope in the language of its syntax tree.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): The node to check.
+//
+// Returns:
+//   - (bool): True if the variables declared under the node are not global.
+//
+// -----------------------------------------------------------------------------
+func IsFunctionScopeNode(language string, node *sitter.Node) bool {
+	return hasNodeType(language, node, functionScopeCategory)
+}
+
+// -----------------------------------------------------------------------------
+// IsClassScopeNode - Checks if a node opens a class or namespace scope in the language of its syntax tree.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): The node to check.
+//
+// Returns:
+//   - (bool): True if the fields declared under the node can be global.
+//
+// -----------------------------------------------------------------------------
+func IsClassScopeNode(language string, node *sitter.Node) bool {
+	return hasNodeType(language, node, classScopeCategory)
+}
+
+// -----------------------------------------------------------------------------
+// GetControlKind - Returns the control kind of a node in the language of its syntax tree.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): The node to check.
+//
+// Returns:
+//   - (string): The control kind (ControlCondition, ControlLoop...), or an empty string if the node is not a control statement.
+//
+// -----------------------------------------------------------------------------
+func GetControlKind(language string, node *sitter.Node) string {
+	if node == nil || !isRegisteredType(node.Type()) {
+		return ""
+	}
+
+	registry.RLock()
+	defer registry.RUnlock()
+
+	return registry.controlKinds[language][node.Type()]
+}
+
+// -----------------------------------------------------------------------------
+// IsSyntaxNode - Checks if a node is one of the constructs the values are followed through in the language of its syntax tree.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): The node to check.
+//   - construct (string): The construct (SyntaxMemberAccess, SyntaxSubscript...).
+//
+// Returns:
+//   - (bool): True if the language of the node gives its kind for the construct.
+//
+// -----------------------------------------------------------------------------
+func IsSyntaxNode(language string, node *sitter.Node, construct string) bool {
+	return hasNodeType(language, node, construct)
+}
+
+// -----------------------------------------------------------------------------
+// ExtractAssignment - Extracts an assignment with the extractor of the language of its syntax tree.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): The node to extract.
+//   - content ([]byte): The content of the source code.
+//
+// Returns:
+//   - ([]string): The identifiers of the left-hand side.
+//   - ([]string): The identifiers of the right-hand side.
+//   - (bool): True if the language extracted the node, false to use the generic extraction.
+//
+// -----------------------------------------------------------------------------
+func ExtractAssignment(language string, node *sitter.Node, content []byte) ([]string, []string, bool) {
+	if extractor, ok := GetSpec(language).(AssignmentExtractor); ok {
+		return extractor.ExtractAssignment(node, content)
+	}
+	return nil, nil, false
+}
+
+// -----------------------------------------------------------------------------
+// FindVariableNodes - Finds the nodes holding the variables read under a node with the extractor of the language of its syntax tree.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): The node to extract the variables of.
+//
+// Returns:
+//   - ([]*sitter.Node): The nodes holding the variables (the value of a Kotlin named argument, none for a member name).
+//   - (bool): True if the language extracted the node, false to use the generic extraction.
+//
+// -----------------------------------------------------------------------------
+func FindVariableNodes(language string, node *sitter.Node) ([]*sitter.Node, bool) {
+	if extractor, ok := GetSpec(language).(IdentifierExtractor); ok {
+		return extractor.VariableNodes(node)
+	}
+	return nil, false
+}
+
+// -----------------------------------------------------------------------------
+// GetFieldNames - Returns the fields of a role in the language of a syntax tree.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - role (string): The role of the fields (FieldObject, FieldMember...).
+//
+// Returns:
+//   - ([]string): The field names, in the order they are looked up, or nil if the language gives no field this role.
+//
+// -----------------------------------------------------------------------------
+func GetFieldNames(language, role string) []string {
+	if roles, ok := GetSpec(language).(FieldRoles); ok {
+		return roles.FieldNames()[role]
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// FindParametersNode - Finds the parameters of a function with the extractor of the language of its syntax tree.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - functionNode (*sitter.Node): The function declaration node.
+//
+// Returns:
+//   - (*sitter.Node): The node holding the parameters, or nil to use the generic extraction.
+//
+// -----------------------------------------------------------------------------
+func FindParametersNode(language string, functionNode *sitter.Node) *sitter.Node {
+	if extractor, ok := GetSpec(language).(ParameterExtractor); ok {
+		return extractor.ParametersNode(functionNode)
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// FindArgumentsNode - Finds the arguments of a call with the extractor of the language of its syntax tree.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - callNode (*sitter.Node): The call node.
+//
+// Returns:
+//   - (*sitter.Node): The node holding the arguments, or nil to use the generic extraction.
+//
+// -----------------------------------------------------------------------------
+func FindArgumentsNode(language string, callNode *sitter.Node) *sitter.Node {
+	if extractor, ok := GetSpec(language).(ArgumentExtractor); ok {
+		return extractor.ArgumentsNode(callNode)
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// GetPositionalParameterIndex - Returns the index of the argument read by a positional parameter of a function.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - functionNode (*sitter.Node): The function declaration node.
+//   - name (string): The name of the parameter (e.g. "1" for $1 in a shell script).
+//
+// Returns:
+//   - (int): The index of the argument, -1 if the parameter reads all the arguments (e.g. $@).
+//   - (bool): True if the language of the function has positional parameters and the name is one of them.
+//
+// -----------------------------------------------------------------------------
+func GetPositionalParameterIndex(language string, functionNode *sitter.Node, name string) (int, bool) {
+	if positional, ok := GetSpec(language).(PositionalParameters); ok {
+		return positional.PositionalParameterIndex(name)
+	}
+	return 0, false
+}
+
+// -----------------------------------------------------------------------------
+// HasPositionalParameters - Checks if the functions of the language of a node read their arguments by position.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): A node of the syntax tree.
+//
+// Returns:
+//   - (bool): True if the language has positional parameters (e.g. $1 in a shell script).
+//
+// -----------------------------------------------------------------------------
+func HasPositionalParameters(language string, node *sitter.Node) bool {
+	_, ok := GetSpec(language).(PositionalParameters)
+	return ok
+}
+
+// -----------------------------------------------------------------------------
+// GetPositionalParameterName - Returns the positional parameter of a function reading the argument at an index.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - functionNode (*sitter.Node): The function declaration node.
+//   - index (int): The index of the argument.
+//
+// Returns:
+//   - (string): The name of the parameter, or an empty string if the language of the function has no positional parameters.
+//
+// -----------------------------------------------------------------------------
+func GetPositionalParameterName(language string, functionNode *sitter.Node, index int) string {
+	if positional, ok := GetSpec(language).(PositionalParameters); ok {
+		return positional.PositionalParameterName(index)
+	}
+	return ""
+}
+
+// -----------------------------------------------------------------------------
+// getSpecs - Returns the registered languages.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - None
+//
+// Returns:
+//   - ([]LanguageSpec): The specifications of the languages, in registration order.
+//
+// -----------------------------------------------------------------------------
+func getSpecs() []LanguageSpec {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	return append([]LanguageSpec(nil), registry.specs...)
+}
+
+// -----------------------------------------------------------------------------
+// addNodeTypes - Indexes node kinds of a language in a category.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - index (map[string]map[string]bool): The node kinds of the language by category.
+//   - category (string): The category of the node kinds.
+//   - nodeTypes ([]string): The node kinds to index.
+//
+// Returns:
+//   - None
+//
+// -----------------------------------------------------------------------------
+func addNodeTypes(index map[string]map[string]bool, category string, nodeTypes []string) {
+	if index[category] == nil {
+		index[category] = make(map[string]bool)
+	}
+	for _, nodeType := range nodeTypes {
+		index[category][nodeType] = true
+	}
+}
+
+// -----------------------------------------------------------------------------
+// hasNodeType - Checks if the kind of a node is indexed in a category by the language of its syntax tree.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): The node to check.
+//   - category (string): The category of the node kinds.
+//
+// Returns:
+//   - (bool): True if the language of the node declares its kind in the category.
+//
+// -----------------------------------------------------------------------------
+func hasNodeType(language string, node *sitter.Node, category string) bool {
+	if node == nil || !isRegisteredType(node.Type()) {
+		return false
+	}
+
+	registry.RLock()
+	defer registry.RUnlock()
+
+	return registry.nodeTypes[language][category][node.Type()]
+}
+
+// -----------------------------------------------------------------------------
+// isRegisteredType - Checks if a registered language gives a role to a node kind.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - nodeType (string): The node kind.
+//
+// Returns:
+//   - (bool): True if a language declares the kind in a category or a control kind.
+//
+// -----------------------------------------------------------------------------
+func isRegisteredType(nodeType string) bool {
+	registry.RLock()
+	defer registry.RUnlock()
+
+	return registry.allTypes[nodeType]
+}
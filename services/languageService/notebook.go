@@ -0,0 +1,157 @@
+// Conversion of the Jupyter notebooks into virtual modules: the code cells put end to end, with the cell and the line of each line of the module.
+
+package languageService
+
+import (
+	"dataflow/models"
+
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// Language of the notebooks whose metadata does not name one
+const defaultNotebookLanguage = "python"
+
+// Source of a cell, stored as a string or as a list of lines
+type notebookSource []string
+
+func (s *notebookSource) UnmarshalJSON(data []byte) error {
+	var text string
+	if err := json.Unmarshal(data, &text); err == nil {
+		*s = notebookSource{text}
+		return nil
+	}
+	var lines []string
+	if err := json.Unmarshal(data, &lines); err != nil {
+		return err
+	}
+	*s = lines
+	return nil
+}
+
+// Content of a notebook file (nbformat 4)
+type notebookFile struct {
+	Cells []struct {
+		CellType string         `json:"cell_type"`
+		Source   notebookSource `json:"source"`
+	} `json:"cells"`
+	Metadata struct {
+		Kernelspec struct {
+			Language string `json:"language"`
+		} `json:"kernelspec"`
+		LanguageInfo struct {
+			Name string `json:"name"`
+		} `json:"language_info"`
+	} `json:"metadata"`
+}
+
+// -----------------------------------------------------------------------------
+// IsNotebookFile - Checks if a file is a Jupyter notebook.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - filePath (string): The path of the file.
+//
+// Returns:
+//   - (bool): True if the file has the extension of the notebooks (.ipynb).
+//
+// -----------------------------------------------------------------------------
+func IsNotebookFile(filePath string) bool {
+	return strings.ToLower(filepath.Ext(filePath)) == ".ipynb"
+}
+
+// -----------------------------------------------------------------------------
+// LoadNotebook - Converts the JSON of a notebook into a virtual module made of its code cells.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - content ([]byte): The content of the .ipynb file.
+//
+// Returns:
+//   - (*models.Notebook): The virtual module, with the cell and the line of each of its lines.
+//   - (error): An error if the content is not a notebook.
+//
+// -----------------------------------------------------------------------------
+func LoadNotebook(content []byte) (*models.Notebook, error) {
+	var file notebookFile
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("invalid notebook: %v", err)
+	}
+
+	notebook := &models.Notebook{Language: file.Metadata.Kernelspec.Language}
+	if notebook.Language == "" {
+		notebook.Language = file.Metadata.LanguageInfo.Name
+	}
+	if notebook.Language == "" {
+		notebook.Language = defaultNotebookLanguage
+	}
+
+	var module strings.Builder
+	for index, cell := range file.Cells {
+		if cell.CellType != "code" {
+			continue
+		}
+
+		lines := strings.Split(strings.Join(cell.Source, ""), "\n")
+		if len(lines) > 1 && lines[len(lines)-1] == "" {
+			lines = lines[:len(lines)-1]
+		}
+
+		// A cell magic (%%bash, %%html...) makes the whole cell another language
+		cellMagic := strings.HasPrefix(strings.TrimSpace(lines[0]), "%%")
+		for i, line := range lines {
+			notebook.Lines = append(notebook.Lines, models.NotebookLine{Cell: index, Line: i + 1, Content: line})
+			if !cellMagic && !isNotebookCommand(line) {
+				module.WriteString(line)
+			}
+			module.WriteString("\n")
+		}
+	}
+
+	notebook.Content = []byte(module.String())
+	return notebook, nil
+}
+
+// -----------------------------------------------------------------------------
+// GetNotebookModuleLine - Returns the line of the virtual module of a notebook holding a line of a cell.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - notebook (*models.Notebook): The notebook.
+//   - cell (int): The index of the cell in the notebook (0-based).
+//   - line (int): The line in the cell (1-based).
+//
+// Returns:
+//   - (int): The line of the virtual module (1-based).
+//   - (error): An error if the cell is not a code cell or has no such line.
+//
+// -----------------------------------------------------------------------------
+func GetNotebookModuleLine(notebook *models.Notebook, cell, line int) (int, error) {
+	for i, notebookLine := range notebook.Lines {
+		if notebookLine.Cell == cell && notebookLine.Line == line {
+			return i + 1, nil
+		}
+	}
+	return 0, fmt.Errorf("line %d of cell %d is not in a code cell of the notebook", line, cell)
+}
+
+// -----------------------------------------------------------------------------
+// isNotebookCommand - Checks if a line of a cell is an IPython line magic or shell command rather than code.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - line (string): The line of the cell.
+//
+// Returns:
+//   - (bool): True if the line starts with "%", "!" or "?", or ends with "?" (help).
+//
+// -----------------------------------------------------------------------------
+func isNotebookCommand(line string) bool {
+	line = strings.TrimSpace(line)
+	if line == "" || strings.HasPrefix(line, "#") {
+		return false
+	}
+	return strings.HasPrefix(line, "%") || strings.HasPrefix(line, "!") || strings.HasPrefix(line, "?") || strings.HasSuffix(line, "?")
+}
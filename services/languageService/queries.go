@@ -0,0 +1,438 @@
+// Tree-sitter queries describing the assignments, calls, parameters, declarations and scopes of each language. The queries are shipped with the languages and can be overridden from a directory.
+
+package languageService
+
+import (
+	"embed"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"dataflow/logger"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// Kinds of queries, each one is a file named <kind>.scm in the directory of the language
+const (
+	QueryAssignments  = "assignments"  // Captures @assignment, @lhs and @rhs
+	QueryCalls        = "calls"        // Captures @call, @callee and @arg
+	QueryParameters   = "parameters"   // Captures @function and @parameter
+	QueryDeclarations = "declarations" // Captures @function and @name
+	QueryScopes       = "scopes"       // Captures @scope, @scope.function, @scope.class, @definition, @definition.new, @definition.hoisted, @definition.global and @global
+)
+
+// Capture naming the node a match describes, by kind of query
+var queryRootCaptures = map[string]string{
+	QueryAssignments:  "assignment",
+	QueryCalls:        "call",
+	QueryParameters:   "function",
+	QueryDeclarations: "function",
+	QueryScopes:       "scope",
+}
+
+// QueryCapture is a node captured by a query run on a whole syntax tree, with the name of its capture
+type QueryCapture struct {
+	Name string
+	Node *sitter.Node
+}
+
+// QueryProvider is implemented by the languages shipping their own queries
+type QueryProvider interface {
+	// Query returns the source of a kind of query, or nil if the language has no query of this kind
+	Query(kind string) []byte
+}
+
+// Query returns the query of the kind declared in the Queries field of the specification
+func (s Spec) Query(kind string) []byte {
+	if source, exists := s.Queries[kind]; exists {
+		return []byte(source)
+	}
+	return nil
+}
+
+// Queries of the built-in languages, in queries/<language>/<kind>.scm
+//
+//go:embed queries
+var builtinQueries embed.FS
+
+// Node kind standing for any node in the root kinds of a query
+const anyNodeType = "_"
+
+// Directory overriding the queries, the queries compiled for each language and kind (nil if the language has no query of the kind),
+// and the node kinds starting the queries of all languages by kind, to skip the nodes no query can match
+var queries = struct {
+	sync.Mutex
+	directory string
+	compiled  map[string]*sitter.Query
+	rootTypes map[string]map[string]bool
+}{compiled: make(map[string]*sitter.Query), rootTypes: make(map[string]map[string]bool)}
+
+// -----------------------------------------------------------------------------
+// SetQueryDirectory - Overrides the queries of the languages with the files of a directory.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - directory (string): The directory containing <language>/<kind>.scm files, or an empty string to use the shipped queries.
+//
+// Returns:
+//   - (error): An error if the directory cannot be read or one of its queries does not compile.
+//
+// -----------------------------------------------------------------------------
+func SetQueryDirectory(directory string) error {
+	if directory != "" {
+		info, err := os.Stat(directory)
+		if err != nil {
+			return fmt.Errorf("cannot read the query directory: %v", err)
+		}
+		if !info.IsDir() {
+			return fmt.Errorf("the query path %s is not a directory", directory)
+		}
+
+		// Every override is compiled once to report the errors before the analysis
+		for _, spec := range getSpecs() {
+			for kind := range queryRootCaptures {
+				path := filepath.Join(directory, spec.Name(), kind+".scm")
+				source, err := os.ReadFile(path)
+				if os.IsNotExist(err) {
+					continue
+				}
+				if err != nil {
+					return fmt.Errorf("cannot read the query %s: %v", path, err)
+				}
+				query, err := sitter.NewQuery(source, spec.Grammar())
+				if err != nil {
+					return fmt.Errorf("invalid query %s: %v", path, err)
+				}
+				query.Close()
+			}
+		}
+	}
+
+	queries.Lock()
+	defer queries.Unlock()
+
+	queries.directory = directory
+	queries.compiled = make(map[string]*sitter.Query)
+	queries.rootTypes = make(map[string]map[string]bool)
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// MatchQuery - Runs a kind of query on a node of a syntax tree.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - kind (string): The kind of query (QueryAssignments, QueryCalls...).
+//   - node (*sitter.Node): The node described by the match (the assignment, the call or the function).
+//   - content ([]byte): The content of the source code.
+//
+// Returns:
+//   - (map[string][]*sitter.Node): The nodes captured for the node, by capture name, in source order.
+//   - (bool): True if a pattern of the query matched the node, false to use the generic extraction.
+//
+// -----------------------------------------------------------------------------
+func MatchQuery(language, kind string, node *sitter.Node, content []byte) (map[string][]*sitter.Node, bool) {
+	if node == nil || !mayStartQuery(kind, node.Type()) {
+		return nil, false
+	}
+	query := getQuery(language, kind)
+	if query == nil {
+		return nil, false
+	}
+
+	cursor := sitter.NewQueryCursor()
+	defer cursor.Close()
+	cursor.Exec(query, node)
+
+	rootCapture := queryRootCaptures[kind]
+	captures := make(map[string][]*sitter.Node)
+	seen := make(map[string]bool)
+	matched := false
+	for {
+		match, ok := cursor.NextMatch()
+		if !ok {
+			break
+		}
+		match = cursor.FilterPredicates(match, content)
+		if !isMatchOf(query, match, rootCapture, node) {
+			continue
+		}
+
+		matched = true
+		for _, capture := range match.Captures {
+			name := query.CaptureNameForId(capture.Index)
+			key := fmt.Sprintf("%s:%d:%d:%s", name, capture.Node.StartByte(), capture.Node.EndByte(), capture.Node.Type())
+			if name == rootCapture || seen[key] {
+				continue
+			}
+			seen[key] = true
+			captures[name] = append(captures[name], capture.Node)
+		}
+	}
+
+	// A pattern matching each child separately yields one match per child: the captures are put back in source order
+	for _, nodes := range captures {
+		sort.SliceStable(nodes, func(i, j int) bool { return nodes[i].StartByte() < nodes[j].StartByte() })
+	}
+	return captures, matched
+}
+
+// -----------------------------------------------------------------------------
+// CaptureQuery - Runs a kind of query on a whole syntax tree.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - kind (string): The kind of query (QueryScopes).
+//   - root (*sitter.Node): The root node of the syntax tree.
+//   - content ([]byte): The content of the source code.
+//
+// Returns:
+//   - ([]QueryCapture): The captures of all the matches, in source order.
+//   - (bool): True if the language has a valid query of the kind.
+//
+// -----------------------------------------------------------------------------
+func CaptureQuery(language, kind string, root *sitter.Node, content []byte) ([]QueryCapture, bool) {
+	query := getQuery(language, kind)
+	if query == nil || root == nil {
+		return nil, false
+	}
+
+	cursor := sitter.NewQueryCursor()
+	defer cursor.Close()
+	cursor.Exec(query, root)
+
+	var captures []QueryCapture
+	for {
+		match, ok := cursor.NextMatch()
+		if !ok {
+			break
+		}
+		match = cursor.FilterPredicates(match, content)
+		for _, capture := range match.Captures {
+			captures = append(captures, QueryCapture{Name: query.CaptureNameForId(capture.Index), Node: capture.Node})
+		}
+	}
+
+	sort.SliceStable(captures, func(i, j int) bool { return captures[i].Node.StartByte() < captures[j].Node.StartByte() })
+	return captures, true
+}
+
+// -----------------------------------------------------------------------------
+// getQuery - Returns the compiled query of a language, compiling it on first use.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The name of the language.
+//   - kind (string): The kind of query.
+//
+// Returns:
+//   - (*sitter.Query): The query, or nil if the language has no valid query of the kind.
+//
+// -----------------------------------------------------------------------------
+func getQuery(language, kind string) *sitter.Query {
+	spec := GetSpec(language)
+	if spec == nil {
+		return nil
+	}
+
+	queries.Lock()
+	defer queries.Unlock()
+
+	key := language + "/" + kind
+	if query, exists := queries.compiled[key]; exists {
+		return query
+	}
+
+	var query *sitter.Query
+	if source, origin := findQuerySource(spec, kind, queries.directory); source != nil {
+		compiled, err := sitter.NewQuery(source, spec.Grammar())
+		if err != nil {
+			logger.PrintError("Invalid query %s: %v", origin, err)
+		} else {
+			query = compiled
+		}
+	}
+	queries.compiled[key] = query
+	return query
+}
+
+// -----------------------------------------------------------------------------
+// mayStartQuery - Checks if a node kind starts a pattern of a kind of query in one of the languages.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - kind (string): The kind of query.
+//   - nodeType (string): The type of the node.
+//
+// Returns:
+//   - (bool): True if a query of the kind can match a node of this type.
+//
+// -----------------------------------------------------------------------------
+func mayStartQuery(kind, nodeType string) bool {
+	queries.Lock()
+	rootTypes, exists := queries.rootTypes[kind]
+	directory := queries.directory
+	queries.Unlock()
+
+	if !exists {
+		// The sources are enough: the queries are only compiled for the languages analyzed
+		rootTypes = make(map[string]bool)
+		for _, spec := range getSpecs() {
+			source, _ := findQuerySource(spec, kind, directory)
+			for rootType := range patternRootTypes(source) {
+				rootTypes[rootType] = true
+			}
+		}
+
+		queries.Lock()
+		queries.rootTypes[kind] = rootTypes
+		queries.Unlock()
+	}
+	return rootTypes[nodeType] || rootTypes[anyNodeType]
+}
+
+// -----------------------------------------------------------------------------
+// patternRootTypes - Lists the node kinds the top-level patterns of a query start with.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - source ([]byte): The source of the query.
+//
+// Returns:
+//   - (map[string]bool): The node kinds, with anyNodeType if a pattern can start with any node.
+//
+// -----------------------------------------------------------------------------
+func patternRootTypes(source []byte) map[string]bool {
+	rootTypes := make(map[string]bool)
+	depth := 0
+	inAlternation := false
+	for i := 0; i < len(source); i++ {
+		switch source[i] {
+		case ';':
+			// Comment until the end of the line
+			for i < len(source) && source[i] != '\n' {
+				i++
+			}
+		case '"':
+			// Anonymous node or predicate argument, skipped up to the closing quote
+			if depth == 0 || (inAlternation && depth == 1) {
+				rootTypes[anyNodeType] = true
+			}
+			for i++; i < len(source) && source[i] != '"'; i++ {
+				if source[i] == '\\' {
+					i++
+				}
+			}
+		case '[':
+			if depth == 0 {
+				inAlternation = true
+			}
+			depth++
+		case '(':
+			if depth == 0 || (inAlternation && depth == 1) {
+				// The node kind follows the parenthesis, a nested pattern or a wildcard can start with any node
+				j := i + 1
+				for j < len(source) && (source[j] == ' ' || source[j] == '\t' || source[j] == '\n' || source[j] == '\r') {
+					j++
+				}
+				start := j
+				for j < len(source) && (source[j] == '_' || source[j] == '.' || source[j] >= 'a' && source[j] <= 'z' || source[j] >= 'A' && source[j] <= 'Z' || source[j] >= '0' && source[j] <= '9') {
+					j++
+				}
+				name := string(source[start:j])
+				if name == "" || name == anyNodeType {
+					rootTypes[anyNodeType] = true
+				} else {
+					rootTypes[name] = true
+				}
+			}
+			depth++
+		case ')', ']':
+			depth--
+			if depth == 0 {
+				inAlternation = false
+			}
+		}
+	}
+	return rootTypes
+}
+
+// -----------------------------------------------------------------------------
+// findQuerySource - Finds the source of a query: the override directory, then the language, then the shipped queries.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - spec (LanguageSpec): The specification of the language.
+//   - kind (string): The kind of query.
+//   - directory (string): The directory overriding the queries, or an empty string.
+//
+// Returns:
+//   - ([]byte): The source of the query, or nil if there is none.
+//   - (string): The origin of the source, for the error messages.
+//
+// -----------------------------------------------------------------------------
+func findQuerySource(spec LanguageSpec, kind, directory string) ([]byte, string) {
+	if directory != "" {
+		path := filepath.Join(directory, spec.Name(), kind+".scm")
+		if source, err := os.ReadFile(path); err == nil {
+			return source, path
+		}
+	}
+	if provider, ok := spec.(QueryProvider); ok {
+		if source := provider.Query(kind); source != nil {
+			return source, spec.Name() + "/" + kind
+		}
+	}
+	path := "queries/" + spec.Name() + "/" + kind + ".scm"
+	if source, err := builtinQueries.ReadFile(path); err == nil {
+		return source, path
+	}
+	return nil, ""
+}
+
+// -----------------------------------------------------------------------------
+// resetQueries - Forgets the compiled queries, after a language is registered again.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - None
+//
+// Returns:
+//   - None
+//
+// -----------------------------------------------------------------------------
+func resetQueries() {
+	queries.Lock()
+	defer queries.Unlock()
+
+	queries.compiled = make(map[string]*sitter.Query)
+	queries.rootTypes = make(map[string]map[string]bool)
+}
+
+// -----------------------------------------------------------------------------
+// isMatchOf - Checks if a match describes a node.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - query (*sitter.Query): The query of the match.
+//   - match (*sitter.QueryMatch): The match, after its predicates are checked.
+//   - rootCapture (string): The capture naming the node described by the match.
+//   - node (*sitter.Node): The node.
+//
+// Returns:
+//   - (bool): True if the root capture of the match is the node.
+//
+// -----------------------------------------------------------------------------
+func isMatchOf(query *sitter.Query, match *sitter.QueryMatch, rootCapture string, node *sitter.Node) bool {
+	for _, capture := range match.Captures {
+		if query.CaptureNameForId(capture.Index) == rootCapture && capture.Node.Equal(node) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,17 @@
+; Assignments: @assignment is the statement, @lhs the assigned variable, @rhs the value
+; The read builtins (read -r name) are extracted by the language, their variables are named by words
+
+(variable_assignment
+  name: (variable_name) @lhs
+  value: (_)? @rhs) @assignment
+
+(variable_assignment
+  name: (subscript
+    name: (variable_name) @lhs)
+  value: (_)? @rhs) @assignment
+
+; Declarations (local, export, declare, readonly) assigning one or several variables
+(declaration_command
+  (variable_assignment
+    name: (variable_name) @lhs
+    value: (_)? @rhs)) @assignment
@@ -0,0 +1,31 @@
+; Commands: @call is the command, @callee the command or function name, @arg each argument
+; An argument expanding a single variable ("$1", "${name}", $name) is captured as the variable, to be matched by its name
+
+(command
+  name: (command_name) @callee) @call
+
+(command
+  argument: [(word) (number) (raw_string) (ansi_c_string) (concatenation) (command_substitution) (arithmetic_expansion) (process_substitution)] @arg) @call
+
+(command
+  argument: (simple_expansion
+    (_) @arg)) @call
+
+(command
+  argument: ((expansion) @_expansion
+    (#not-match? @_expansion "^\\$\\{[A-Za-z0-9_@*]+\\}$")) @arg) @call
+
+(command
+  argument: ((expansion
+    (_) @arg) @_expansion
+    (#match? @_expansion "^\\$\\{[A-Za-z0-9_@*]+\\}$"))) @call
+
+(command
+  argument: ((string) @arg
+    (#not-match? @arg "^\"\\$(\\{[A-Za-z0-9_@*]+\\}|[A-Za-z0-9_@*]+)\"$"))) @call
+
+(command
+  argument: ((string
+    [(simple_expansion (_) @arg)
+     (expansion (_) @arg)]) @_string
+    (#match? @_string "^\"\\$(\\{[A-Za-z0-9_@*]+\\}|[A-Za-z0-9_@*]+)\"$"))) @call
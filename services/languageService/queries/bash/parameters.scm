@@ -0,0 +1,2 @@
+; Parameters of the functions: @function is the definition, @parameter each parameter
+; Shell functions declare no parameters, they read their arguments as positional parameters ($1, $@)
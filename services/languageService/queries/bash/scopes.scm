@@ -0,0 +1,10 @@
+; Scopes of the variables: @scope.function is a function, @definition.hoisted a local variable of the whole function,
+; @definition.global a variable of the script, which every assignment outside a local declaration writes
+
+(function_definition) @scope.function
+
+(declaration_command ["local" "declare" "typeset"] (variable_assignment name: (variable_name) @definition.hoisted))
+(declaration_command ["local" "declare" "typeset"] (variable_name) @definition.hoisted)
+
+(variable_assignment name: (variable_name) @definition.global)
+(for_statement variable: (variable_name) @definition.global)
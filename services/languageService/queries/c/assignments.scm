@@ -0,0 +1,21 @@
+; Assignments and declarations: @assignment is the statement, @lhs the declared or assigned variable, @rhs the value
+
+(assignment_expression
+  left: (_) @lhs
+  right: (_) @rhs) @assignment
+
+(declaration
+  declarator: (init_declarator
+    declarator: [
+      (identifier) @lhs
+      (pointer_declarator declarator: (identifier) @lhs)
+      (array_declarator declarator: (identifier) @lhs)
+    ]
+    value: (_) @rhs)) @assignment
+
+(declaration
+  declarator: [
+    (identifier) @lhs
+    (pointer_declarator declarator: (identifier) @lhs)
+    (array_declarator declarator: (identifier) @lhs)
+  ]) @assignment
@@ -0,0 +1,10 @@
+; Function definitions, the name is in the function declarator: @function is the definition, @name the name of the function
+
+(function_definition
+  declarator: (function_declarator
+    declarator: (identifier) @name)) @function
+
+(function_definition
+  declarator: (pointer_declarator
+    declarator: (function_declarator
+      declarator: (identifier) @name))) @function
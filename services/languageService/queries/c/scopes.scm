@@ -0,0 +1,14 @@
+; Scopes of the variables: @scope.function is a function, @scope a block, @definition a variable declared from there to the end of the innermost scope
+
+(function_definition) @scope.function
+
+[
+  (compound_statement)
+  (for_statement)
+] @scope
+
+(parameter_declaration declarator: (identifier) @definition)
+(declaration declarator: (identifier) @definition)
+(init_declarator declarator: (identifier) @definition)
+(pointer_declarator declarator: (identifier) @definition)
+(array_declarator declarator: (identifier) @definition)
@@ -0,0 +1,10 @@
+; Parameters of the functions, the function declarator can be nested in the declarator of the return type: @function is the definition, @parameter each parameter declaration
+
+(function_definition
+  declarator: (function_declarator
+    parameters: (parameter_list (_) @parameter))) @function
+
+(function_definition
+  declarator: (pointer_declarator
+    declarator: (function_declarator
+      parameters: (parameter_list (_) @parameter)))) @function
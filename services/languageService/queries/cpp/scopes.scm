@@ -0,0 +1,21 @@
+; Scopes of the variables: @scope.function is a function, @scope a block, @definition a variable declared from there to the end of the innermost scope
+
+[
+  (function_definition)
+  (lambda_expression)
+] @scope.function
+
+[
+  (compound_statement)
+  (for_statement)
+  (for_range_loop)
+  (catch_clause)
+] @scope
+
+(parameter_declaration declarator: (identifier) @definition)
+(declaration declarator: (identifier) @definition)
+(init_declarator declarator: (identifier) @definition)
+(pointer_declarator declarator: (identifier) @definition)
+(array_declarator declarator: (identifier) @definition)
+(reference_declarator (identifier) @definition)
+(for_range_loop declarator: (identifier) @definition)
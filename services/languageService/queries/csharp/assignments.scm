@@ -0,0 +1,29 @@
+; Assignments and local declarations, the value of a declarator follows its name: @assignment is the statement, @lhs the assigned variable, @rhs the value
+
+(assignment_expression
+  left: (_) @lhs
+  right: (_) @rhs) @assignment
+
+(local_declaration_statement
+  (variable_declaration
+    (variable_declarator
+      name: (_) @lhs
+      (_)? @rhs))) @assignment
+
+(variable_declaration
+  (variable_declarator
+    name: (_) @lhs
+    (_)? @rhs)) @assignment
+
+(variable_declaration
+  (variable_declarator
+    (tuple_pattern) @lhs
+    .
+    (_) @rhs)) @assignment
+
+(local_declaration_statement
+  (variable_declaration
+    (variable_declarator
+      (tuple_pattern) @lhs
+      .
+      (_) @rhs))) @assignment
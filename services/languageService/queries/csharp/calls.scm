@@ -0,0 +1,7 @@
+; Method invocations: @call is the invocation, @callee the invoked method, @arg each argument
+
+(invocation_expression
+  function: (_) @callee) @call
+
+(invocation_expression
+  arguments: (argument_list (_) @arg)) @call
@@ -0,0 +1,4 @@
+; Method declarations: @function is the declaration, @name the name of the method
+
+(method_declaration
+  name: (_) @name) @function
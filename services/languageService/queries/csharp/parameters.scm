@@ -0,0 +1,4 @@
+; Parameters of the methods: @function is the declaration, @parameter each parameter
+
+(method_declaration
+  parameters: (parameter_list (_) @parameter)) @function
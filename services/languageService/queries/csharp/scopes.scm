@@ -0,0 +1,31 @@
+; Scopes of the variables: @scope.function is a method, @scope a block, @scope.class a class body,
+; @definition a variable declared from there to the end of the innermost scope
+
+[
+  (method_declaration)
+  (constructor_declaration)
+  (local_function_statement)
+  (lambda_expression)
+  (anonymous_method_expression)
+] @scope.function
+
+[
+  (block)
+  (for_statement)
+  (foreach_statement)
+  (using_statement)
+  (catch_clause)
+  (switch_section)
+] @scope
+
+(declaration_list) @scope.class
+
+(parameter name: (identifier) @definition)
+(parameter_list name: (identifier) @definition)
+(implicit_parameter) @definition
+(catch_declaration name: (identifier) @definition)
+
+(variable_declarator name: (identifier) @definition)
+(foreach_statement left: (identifier) @definition)
+(declaration_pattern name: (identifier) @definition)
+(declaration_expression name: (identifier) @definition)
@@ -0,0 +1,19 @@
+; Assignments and declarations with initialization: @assignment is the statement, @lhs the assigned side, @rhs the value
+
+(short_var_declaration
+  left: (_) @lhs
+  right: (_) @rhs) @assignment
+
+(assignment_statement
+  left: (_) @lhs
+  right: (_) @rhs) @assignment
+
+(var_declaration
+  (var_spec
+    name: (_) @lhs
+    value: (_)? @rhs)) @assignment
+
+(const_declaration
+  (const_spec
+    name: (_) @lhs
+    value: (_)? @rhs)) @assignment
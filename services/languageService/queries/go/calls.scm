@@ -0,0 +1,7 @@
+; Function and method calls: @call is the call, @callee the called function, @arg each argument
+
+(call_expression
+  function: (_) @callee) @call
+
+(call_expression
+  arguments: (argument_list (_) @arg)) @call
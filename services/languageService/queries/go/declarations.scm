@@ -0,0 +1,7 @@
+; Function declarations: @function is the declaration, @name the name of the function
+
+(function_declaration
+  name: (_) @name) @function
+
+(method_declaration
+  name: (_) @name) @function
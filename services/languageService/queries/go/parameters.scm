@@ -0,0 +1,7 @@
+; Parameters of the functions: @function is the declaration, @parameter each parameter declaration
+
+(function_declaration
+  parameters: (parameter_list (_) @parameter)) @function
+
+(method_declaration
+  parameters: (parameter_list (_) @parameter)) @function
@@ -0,0 +1,45 @@
+; Scopes of the variables: @scope.function is a function, @scope a block, @definition a variable declared from there to the end of the innermost scope
+; The body of a function shares the scope of its parameters, its blocks are only scopes below a statement
+
+[
+  (function_declaration)
+  (method_declaration)
+  (func_literal)
+] @scope.function
+
+[
+  (if_statement)
+  (for_statement)
+  (expression_switch_statement)
+  (type_switch_statement)
+  (select_statement)
+  (expression_case)
+  (default_case)
+  (type_case)
+  (communication_case)
+] @scope
+
+(if_statement consequence: (block) @scope)
+(if_statement alternative: (block) @scope)
+(for_statement body: (block) @scope)
+(block (block) @scope)
+
+(parameter_declaration name: (identifier) @definition)
+(variadic_parameter_declaration name: (identifier) @definition)
+
+(short_var_declaration
+  left: (expression_list (identifier) @definition))
+
+(var_spec name: (identifier) @definition)
+(const_spec name: (identifier) @definition)
+
+(range_clause
+  left: (expression_list (identifier) @definition)
+  ":=")
+
+(receive_statement
+  left: (expression_list (identifier) @definition)
+  ":=")
+
+(type_switch_statement
+  alias: (expression_list (identifier) @definition))
@@ -0,0 +1,10 @@
+; Assignments and local declarations: @assignment is the statement, @lhs the assigned variable, @rhs the value
+
+(assignment_expression
+  left: (_) @lhs
+  right: (_) @rhs) @assignment
+
+(local_variable_declaration
+  declarator: (variable_declarator
+    name: (_) @lhs
+    value: (_)? @rhs)) @assignment
@@ -0,0 +1,7 @@
+; Method invocations: @call is the invocation, @callee the name of the method, @arg each argument
+
+(method_invocation
+  name: (_) @callee) @call
+
+(method_invocation
+  arguments: (argument_list (_) @arg)) @call
@@ -0,0 +1,4 @@
+; Parameters of the methods: @function is the declaration, @parameter each formal parameter
+
+(method_declaration
+  parameters: (formal_parameters (_) @parameter)) @function
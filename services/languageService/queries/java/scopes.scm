@@ -0,0 +1,35 @@
+; Scopes of the variables: @scope.function is a method, @scope a block, @scope.class a class body,
+; @definition a variable declared from there to the end of the innermost scope
+
+[
+  (method_declaration)
+  (constructor_declaration)
+  (lambda_expression)
+] @scope.function
+
+[
+  (block)
+  (for_statement)
+  (enhanced_for_statement)
+  (try_with_resources_statement)
+  (catch_clause)
+  (switch_block_statement_group)
+] @scope
+
+[
+  (class_body)
+  (interface_body)
+  (enum_body)
+] @scope.class
+
+(formal_parameter name: (identifier) @definition)
+(spread_parameter (variable_declarator name: (identifier) @definition))
+(inferred_parameters (identifier) @definition)
+(lambda_expression parameters: (identifier) @definition)
+(catch_formal_parameter name: (identifier) @definition)
+
+(local_variable_declaration declarator: (variable_declarator name: (identifier) @definition))
+(field_declaration declarator: (variable_declarator name: (identifier) @definition))
+(enhanced_for_statement name: (identifier) @definition)
+(resource name: (identifier) @definition)
+(instanceof_expression name: (identifier) @definition)
@@ -0,0 +1,4 @@
+; Function declarations: @function is the declaration, @name the name of the function
+
+(function_declaration
+  name: (_) @name) @function
@@ -0,0 +1,4 @@
+; Parameters of the functions: @function is the declaration, @parameter each parameter
+
+(function_declaration
+  parameters: (formal_parameters (_) @parameter)) @function
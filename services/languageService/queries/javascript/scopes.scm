@@ -0,0 +1,46 @@
+; Scopes of the variables: @scope.function is a function, @scope a block, @scope.class a class body,
+; @definition a variable declared from there to the end of the innermost scope (let, const, parameter), @definition.hoisted a variable of the whole innermost function (var)
+
+[
+  (function_declaration)
+  (generator_function_declaration)
+  (function_expression)
+  (generator_function)
+  (arrow_function)
+  (method_definition)
+] @scope.function
+
+[
+  (statement_block)
+  (for_statement)
+  (for_in_statement)
+  (catch_clause)
+  (switch_body)
+] @scope
+
+(class_body) @scope.class
+
+(formal_parameters (identifier) @definition)
+(formal_parameters (assignment_pattern left: (identifier) @definition))
+(formal_parameters (rest_pattern (identifier) @definition))
+(formal_parameters (object_pattern (shorthand_property_identifier_pattern) @definition))
+(formal_parameters (object_pattern (pair_pattern value: (identifier) @definition)))
+(formal_parameters (array_pattern (identifier) @definition))
+(arrow_function parameter: (identifier) @definition)
+(catch_clause parameter: (identifier) @definition)
+
+(lexical_declaration (variable_declarator name: (identifier) @definition))
+(lexical_declaration (variable_declarator name: (object_pattern (shorthand_property_identifier_pattern) @definition)))
+(lexical_declaration (variable_declarator name: (object_pattern (pair_pattern value: (identifier) @definition))))
+(lexical_declaration (variable_declarator name: (object_pattern (rest_pattern (identifier) @definition))))
+(lexical_declaration (variable_declarator name: (array_pattern (identifier) @definition)))
+(lexical_declaration (variable_declarator name: (array_pattern (assignment_pattern left: (identifier) @definition))))
+(lexical_declaration (variable_declarator name: (array_pattern (rest_pattern (identifier) @definition))))
+
+(variable_declaration (variable_declarator name: (identifier) @definition.hoisted))
+(variable_declaration (variable_declarator name: (object_pattern (shorthand_property_identifier_pattern) @definition.hoisted)))
+(variable_declaration (variable_declarator name: (object_pattern (pair_pattern value: (identifier) @definition.hoisted))))
+(variable_declaration (variable_declarator name: (array_pattern (identifier) @definition.hoisted)))
+
+(for_in_statement kind: ["let" "const"] left: (identifier) @definition)
+(for_in_statement kind: "var" left: (identifier) @definition.hoisted)
@@ -0,0 +1,25 @@
+; Assignments and variable declarations: @assignment is the statement, @lhs the assigned variable, @rhs the value
+
+(property_declaration
+  (variable_declaration
+    (simple_identifier) @lhs)) @assignment
+
+(property_declaration
+  (variable_declaration)
+  "="
+  (_) @rhs) @assignment
+
+(property_declaration
+  (multi_variable_declaration
+    (variable_declaration
+      (simple_identifier) @lhs))) @assignment
+
+(property_declaration
+  (multi_variable_declaration)
+  "="
+  (_) @rhs) @assignment
+
+; The assigned expression is a variable, a property (box.path) or an indexed element (map["path"])
+(assignment
+  (directly_assignable_expression) @lhs
+  (_) @rhs) @assignment
@@ -0,0 +1,17 @@
+; Function and method calls: @call is the call, @callee the called function, @arg each argument
+
+(call_expression
+  .
+  (simple_identifier) @callee) @call
+
+(call_expression
+  .
+  (navigation_expression
+    (navigation_suffix
+      (simple_identifier) @callee))) @call
+
+(call_expression
+  (call_suffix
+    (value_arguments
+      (value_argument
+        (_) @arg .)))) @call
@@ -0,0 +1,5 @@
+; Parameters of the functions: @function is the declaration, @parameter each parameter
+
+(function_declaration
+  (function_value_parameters
+    (parameter) @parameter)) @function
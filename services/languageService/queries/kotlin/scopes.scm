@@ -0,0 +1,25 @@
+; Scopes of the variables: @scope.function is a function, @scope a block, @scope.class a class,
+; @definition a variable declared from there to the end of the innermost scope
+
+[
+  (function_declaration)
+  (lambda_literal)
+  (anonymous_function)
+] @scope.function
+
+[
+  (control_structure_body)
+  (for_statement)
+  (when_expression)
+  (catch_block)
+] @scope
+
+[
+  (class_declaration)
+  (object_declaration)
+] @scope.class
+
+(parameter (simple_identifier) @definition)
+(class_parameter (simple_identifier) @definition)
+(variable_declaration (simple_identifier) @definition)
+(catch_block (simple_identifier) @definition)
@@ -0,0 +1,9 @@
+; Assignments: @assignment is the expression, @lhs the assigned variable, @rhs the value
+
+(assignment_expression
+  left: (_) @lhs
+  right: (_) @rhs) @assignment
+
+(reference_assignment_expression
+  left: (_) @lhs
+  right: (_) @rhs) @assignment
@@ -0,0 +1,7 @@
+; Function calls: @call is the call, @callee the called function, @arg each argument
+
+(function_call_expression
+  function: (_) @callee) @call
+
+(function_call_expression
+  arguments: (arguments (_) @arg)) @call
@@ -0,0 +1,7 @@
+; Function and method definitions: @function is the definition, @name the name of the function
+
+(function_definition
+  name: (_) @name) @function
+
+(method_declaration
+  name: (_) @name) @function
@@ -0,0 +1,7 @@
+; Parameters of the functions and methods: @function is the definition, @parameter each parameter
+
+(function_definition
+  parameters: (formal_parameters (_) @parameter)) @function
+
+(method_declaration
+  parameters: (formal_parameters (_) @parameter)) @function
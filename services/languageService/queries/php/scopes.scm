@@ -0,0 +1,21 @@
+; Scopes of the variables: @scope.function is a function, @definition.hoisted a variable of the whole innermost function, @global a name of the global scope
+; The blocks are not scopes in PHP
+
+[
+  (function_definition)
+  (method_declaration)
+  (anonymous_function_creation_expression)
+  (arrow_function)
+] @scope.function
+
+(simple_parameter name: (variable_name) @definition.hoisted)
+(variadic_parameter name: (variable_name) @definition.hoisted)
+
+(assignment_expression left: (variable_name) @definition.hoisted)
+(list_literal (variable_name) @definition.hoisted)
+(foreach_statement (_) (variable_name) @definition.hoisted)
+(foreach_statement (pair (variable_name) @definition.hoisted))
+(catch_clause name: (variable_name) @definition.hoisted)
+(static_variable_declaration name: (variable_name) @definition.hoisted)
+
+(global_declaration (variable_name) @global)
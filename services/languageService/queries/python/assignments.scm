@@ -0,0 +1,5 @@
+; Assignments: @assignment is the statement, @lhs the assigned side, @rhs the value
+
+(assignment
+  left: (_) @lhs
+  right: (_)? @rhs) @assignment
@@ -0,0 +1,7 @@
+; Function and method calls: @call is the call, @callee the called function, @arg each argument
+
+(call
+  function: (_) @callee) @call
+
+(call
+  arguments: (argument_list (_) @arg)) @call
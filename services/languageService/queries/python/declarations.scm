@@ -0,0 +1,4 @@
+; Function definitions: @function is the definition, @name the name of the function
+
+(function_definition
+  name: (_) @name) @function
@@ -0,0 +1,4 @@
+; Parameters of the functions: @function is the definition, @parameter each parameter
+
+(function_definition
+  parameters: (parameters (_) @parameter)) @function
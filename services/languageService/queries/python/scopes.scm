@@ -0,0 +1,32 @@
+; Scopes of the variables: @scope.function is a function, @definition.hoisted a variable of the whole innermost function, @global a name of the enclosing scopes
+; The classes are not scopes: their attributes are read through self, and the comprehensions have the scope of their own variables
+
+[
+  (function_definition)
+  (lambda)
+  (list_comprehension)
+  (set_comprehension)
+  (dictionary_comprehension)
+  (generator_expression)
+] @scope.function
+
+(parameters (identifier) @definition.hoisted)
+(lambda_parameters (identifier) @definition.hoisted)
+(default_parameter name: (identifier) @definition.hoisted)
+(typed_parameter (identifier) @definition.hoisted)
+(typed_default_parameter name: (identifier) @definition.hoisted)
+(list_splat_pattern (identifier) @definition.hoisted)
+(dictionary_splat_pattern (identifier) @definition.hoisted)
+
+(assignment left: (identifier) @definition.hoisted)
+(augmented_assignment left: (identifier) @definition.hoisted)
+(pattern_list (identifier) @definition.hoisted)
+(tuple_pattern (identifier) @definition.hoisted)
+(list_pattern (identifier) @definition.hoisted)
+(named_expression name: (identifier) @definition.hoisted)
+(as_pattern_target (identifier) @definition.hoisted)
+(for_statement left: (identifier) @definition.hoisted)
+(for_in_clause left: (identifier) @definition.hoisted)
+
+(global_statement (identifier) @global)
+(nonlocal_statement (identifier) @global)
@@ -0,0 +1,5 @@
+; Assignments: @assignment is the assignment, @lhs the assigned variable, @rhs the value
+
+(assignment
+  left: (_) @lhs
+  right: (_) @rhs) @assignment
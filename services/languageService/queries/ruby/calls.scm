@@ -0,0 +1,7 @@
+; Method calls: @call is the call, @callee the called method, @arg each argument
+
+(call
+  method: (_) @callee) @call
+
+(call
+  arguments: (argument_list (_) @arg)) @call
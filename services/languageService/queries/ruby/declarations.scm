@@ -0,0 +1,4 @@
+; Method definitions: @function is the method, @name the name of the method
+
+(method
+  name: (_) @name) @function
@@ -0,0 +1,4 @@
+; Parameters of the methods: @function is the method, @parameter each parameter
+
+(method
+  parameters: (method_parameters (_) @parameter)) @function
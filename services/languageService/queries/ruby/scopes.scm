@@ -0,0 +1,31 @@
+; Scopes of the variables: @scope.function is a method or a class body, @scope a block, @definition a block parameter,
+; @definition.hoisted a variable of the whole innermost method (the assignments of a block reach the variables of the method)
+
+[
+  (method)
+  (singleton_method)
+  (class)
+  (module)
+] @scope.function
+
+[
+  (block)
+  (do_block)
+  (lambda)
+] @scope
+
+(method_parameters (identifier) @definition.hoisted)
+(optional_parameter name: (identifier) @definition.hoisted)
+(splat_parameter name: (identifier) @definition.hoisted)
+(hash_splat_parameter name: (identifier) @definition.hoisted)
+(keyword_parameter name: (identifier) @definition.hoisted)
+(block_parameter name: (identifier) @definition.hoisted)
+
+(block_parameters (identifier) @definition)
+(lambda_parameters (identifier) @definition)
+
+(assignment left: (identifier) @definition.hoisted)
+(operator_assignment left: (identifier) @definition.hoisted)
+(left_assignment_list (identifier) @definition.hoisted)
+(for pattern: (identifier) @definition.hoisted)
+(exception_variable (identifier) @definition.hoisted)
@@ -0,0 +1,13 @@
+; Assignments, let bindings and statics: @assignment is the statement, @lhs the assigned pattern, @rhs the value
+
+(assignment_expression
+  left: (_) @lhs
+  right: (_) @rhs) @assignment
+
+(let_declaration
+  pattern: (_) @lhs
+  value: (_)? @rhs) @assignment
+
+(static_item
+  name: (_) @lhs
+  value: (_)? @rhs) @assignment
@@ -0,0 +1,4 @@
+; Function items: @function is the function, @name the name of the function
+
+(function_item
+  name: (_) @name) @function
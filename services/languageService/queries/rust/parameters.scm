@@ -0,0 +1,4 @@
+; Parameters of the functions: @function is the function, @parameter each parameter
+
+(function_item
+  parameters: (parameters (_) @parameter)) @function
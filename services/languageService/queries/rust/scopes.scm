@@ -0,0 +1,30 @@
+; Scopes of the variables: @scope.function is a function, @scope a block, @definition a variable declared from there to the end of the innermost scope,
+; @definition.new a let binding, visible after its statement and shadowing the previous variable of the same name
+
+[
+  (function_item)
+  (closure_expression)
+] @scope.function
+
+[
+  (block)
+  (for_expression)
+  (if_expression)
+  (while_expression)
+  (match_arm)
+] @scope
+
+(parameter pattern: (identifier) @definition)
+(parameter pattern: (tuple_pattern (identifier) @definition))
+(closure_parameters (identifier) @definition)
+(for_expression pattern: (identifier) @definition)
+(for_expression pattern: (tuple_pattern (identifier) @definition))
+(match_pattern (identifier) @definition)
+(tuple_struct_pattern type: (_) (identifier) @definition)
+
+(let_declaration pattern: (identifier) @definition.new)
+(let_declaration pattern: (mut_pattern (identifier) @definition.new))
+(let_declaration pattern: (tuple_pattern (identifier) @definition.new))
+(let_declaration pattern: (tuple_pattern (mut_pattern (identifier) @definition.new)))
+(let_declaration pattern: (struct_pattern (field_pattern name: (shorthand_field_identifier) @definition.new)))
+(let_declaration pattern: (struct_pattern (field_pattern pattern: (identifier) @definition.new)))
@@ -0,0 +1,15 @@
+; Assignments and variable declarations: @assignment is the statement, @lhs the assigned variable, @rhs the value
+
+(assignment_expression
+  left: (_) @lhs
+  right: (_) @rhs) @assignment
+
+(lexical_declaration
+  (variable_declarator
+    name: (_) @lhs
+    value: (_)? @rhs)) @assignment
+
+(variable_declaration
+  (variable_declarator
+    name: (_) @lhs
+    value: (_)? @rhs)) @assignment
@@ -5,7 +5,10 @@ package nodeService
 import (
 	"dataflow/logger"
 	"dataflow/models"
+	"dataflow/services/languageService"
 	"dataflow/services/utilityService"
+	"sort"
+	"strconv"
 	"strings"
 
 	sitter "github.com/smacker/go-tree-sitter"
@@ -18,6 +21,7 @@ import (
 // -----------------------------------------------------------------------------
 //
 // Parameters:
+//   - language (string): The language of the syntax tree.
 //   - node (*sitter.Node): The node for which to find the parent function.
 //   - content ([]byte): The content of the source code.
 //
@@ -25,26 +29,12 @@ import (
 //   - (string): The name of the parent function if found, otherwise an empty string.
 //
 // -----------------------------------------------------------------------------
-func FindParentFunction(node *sitter.Node, content []byte) string {
+func FindParentFunction(language string, node *sitter.Node, content []byte) string {
 	for node != nil {
-		switch node.Type() {
-		case "function_declaration", "method_declaration", "function_definition", "function_item", "method":
-			// Attempt to get the function name from the "name" field
-			funcName := node.ChildByFieldName("name")
-			if funcName != nil {
-				return SafeContent(funcName, content)
-			}
-
-			// Handle special cases where the function name is nested differently
-			if node.Type() == "function_definition" {
-				// For C and C++, the function name is under the "declarator"
-				declarator := node.ChildByFieldName("declarator")
-				if declarator != nil {
-					funcNameNode := findIdentifierInDeclarator(declarator)
-					if funcNameNode != nil {
-						return SafeContent(funcNameNode, content)
-					}
-				}
+		if languageService.IsFunctionNode(language, node) {
+			funcNameNode := getFunctionNameNode(language, node, content)
+			if funcNameNode != nil {
+				return SafeContent(funcNameNode, content)
 			}
 		}
 		node = node.Parent()
@@ -57,6 +47,7 @@ func FindParentFunction(node *sitter.Node, content []byte) string {
 // -----------------------------------------------------------------------------
 //
 // Parameters:
+//   - language (string): The language of the syntax tree.
 //   - root (*sitter.Node): The root node of the syntax tree.
 //   - node (*sitter.Node): The node to check if it is a function declaration.
 //   - content ([]byte): The content of the source code.
@@ -65,26 +56,12 @@ func FindParentFunction(node *sitter.Node, content []byte) string {
 //   - (string): The name of the function if the node is a function declaration, otherwise an empty string.
 //
 // -----------------------------------------------------------------------------
-func IsFunctionDeclaration(root, node *sitter.Node, content []byte) string {
-	switch node.Type() {
-	case "function_declaration", "method_declaration", "function_definition", "function_item", "method":
-		// Attempt to get the function name from the "name" field
-		funcNameNode := node.ChildByFieldName("name")
+func IsFunctionDeclaration(language string, root, node *sitter.Node, content []byte) string {
+	if languageService.IsFunctionNode(language, node) {
+		funcNameNode := getFunctionNameNode(language, node, content)
 		if funcNameNode != nil {
 			return SafeContent(funcNameNode, content)
 		}
-
-		// Handle special cases where the function name is nested differently
-		if node.Type() == "function_definition" {
-			// For C and C++, the function name is under the "declarator"
-			declarator := node.ChildByFieldName("declarator")
-			if declarator != nil {
-				funcNameNode := findIdentifierInDeclarator(declarator)
-				if funcNameNode != nil {
-					return SafeContent(funcNameNode, content)
-				}
-			}
-		}
 	}
 	return ""
 }
@@ -94,6 +71,7 @@ func IsFunctionDeclaration(root, node *sitter.Node, content []byte) string {
 // -----------------------------------------------------------------------------
 //
 // Parameters:
+//   - language (string): The language of the syntax tree.
 //   - root (*sitter.Node): The root node of the syntax tree.
 //   - functionName (string): The name of the function to search for.
 //   - content ([]byte): The content of the source code.
@@ -102,7 +80,7 @@ func IsFunctionDeclaration(root, node *sitter.Node, content []byte) string {
 //   - (bool): True if the function is declared, false otherwise.
 //
 // -----------------------------------------------------------------------------
-func IsFunctionDeclared(root *sitter.Node, functionName string, content []byte) bool {
+func IsFunctionDeclared(language string, root *sitter.Node, functionName string, content []byte) bool {
 	var found bool
 
 	var walkFunc func(node *sitter.Node)
@@ -111,29 +89,11 @@ func IsFunctionDeclared(root *sitter.Node, functionName string, content []byte)
 			return
 		}
 
-		switch node.Type() {
-		case "function_declaration", "method_declaration", "function_definition", "function_item", "method":
-			// Attempt to get the function name from the "name" field
-			funcNameNode := node.ChildByFieldName("name")
-			if funcNameNode != nil {
-				funcName := SafeContent(funcNameNode, content)
-				if funcName == functionName {
-					found = true
-					return
-				}
-			} else if node.Type() == "function_definition" {
-				// For C, C++, and PHP, the function name may be nested in the declarator
-				declarator := node.ChildByFieldName("declarator")
-				if declarator != nil {
-					funcNameNode := findIdentifierInDeclarator(declarator)
-					if funcNameNode != nil {
-						funcName := SafeContent(funcNameNode, content)
-						if funcName == functionName {
-							found = true
-							return
-						}
-					}
-				}
+		if languageService.IsFunctionNode(language, node) {
+			funcNameNode := getFunctionNameNode(language, node, content)
+			if funcNameNode != nil && SafeContent(funcNameNode, content) == functionName {
+				found = true
+				return
 			}
 		}
 
@@ -156,6 +116,7 @@ func IsFunctionDeclared(root *sitter.Node, functionName string, content []byte)
 // -----------------------------------------------------------------------------
 //
 // Parameters:
+//   - language (string): The language of the syntax tree.
 //   - root (*sitter.Node): The root node of the syntax tree.
 //   - functionName (string): The name of the function to find.
 //   - content ([]byte): The content of the source code.
@@ -164,42 +125,18 @@ func IsFunctionDeclared(root *sitter.Node, functionName string, content []byte)
 //   - (*sitter.Node): The node representing the function declaration if found, otherwise nil.
 //
 // -----------------------------------------------------------------------------
-func FindFunctionDeclaration(root *sitter.Node, functionName string, content []byte) *sitter.Node {
+func FindFunctionDeclaration(language string, root *sitter.Node, functionName string, content []byte) *sitter.Node {
 	var result *sitter.Node
 	var traverse func(node *sitter.Node)
 	traverse = func(node *sitter.Node) {
 		if node == nil || result != nil {
 			return
 		}
-		switch node.Type() {
-		case "function_declaration", "method_declaration", "function_definition", "function_item", "method":
-			// Attempt to get the function name from the "name" field
-			funcNameNode := node.ChildByFieldName("name")
-			if funcNameNode != nil {
-				name := SafeContent(funcNameNode, content)
-				if name == functionName {
-					result = node
-					return
-				}
-			} else if node.Type() == "function_definition" {
-				// For C, C++, PHP, and Python, the function name is under the "declarator"
-				declarator := node.ChildByFieldName("declarator")
-				if declarator != nil {
-					funcNameNode := findIdentifierInDeclarator(declarator)
-					if funcNameNode != nil {
-						name := SafeContent(funcNameNode, content)
-						if name == functionName {
-							result = node
-							return
-						}
-					}
-				}
-			} else if node.Type() == "function_item" {
-				// For Rust, the function name is under the "name" field
-				// Already handled by funcNameNode
-			} else if node.Type() == "method" {
-				// For Ruby, the function name is under the "name" field
-				// Already handled by funcNameNode
+		if languageService.IsFunctionNode(language, node) {
+			funcNameNode := getFunctionNameNode(language, node, content)
+			if funcNameNode != nil && SafeContent(funcNameNode, content) == functionName {
+				result = node
+				return
 			}
 		}
 		// Recursively traverse child nodes
@@ -216,16 +153,18 @@ func FindFunctionDeclaration(root *sitter.Node, functionName string, content []b
 // -----------------------------------------------------------------------------
 //
 // Parameters:
+//   - language (string): The language of the syntax tree.
 //   - root (*sitter.Node): The root node of the syntax tree.
 //   - functionName (string): The name of the function to find.
 //   - content ([]byte): The content of the source code.
+//   - log (*logger.Logger): The logger of the analysis.
 //
 // Returns:
 //   - (*sitter.Node): The node representing the function declaration if found, otherwise nil.
 //
 // -----------------------------------------------------------------------------
-func FindFunctionByName(root *sitter.Node, name string, content []byte) *sitter.Node {
-	logger.PrintDebug("Searching for function '%s'...", name)
+func FindFunctionByName(language string, root *sitter.Node, name string, content []byte, log *logger.Logger) *sitter.Node {
+	log.PrintDebug("Searching for function '%s'...", name)
 
 	var result *sitter.Node
 	var traverse func(node *sitter.Node)
@@ -233,33 +172,16 @@ func FindFunctionByName(root *sitter.Node, name string, content []byte) *sitter.
 		if node == nil || result != nil {
 			return
 		}
-		switch node.Type() {
-		case "function_declaration", "method_declaration", "function_definition", "function_item", "method":
-			// Attempt to get the function name from the "name" field
-			funcNameNode := node.ChildByFieldName("name")
+		if languageService.IsFunctionNode(language, node) {
+			funcNameNode := getFunctionNameNode(language, node, content)
 			if funcNameNode != nil {
 				funcName := SafeContent(funcNameNode, content)
-				logger.PrintDebug("Comparing function name '%s' with '%s'", funcName, name)
+				log.PrintDebug("Comparing function name '%s' with '%s'", funcName, name)
 				if funcName == name {
-					logger.PrintInfo("Function '%s' found at line %d", funcName, funcNameNode.StartPoint().Row+1)
+					log.PrintDebug("Function '%s' found at line %d", funcName, funcNameNode.StartPoint().Row+1)
 					result = node
 					return
 				}
-			} else if node.Type() == "function_definition" {
-				// For C, C++, PHP, and Python, the function name may be nested under the "declarator"
-				declarator := node.ChildByFieldName("declarator")
-				if declarator != nil {
-					funcNameNode := findIdentifierInDeclarator(declarator)
-					if funcNameNode != nil {
-						funcName := SafeContent(funcNameNode, content)
-						logger.PrintDebug("Comparing function name '%s' with '%s'", funcName, name)
-						if funcName == name {
-							logger.PrintInfo("Function '%s' found at line %d", funcName, funcNameNode.StartPoint().Row+1)
-							result = node
-							return
-						}
-					}
-				}
 			}
 		}
 		// Recursively traverse child nodes
@@ -269,7 +191,7 @@ func FindFunctionByName(root *sitter.Node, name string, content []byte) *sitter.
 	}
 	traverse(root)
 	if result == nil {
-		logger.PrintDebug("Function '%s' not found", name)
+		log.PrintDebug("Function '%s' not found", name)
 	}
 	return result
 }
@@ -279,15 +201,15 @@ func FindFunctionByName(root *sitter.Node, name string, content []byte) *sitter.
 // -----------------------------------------------------------------------------
 //
 // Parameters:
+//   - language (string): The language of the syntax tree.
 //   - root (*sitter.Node): The root node of the syntax tree.
 //   - line (uint32): The line number to search for.
-//   - lang (string): The programming language of the source code.
 //
 // Returns:
 //   - (*sitter.Node): The node representing the function if found, otherwise nil.
 //
 // -----------------------------------------------------------------------------
-func FindFunctionByLine(root *sitter.Node, line uint32, lang string) *sitter.Node {
+func FindFunctionByLine(language string, root *sitter.Node, line uint32) *sitter.Node {
 	var result *sitter.Node
 
 	var traverse func(node *sitter.Node)
@@ -297,8 +219,7 @@ func FindFunctionByLine(root *sitter.Node, line uint32, lang string) *sitter.Nod
 		}
 
 		// Check if the node is a function node
-		switch node.Type() {
-		case "function_declaration", "method_declaration", "function_definition", "function_item", "method":
+		if languageService.IsFunctionNode(language, node) {
 			startLine := node.StartPoint().Row + 1
 			endLine := node.EndPoint().Row + 1
 			if startLine <= line && line <= endLine {
@@ -327,24 +248,24 @@ func FindFunctionByLine(root *sitter.Node, line uint32, lang string) *sitter.Nod
 // -----------------------------------------------------------------------------
 //
 // Parameters:
+//   - language (string): The language of the syntax tree.
 //   - root (*sitter.Node): The root node of the syntax tree.
 //   - node (*sitter.Node): The node to check if it is a function declaration.
 //   - startLine (uint32): The line number to search for.
 //
 // Returns:
-//   - (functionStart, functionEnd uint32): The start and end lines of the function.
+//   - (functionStart, functionEnd uint32): The start and end lines of the function, the whole file outside the functions.
 //
 // -----------------------------------------------------------------------------
-func FindFunctionBounds(root *sitter.Node, node *sitter.Node, startLine uint32) (functionStart, functionEnd uint32) {
+func FindFunctionBounds(language string, root *sitter.Node, node *sitter.Node, startLine uint32) (functionStart, functionEnd uint32) {
 	var currentNode *sitter.Node
 
 	// Find the node at the specified line
-	currentNode = FindNodeAtLine(root, startLine)
+	currentNode = FindNodeAtLine(language, root, startLine)
 
 	// Traverse up the tree to find the function node
 	for currentNode != nil {
-		switch currentNode.Type() {
-		case "function_declaration", "method_declaration", "function_definition", "function_item", "method":
+		if languageService.IsFunctionNode(language, currentNode) {
 			functionStart = currentNode.StartPoint().Row + 1
 			functionEnd = currentNode.EndPoint().Row + 1
 			return functionStart, functionEnd
@@ -352,13 +273,44 @@ func FindFunctionBounds(root *sitter.Node, node *sitter.Node, startLine uint32)
 		currentNode = currentNode.Parent()
 	}
 
-	// If no function node is found, default to startLine
-	functionStart = startLine
-	functionEnd = startLine
+	// If no function node is found, the line is in the module scope (e.g. the body of a script), which spans the whole file
+	functionStart = root.StartPoint().Row + 1
+	functionEnd = root.EndPoint().Row + 1
 
 	return functionStart, functionEnd
 }
 
+// -----------------------------------------------------------------------------
+// getFunctionNameNode - Returns the node naming a function declaration, from the declarations query of its language.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - functionNode (*sitter.Node): The function declaration node.
+//   - content ([]byte): The content of the source code.
+//
+// Returns:
+//   - (*sitter.Node): The name node if found, otherwise nil.
+//
+// -----------------------------------------------------------------------------
+func getFunctionNameNode(language string, functionNode *sitter.Node, content []byte) *sitter.Node {
+	if captures, ok := languageService.MatchQuery(language, languageService.QueryDeclarations, functionNode, content); ok && len(captures["name"]) > 0 {
+		return captures["name"][0]
+	}
+
+	// Attempt to get the function name from the "name" field
+	funcNameNode := functionNode.ChildByFieldName("name")
+	if funcNameNode != nil {
+		return funcNameNode
+	}
+
+	// For C and C++, the function name is under the "declarator"
+	if declarator := functionNode.ChildByFieldName("declarator"); declarator != nil {
+		return findIdentifierInDeclarator(language, declarator)
+	}
+	return nil
+}
+
 /**** Utils Functions ****/
 
 // -----------------------------------------------------------------------------
@@ -394,6 +346,7 @@ func SafeContent(node *sitter.Node, content []byte) string {
 // -----------------------------------------------------------------------------
 //
 // Parameters:
+//   - language (string): The language of the syntax tree.
 //   - root (*sitter.Node): The root node of the syntax tree.
 //   - variable (string): The variable name to check.
 //   - content ([]byte): The content of the source code.
@@ -402,9 +355,9 @@ func SafeContent(node *sitter.Node, content []byte) string {
 //   - (bool): True if the variable name does not correspond to a function declaration, false otherwise.
 //
 // -----------------------------------------------------------------------------
-func IsValidVariableToTrack(root *sitter.Node, variable string, content []byte) bool {
+func IsValidVariableToTrack(language string, root *sitter.Node, variable string, content []byte) bool {
 	// Vérifier si le nom de la variable correspond à une déclaration de fonction
-	return !IsFunctionDeclared(root, variable, content)
+	return !IsFunctionDeclared(language, root, variable, content)
 }
 
 // -----------------------------------------------------------------------------
@@ -412,25 +365,37 @@ func IsValidVariableToTrack(root *sitter.Node, variable string, content []byte)
 // -----------------------------------------------------------------------------
 //
 // Parameters:
+//   - language (string): The language of the syntax tree.
 //   - node (*sitter.Node): The root node of the syntax tree.
 //   - targetLine (uint32): The line number to search for.
 //
 // Returns:
-//   - (*sitter.Node): The node at the specified line if found, otherwise nil.
+//   - (*sitter.Node): The node at the specified line if found, otherwise nil. The markup of a template is skipped: the
+//     node of <h1><?php echo $greeting; ?></h1> is its echo statement.
 //
 // -----------------------------------------------------------------------------
-func FindNodeAtLine(node *sitter.Node, targetLine uint32) *sitter.Node {
-	if node.StartPoint().Row+1 == targetLine {
+func FindNodeAtLine(language string, node *sitter.Node, targetLine uint32) *sitter.Node {
+	if languageService.IsSyntaxNode(language, node, languageService.SyntaxTemplateMarkup) {
+		return nil
+	}
+
+	// The root starts on the first line but spans the whole file: the first line is its first statement
+	isRoot := node.Parent() == nil
+	if node.StartPoint().Row+1 == targetLine && !isRoot {
 		return node
 	}
 
 	for i := 0; i < int(node.ChildCount()); i++ {
 		child := node.Child(i)
-		if result := FindNodeAtLine(child, targetLine); result != nil {
+		if result := FindNodeAtLine(language, child, targetLine); result != nil {
 			return result
 		}
 	}
 
+	// A line of markup only (<?php) has no node
+	if isRoot && node.StartPoint().Row+1 == targetLine && !(node.ChildCount() > 0 && languageService.IsSyntaxNode(language, node.Child(0), languageService.SyntaxTemplateMarkup)) {
+		return node
+	}
 	return nil
 }
 
@@ -439,117 +404,94 @@ func FindNodeAtLine(node *sitter.Node, targetLine uint32) *sitter.Node {
 // -----------------------------------------------------------------------------
 //
 // Parameters:
-//   - nodeType (string): The type of the node.
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): The node of the statement.
 //
 // Returns:
 //   - (string): A descriptive string for the control flow statement.
 //
 // -----------------------------------------------------------------------------
-func GetControlType(nodeType string) string {
-	switch nodeType {
-	// Conditional statements
-	case "if_statement", "if_expression", // Go, C, C++, Java, JavaScript, C#, PHP, Python, Rust
-		"if",                     // Ruby
-		"conditional_expression": // JavaScript ternary operator
+func GetControlType(language string, node *sitter.Node) string {
+	switch languageService.GetControlKind(language, node) {
+	case languageService.ControlCondition:
 		return "Variable used in 'if' condition"
-
-	// Loop statements
-	case "for_statement", "enhanced_for_statement", "foreach_statement", "for_in_statement", "for_of_statement", // Go, C, C++, Java, JavaScript, C#, PHP
-		"for_expression",                                   // Rust
-		"for",                                              // Ruby
-		"do_statement", "do_expression", "loop_expression": // Go, C, C++, Java, JavaScript, C#, PHP, Rust
+	case languageService.ControlLoop:
 		return "Variable used in loop condition"
-
-	// While loops
-	case "while_statement", "while_expression", // C, C++, Java, JavaScript, C#, PHP, Python, Rust
-		"while": // Ruby
+	case languageService.ControlWhile:
 		return "Variable used in 'while' loop condition"
-
-	// Switch statements
-	case "switch_statement", "switch_expression", "match_expression": // Go, C, C++, Java, JavaScript, C#, PHP, Rust
+	case languageService.ControlSwitch:
 		return "Variable used in 'switch' or 'match' statement"
-
-	// Return statements
-	case "return_statement", "return_expression": // Go, C, C++, Java, JavaScript, C#, PHP, Python, Rust
+	case languageService.ControlReturn:
 		return "Variable used in return statement"
-
-	// Function calls
-	case "call_expression", "function_call_expression", "method_invocation", "invocation_expression", "call": // Go, C, C++, Java, JavaScript, C#, PHP, Python, Ruby
+	case languageService.ControlCall:
 		return "Variable used in function call"
-
-	// Goroutines (Go-specific)
-	case "go_statement": // Go
+	case languageService.ControlConcurrency:
 		return "Variable used in goroutine launch"
-
-	// Identifier usage
-	case "identifier", "variable_name", "variable": // General identifier usage across languages
+	case languageService.ControlIdentifier:
 		return "Use of variable"
-
-	// Exception handling
-	case "try_statement", "catch_clause", "except_clause", "finally_clause": // Java, C#, JavaScript, Python, PHP
+	case languageService.ControlException:
 		return "Variable used in exception handling"
-
-	// Others
-	case "assignment_expression", "assignment_statement", "short_var_declaration": // Assignments
+	case languageService.ControlAssignment:
 		return "Variable used in assignment"
-
 	default:
 		return ""
 	}
 }
 
+// -----------------------------------------------------------------------------
+// containsIdentifier - Checks if a variable is read under a node.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): The node to check.
+//
+// Returns:
+//   - (bool): True if an identifier is found under the node.
+//
+// -----------------------------------------------------------------------------
+func containsIdentifier(language string, node *sitter.Node) bool {
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		child := node.NamedChild(i)
+		if IsIdentifierNode(language, child) || containsIdentifier(language, child) {
+			return true
+		}
+	}
+	return false
+}
+
 // -----------------------------------------------------------------------------
 // IsLiteral - Checks if the node represents a literal value in the syntax tree.
 // -----------------------------------------------------------------------------
 //
 // Parameters:
+//   - language (string): The language of the syntax tree.
 //   - node (*sitter.Node): The node to check.
 //
 // Returns:
 //   - (bool): True if the node represents a literal value, false otherwise.
 //
 // -----------------------------------------------------------------------------
-func IsLiteral(node *sitter.Node) bool {
+func IsLiteral(language string, node *sitter.Node) bool {
 	if node == nil {
 		return false
 	}
 
-	// Node types representing literals in different languages
-	literalNodeTypes := []string{
-		// Go
-		"interpreted_string_literal", "raw_string_literal", "int_literal", "float_literal", "rune_literal",
-		// C/C++
-		"string_literal", "char_literal", "number_literal",
-		// C#
-		"string_literal", "character_literal", "integer_literal", "real_literal",
-		// Java
-		"string_literal", "character_literal", "decimal_integer_literal", "hex_integer_literal", "octal_integer_literal", "binary_integer_literal", "floating_point_literal",
-		// JavaScript
-		"string", "number",
-		// PHP
-		"string", "encapsed_string", "integer", "floating_point",
-		// Python
-		"string", "integer", "float",
-		// Ruby
-		"string", "integer", "float",
-		// Rust
-		"string_literal", "char_literal", "integer_literal", "float_literal",
-	}
-
-	if utilityService.ContainString(literalNodeTypes, node.Type()) {
-		return true
+	// A value reading a variable is not a literal, even built with literals (e.g., cfg["path"], rest[0], base + "/data",
+	// "$path", f"{path}")
+	if IsIdentifierNode(language, node) || containsIdentifier(language, node) {
+		return false
 	}
 
-	// Check for nested literal content (e.g., string content)
-	nestedLiteralTypes := []string{"string_content", "string_fragment", "string_literal_content"}
-	if utilityService.ContainString(nestedLiteralTypes, node.Type()) {
+	// Literals and nested literal content (e.g., string content) of the supported languages
+	if languageService.IsLiteralNode(language, node) {
 		return true
 	}
 
 	// Recursively check child nodes
 	for i := 0; i < int(node.ChildCount()); i++ {
 		child := node.Child(i)
-		if IsLiteral(child) {
+		if IsLiteral(language, child) {
 			return true
 		}
 	}
@@ -557,28 +499,97 @@ func IsLiteral(node *sitter.Node) bool {
 	return false
 }
 
+// -----------------------------------------------------------------------------
+// IsIdentifierNode - Checks if a node is an identifier that can name a variable.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): The node to check.
+//
+// Returns:
+//   - (bool): True if the node is an identifier of its language (e.g., simple_identifier in Kotlin).
+//
+// -----------------------------------------------------------------------------
+func IsIdentifierNode(language string, node *sitter.Node) bool {
+	return languageService.IsSyntaxNode(language, node, languageService.SyntaxIdentifier) || languageService.GetControlKind(language, node) == languageService.ControlIdentifier
+}
+
+// -----------------------------------------------------------------------------
+// IsBlockNode - Checks if a node is a block of statements, analyzed from its first child.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): The node to check.
+//
+// Returns:
+//   - (bool): True if the node is a block of its language (e.g., block in Python, statements in Kotlin).
+//
+// -----------------------------------------------------------------------------
+func IsBlockNode(language string, node *sitter.Node) bool {
+	return languageService.IsSyntaxNode(language, node, languageService.SyntaxBlock)
+}
+
+// -----------------------------------------------------------------------------
+// IsReturnNode - Checks if a node is a return statement.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): The node to check.
+//
+// Returns:
+//   - (bool): True if the node returns from its function (e.g., return_expression in Rust).
+//
+// -----------------------------------------------------------------------------
+func IsReturnNode(language string, node *sitter.Node) bool {
+	return languageService.GetControlKind(language, node) == languageService.ControlReturn
+}
+
+// -----------------------------------------------------------------------------
+// GetIdentifierNode - Returns the node naming the variable of an identifier, the way the analysis tracks it.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): The identifier node.
+//
+// Returns:
+//   - (*sitter.Node): The variable written with its sigil when the identifier is the name of a sigil variable ($path
+//     for path in PHP), otherwise the identifier itself.
+//
+// -----------------------------------------------------------------------------
+func GetIdentifierNode(language string, node *sitter.Node) *sitter.Node {
+	if parent := node.Parent(); parent != nil && languageService.IsSyntaxNode(language, parent, languageService.SyntaxSigilVariable) {
+		return parent
+	}
+	return node
+}
+
 // -----------------------------------------------------------------------------
 // findIdentifierInDeclarator - Recursively searches for an identifier in a declarator node.
 // -----------------------------------------------------------------------------
 //
 // Parameters:
+//   - language (string): The language of the syntax tree.
 //   - node (*sitter.Node): The declarator node to search.
 //
 // Returns:
 //   - (*sitter.Node): The identifier node if found, otherwise nil.
 //
 // -----------------------------------------------------------------------------
-func findIdentifierInDeclarator(node *sitter.Node) *sitter.Node {
+func findIdentifierInDeclarator(language string, node *sitter.Node) *sitter.Node {
 	if node == nil {
 		return nil
 	}
-	if node.Type() == "identifier" || node.Type() == "variable_name" || node.Type() == "name" {
+	if languageService.IsSyntaxNode(language, node, languageService.SyntaxIdentifier) {
 		return node
 	}
 	// Recurse into all children, not just named ones
 	for i := 0; i < int(node.ChildCount()); i++ {
 		child := node.Child(i)
-		if result := findIdentifierInDeclarator(child); result != nil {
+		if result := findIdentifierInDeclarator(language, child); result != nil {
 			return result
 		}
 	}
@@ -592,25 +603,25 @@ func findIdentifierInDeclarator(node *sitter.Node) *sitter.Node {
 // -----------------------------------------------------------------------------
 //
 // Parameters:
+//   - language (string): The language of the syntax tree.
 //   - node (*sitter.Node): The node to search for a call expression.
 //
 // Returns:
 //   - (*sitter.Node): The call expression node if found, otherwise nil.
 //
 // -----------------------------------------------------------------------------
-func FindCallExpression(node *sitter.Node) *sitter.Node {
+func FindCallExpression(language string, node *sitter.Node) *sitter.Node {
 	if node == nil {
 		return nil
 	}
 
-	switch node.Type() {
-	case "call_expression", "invocation_expression", "method_invocation", "function_call_expression", "call", "macro_invocation":
+	if languageService.IsCallNode(language, node) {
 		return node
 	}
 
 	for i := 0; i < int(node.ChildCount()); i++ {
 		child := node.Child(i)
-		result := FindCallExpression(child)
+		result := FindCallExpression(language, child)
 		if result != nil {
 			return result
 		}
@@ -619,11 +630,39 @@ func FindCallExpression(node *sitter.Node) *sitter.Node {
 	return nil
 }
 
+// -----------------------------------------------------------------------------
+// FindCallExpressions - Finds the call expression nodes in a given node, the outer calls first.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): The node to search for call expressions.
+//
+// Returns:
+//   - ([]*sitter.Node): The call expression nodes (strings.ToLower(helper(a)) and helper(a)).
+//
+// -----------------------------------------------------------------------------
+func FindCallExpressions(language string, node *sitter.Node) []*sitter.Node {
+	var calls []*sitter.Node
+	if node == nil {
+		return calls
+	}
+
+	if languageService.IsCallNode(language, node) {
+		calls = append(calls, node)
+	}
+	for i := 0; i < int(node.ChildCount()); i++ {
+		calls = append(calls, FindCallExpressions(language, node.Child(i))...)
+	}
+	return calls
+}
+
 // -----------------------------------------------------------------------------
 // ExtractFunctionNameFromCall - Extracts the function name from a function call node.
 // -----------------------------------------------------------------------------
 //
 // Parameters:
+//   - language (string): The language of the syntax tree.
 //   - node (*sitter.Node): The function call node.
 //   - content ([]byte): The content of the source code.
 //
@@ -631,11 +670,16 @@ func FindCallExpression(node *sitter.Node) *sitter.Node {
 //   - (string): The name of the function being called.
 //
 // -----------------------------------------------------------------------------
-func ExtractFunctionNameFromCall(node *sitter.Node, content []byte) string {
+func ExtractFunctionNameFromCall(language string, node *sitter.Node, content []byte) string {
 	if node == nil {
 		return ""
 	}
 
+	// The calls query of the language names the called function
+	if captures, ok := languageService.MatchQuery(language, languageService.QueryCalls, node, content); ok && len(captures["callee"]) > 0 {
+		return SafeContent(captures["callee"][0], content)
+	}
+
 	// Try to extract function name from different possible fields
 	possibleFields := []string{"function", "call", "method", "name", "function_name"}
 	for _, field := range possibleFields {
@@ -648,7 +692,7 @@ func ExtractFunctionNameFromCall(node *sitter.Node, content []byte) string {
 	// If no function node is found, attempt to find an identifier or constant among the children
 	for i := 0; i < int(node.NamedChildCount()); i++ {
 		child := node.NamedChild(i)
-		if child.Type() == "identifier" || child.Type() == "constant" || child.Type() == "variable_name" || child.Type() == "name" {
+		if IsIdentifierNode(language, child) || languageService.IsSyntaxNode(language, child, languageService.SyntaxConstant) {
 			return SafeContent(child, content)
 		}
 	}
@@ -661,6 +705,7 @@ func ExtractFunctionNameFromCall(node *sitter.Node, content []byte) string {
 // -----------------------------------------------------------------------------
 //
 // Parameters:
+//   - language (string): The language of the syntax tree.
 //   - node (*sitter.Node): The node to check.
 //   - content ([]byte): The content of the source code.
 //   - variable (string): The variable to check for.
@@ -669,44 +714,28 @@ func ExtractFunctionNameFromCall(node *sitter.Node, content []byte) string {
 //   - (bool, string): True if the node represents a function call involving the variable, and the variable name if assigned.
 //
 // -----------------------------------------------------------------------------
-func IsFunctionCall(node *sitter.Node, content []byte, variable string) (bool, string) {
+func IsFunctionCall(language string, node *sitter.Node, content []byte, variable string) (bool, string) {
 	if node == nil {
 		return false, ""
 	}
 
 	// Check if the node is a function call in any of the supported languages
-	switch node.Type() {
-	case "call_expression", // Go, C, C++, JavaScript, Rust
-		"invocation_expression",    // C#
-		"method_invocation",        // Java
-		"function_call_expression", // PHP
-		"call",                     // Python, Ruby
-		"macro_invocation":         // Rust (for macros like println!)
-		// Proceed to extract arguments
-	default:
+	if !languageService.IsCallNode(language, node) {
 		return false, ""
 	}
 
 	// Extract arguments from the function call
-	args := node.ChildByFieldName("arguments")
-	if args == nil && node.Type() == "call" {
-		// For Ruby, arguments might be direct children under "argument_list"
-		args = node.ChildByFieldName("argument_list")
-	}
-	if args != nil {
-		for i := 0; i < int(args.NamedChildCount()); i++ {
-			arg := args.NamedChild(i)
-			if SafeContent(arg, content) == variable {
-				// Check for assignment to a new variable on the left
-				parent := node.Parent()
-				if parent != nil {
-					variableName := getAssignedVariableName(parent, content)
-					if variableName != "" {
-						return true, variableName
-					}
+	for _, arg := range GetCallArguments(language, node, content) {
+		if ArgumentRefersToVariable(language, arg, content, variable) {
+			// Check for assignment to a new variable on the left
+			parent := node.Parent()
+			if parent != nil {
+				variableName := getAssignedVariableName(language, parent, content)
+				if variableName != "" {
+					return true, variableName
 				}
-				return true, ""
 			}
+			return true, ""
 		}
 	}
 
@@ -718,6 +747,7 @@ func IsFunctionCall(node *sitter.Node, content []byte, variable string) (bool, s
 // -----------------------------------------------------------------------------
 //
 // Parameters:
+//   - language (string): The language of the syntax tree.
 //   - parent (*sitter.Node): The parent node to check.
 //   - content ([]byte): The content of the source code.
 //
@@ -725,59 +755,75 @@ func IsFunctionCall(node *sitter.Node, content []byte, variable string) (bool, s
 //   - (string): The name of the assigned variable if found, otherwise an empty string.
 //
 // -----------------------------------------------------------------------------
-func getAssignedVariableName(parent *sitter.Node, content []byte) string {
+func getAssignedVariableName(language string, parent *sitter.Node, content []byte) string {
 	if parent == nil {
 		return ""
 	}
 
 	var leftSide *sitter.Node
 
-	switch parent.Type() {
-	case "short_var_declaration", "assignment_statement", "assignment_expression", "assignment":
-		// Common assignment node types
-		leftSide = parent.ChildByFieldName("left")
-	case "local_variable_declaration", "local_declaration_statement", "lexical_declaration":
-		// Declarations with assignments in Java, C#, JavaScript
-		for i := 0; i < int(parent.NamedChildCount()); i++ {
-			child := parent.NamedChild(i)
-			if child.Type() == "variable_declarator" {
-				leftSide = child.ChildByFieldName("name")
-				break
-			}
-		}
-	case "declaration":
-		// For C and C++ declarations
-		declarator := parent.ChildByFieldName("declarator")
-		if declarator != nil {
-			leftSide = findIdentifierInDeclarator(declarator)
-		}
-	case "let_declaration":
-		// Rust let declaration
-		leftSide = parent.ChildByFieldName("pattern")
-	case "variable_declarator", "init_declarator":
-		// Variable declarators in various languages
+	if languageService.IsSyntaxNode(language, parent, languageService.SyntaxDeclarator) {
+		// A declarator names the variable receiving its value (b in const a = 1, b = read())
 		leftSide = parent.ChildByFieldName("name")
 		if leftSide == nil {
 			// For C++, the name might be nested in the declarator
-			declarator := parent.ChildByFieldName("declarator")
-			if declarator != nil {
-				leftSide = findIdentifierInDeclarator(declarator)
-			}
+			leftSide = findIdentifierInDeclarator(language, parent.ChildByFieldName("declarator"))
 		}
+	} else if captures, ok := languageService.MatchQuery(language, languageService.QueryAssignments, parent, content); ok && len(captures["lhs"]) > 0 {
+		// Otherwise the first target of the assignments query of the language receives it
+		leftSide = captures["lhs"][0]
 	}
 
 	if leftSide != nil {
+		// A field or a key receives the value (e.g., req.Path = read())
+		if path := GetAccessPath(language, leftSide, content); path != "" {
+			return path
+		}
 		return SafeContent(leftSide, content)
 	}
 
 	return ""
 }
 
+// -----------------------------------------------------------------------------
+// FindAssignedVariable - Finds the variable receiving the value returned by a function call.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - callNode (*sitter.Node): The function call node.
+//   - content ([]byte): The content of the source code.
+//
+// Returns:
+//   - (string): The name of the assigned variable if found, otherwise an empty string.
+//
+// -----------------------------------------------------------------------------
+func FindAssignedVariable(language string, callNode *sitter.Node, content []byte) string {
+	if callNode == nil {
+		return ""
+	}
+
+	parent := callNode.Parent()
+	for parent != nil {
+		if variableName := getAssignedVariableName(language, parent, content); variableName != "" {
+			return variableName
+		}
+
+		// Climb through the nodes wrapping the call without changing its value, TypeScript casts and non-null assertions included
+		if !languageService.IsSyntaxNode(language, parent, languageService.SyntaxValueWrapper) {
+			return ""
+		}
+		parent = parent.Parent()
+	}
+	return ""
+}
+
 // -----------------------------------------------------------------------------
 // FindFunctionCallSites - Searches all lines where a specific function is called.
 // -----------------------------------------------------------------------------
 //
 // Parameters:
+//   - language (string): The language of the syntax tree.
 //   - root (*sitter.Node): The root node of the syntax tree.
 //   - functionName (string): The name of the function to search for.
 //   - content ([]byte): The content of the source code.
@@ -786,7 +832,7 @@ func getAssignedVariableName(parent *sitter.Node, content []byte) string {
 //   - ([]models.FunctionCallSite): A slice of function call sites.
 //
 // -----------------------------------------------------------------------------
-func FindFunctionCallSites(root *sitter.Node, functionName string, content []byte) []models.FunctionCallSite {
+func FindFunctionCallSites(language string, root *sitter.Node, functionName string, content []byte) []models.FunctionCallSite {
 	var callSites []models.FunctionCallSite
 
 	var exploreNode func(node *sitter.Node)
@@ -796,12 +842,11 @@ func FindFunctionCallSites(root *sitter.Node, functionName string, content []byt
 		}
 
 		// Check if the node represents a function call in any of the specified languages
-		switch node.Type() {
-		case "call_expression", "invocation_expression", "method_invocation", "function_call_expression", "call", "macro_invocation":
+		if languageService.IsCallNode(language, node) {
 			// Extract the function being called
-			funcNode := getFunctionNode(node)
+			funcNode := GetCallee(language, node, content)
 			if funcNode != nil {
-				funcName := extractFunctionName(funcNode, content)
+				funcName := extractFunctionName(language, funcNode, content)
 				if funcName == functionName {
 					// Add the call site to the list
 					callSites = append(callSites, models.FunctionCallSite{
@@ -829,6 +874,7 @@ func FindFunctionCallSites(root *sitter.Node, functionName string, content []byt
 // -----------------------------------------------------------------------------
 //
 // Parameters:
+//   - language (string): The language of the syntax tree.
 //   - callNode (*sitter.Node): The function call node.
 //   - parameterVariable (string): The parameter variable to search for.
 //   - functionNode (*sitter.Node): The function node containing the parameter.
@@ -838,54 +884,113 @@ func FindFunctionCallSites(root *sitter.Node, functionName string, content []byt
 //   - (string): The argument variable corresponding to the parameter variable.
 //
 // -----------------------------------------------------------------------------
-func GetArgumentVariable(callNode *sitter.Node, parameterVariable string, functionNode *sitter.Node, content []byte) string {
-	// Get the list of parameter names from the function's parameter list
-	parameters := getParametersNode(functionNode)
-	logger.PrintDebug("Parameters: %v", parameters)
-	if parameters != nil {
-		var parameterNames []string
-		for i := 0; i < int(parameters.NamedChildCount()); i++ {
-			param := parameters.NamedChild(i)
-			paramNames := extractParameterNames(param, content)
-			parameterNames = append(parameterNames, paramNames...)
+func GetArgumentVariable(language string, callNode *sitter.Node, parameterVariable string, functionNode *sitter.Node, content []byte) string {
+	return GetArgumentVariableFromContents(language, callNode, content, parameterVariable, functionNode, content)
+}
 
+// -----------------------------------------------------------------------------
+// GetArgumentVariableFromContents - Finds the argument variable corresponding to a parameter variable
+// when the function call and the function declaration live in different files.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - callNode (*sitter.Node): The function call node.
+//   - callContent ([]byte): The content of the file containing the call.
+//   - parameterVariable (string): The parameter variable to search for.
+//   - functionNode (*sitter.Node): The function node containing the parameter.
+//   - functionContent ([]byte): The content of the file containing the function.
+//
+// Returns:
+//   - (string): The argument variable corresponding to the parameter variable.
+//
+// -----------------------------------------------------------------------------
+func GetArgumentVariableFromContents(language string, callNode *sitter.Node, callContent []byte, parameterVariable string, functionNode *sitter.Node, functionContent []byte) string {
+	content := callContent
+
+	// Functions reading their arguments by position (e.g. $1 in a shell script) declare no parameters
+	if index, ok := languageService.GetPositionalParameterIndex(language, functionNode, parameterVariable); ok {
+		arguments := GetCallArguments(language, callNode, content)
+		if index >= 0 {
+			if index < len(arguments) {
+				return extractArgumentName(language, arguments[index], content)
+			}
+			return ""
 		}
-
-		logger.PrintDebug("Parameter names: %v", parameterNames)
-		// Find the index of the parameter variable
-		var paramIndex = -1
-		for idx, paramName := range parameterNames {
-			if paramName == parameterVariable {
-				paramIndex = idx
-				break
+		// The whole argument list ($@) is the first argument holding a variable
+		for _, arg := range arguments {
+			if argName := extractArgumentName(language, arg, content); argName != "" {
+				return argName
 			}
 		}
-		if paramIndex != -1 {
-			// Get the argument at the same index in the call node
-			arguments := getArgumentsNode(callNode)
-			if arguments != nil && paramIndex < int(arguments.NamedChildCount()) {
-				arg := arguments.NamedChild(paramIndex)
-				argName := extractArgumentName(arg, content)
-				if argName != "" {
-					return argName
-				} else {
-					// If argName is empty, try to extract identifiers from expressions
-					identifiers := extractIdentifiers(arg, content)
-					if len(identifiers) > 0 {
-						return identifiers[0] // Return the first identifier found
-					}
-				}
+		return ""
+	}
+
+	// Find the arguments passed to the parameter, the tracked path being a field of the parameter or the parameter itself
+	parameterBase := AccessPathBase(parameterVariable)
+	for _, binding := range bindArguments(language, functionNode, functionContent, callNode, content) {
+		if binding.parameter.name != parameterBase || binding.argument == nil {
+			continue
+		}
+
+		// Keyword arguments collected by **kwargs are the keys of the parameter (kwargs["path"] for f(path=p))
+		parameterPath := binding.parameter.name + binding.parameterKey
+		if !AccessPathsOverlap(parameterPath, parameterVariable) {
+			continue
+		}
+
+		arg := binding.argument
+		if argPath := GetAccessPath(language, arg, content); argPath != "" {
+			// A dictionary spread over the parameters passes its keys (opts["path"] for path in f(**opts))
+			return RebaseAccessPath(parameterVariable, parameterPath, argPath+binding.argumentKey)
+		}
+		argName := extractArgumentName(language, arg, content)
+		if argName != "" {
+			return argName
+		} else {
+			// If argName is empty, try to extract identifiers from expressions
+			identifiers := extractIdentifiers(language, arg, content)
+			if len(identifiers) > 0 {
+				return identifiers[0] // Return the first identifier found
 			}
 		}
 	}
 	return ""
 }
 
+// -----------------------------------------------------------------------------
+// GetParameterDefaultValue - Returns the default value a parameter takes when a function call leaves it out.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - callNode (*sitter.Node): The function call node.
+//   - callContent ([]byte): The content of the file containing the call.
+//   - parameterVariable (string): The parameter variable, or one of its fields.
+//   - functionNode (*sitter.Node): The function node containing the parameter.
+//   - functionContent ([]byte): The content of the file containing the function.
+//
+// Returns:
+//   - (*sitter.Node): The default value of the parameter (os.getcwd() in def load(path=os.getcwd())), or nil if the
+//     call passes an argument to the parameter or the parameter has no default value.
+//
+// -----------------------------------------------------------------------------
+func GetParameterDefaultValue(language string, callNode *sitter.Node, callContent []byte, parameterVariable string, functionNode *sitter.Node, functionContent []byte) *sitter.Node {
+	parameterBase := AccessPathBase(parameterVariable)
+	for _, binding := range bindArguments(language, functionNode, functionContent, callNode, callContent) {
+		if binding.parameter.name == parameterBase && binding.argument == nil {
+			return binding.parameter.defaultValue
+		}
+	}
+	return nil
+}
+
 // -----------------------------------------------------------------------------
 // extractArgumentName - Extracts the variable name from an argument node.
 // -----------------------------------------------------------------------------
 //
 // Parameters:
+//   - language (string): The language of the syntax tree.
 //   - argNode (*sitter.Node): The argument node.
 //   - content ([]byte): The content of the source code.
 //
@@ -893,19 +998,18 @@ func GetArgumentVariable(callNode *sitter.Node, parameterVariable string, functi
 //   - (string): The name of the argument variable.
 //
 // -----------------------------------------------------------------------------
-func extractArgumentName(argNode *sitter.Node, content []byte) string {
+func extractArgumentName(language string, argNode *sitter.Node, content []byte) string {
 	if argNode == nil {
 		return ""
 	}
-	switch argNode.Type() {
-	case "identifier", "variable_name", "name":
+	if IsIdentifierNode(language, argNode) {
 		return SafeContent(argNode, content)
-	default:
-		// Attempt to find an identifier within the argument node
-		identifiers := extractIdentifiers(argNode, content)
-		if len(identifiers) > 0 {
-			return identifiers[0] // Return the first identifier found
-		}
+	}
+
+	// Attempt to find an identifier within the argument node
+	identifiers := extractIdentifiers(language, argNode, content)
+	if len(identifiers) > 0 {
+		return identifiers[0] // Return the first identifier found
 	}
 	return ""
 }
@@ -915,36 +1019,24 @@ func extractArgumentName(argNode *sitter.Node, content []byte) string {
 // -----------------------------------------------------------------------------
 //
 // Parameters:
+//   - language (string): The language of the syntax tree.
 //   - node (*sitter.Node): The node to search for the function.
 //
 // Returns:
 //   - (*sitter.Node): The function node if found, otherwise nil.
 //
 // -----------------------------------------------------------------------------
-func getFunctionNode(node *sitter.Node) *sitter.Node {
-	// Depending on the node type, the function node may be in different child fields
-	switch node.Type() {
-	case "call_expression", "invocation_expression", "method_invocation", "function_call_expression", "macro_invocation":
-		// For these node types, the function is in the "function" or "function_name" field
-		funcNode := node.ChildByFieldName("function")
-		if funcNode == nil {
-			funcNode = node.ChildByFieldName("function_name")
-		}
-		if funcNode == nil {
-			// For some languages, the function might be in a different field
-			funcNode = node.ChildByFieldName("name")
-		}
-		return funcNode
-	case "call":
-		// For Python and Ruby, the function being called may be in the "function" field or "method" field
-		funcNode := node.ChildByFieldName("function")
-		if funcNode == nil {
-			funcNode = node.ChildByFieldName("method")
-		}
-		return funcNode
-	default:
+func getFunctionNode(language string, node *sitter.Node) *sitter.Node {
+	if !languageService.IsCallNode(language, node) {
 		return nil
 	}
+	// Depending on the language, the function node may be in different child fields, Ruby naming its method
+	for _, field := range []string{"function", "function_name", "name", "method"} {
+		if funcNode := node.ChildByFieldName(field); funcNode != nil {
+			return funcNode
+		}
+	}
+	return nil
 }
 
 // -----------------------------------------------------------------------------
@@ -952,6 +1044,7 @@ func getFunctionNode(node *sitter.Node) *sitter.Node {
 // -----------------------------------------------------------------------------
 //
 // Parameters:
+//   - language (string): The language of the syntax tree.
 //   - funcNode (*sitter.Node): The function node.
 //   - content ([]byte): The content of the source code.
 //
@@ -959,35 +1052,34 @@ func getFunctionNode(node *sitter.Node) *sitter.Node {
 //   - (string): The name of the function.
 //
 // -----------------------------------------------------------------------------
-func extractFunctionName(funcNode *sitter.Node, content []byte) string {
+func extractFunctionName(language string, funcNode *sitter.Node, content []byte) string {
 	if funcNode == nil {
 		return ""
 	}
 
 	// In some languages, the function node may be an identifier or a more complex expression
-	switch funcNode.Type() {
-	case "identifier", "variable_name", "name", "constant":
+	if IsIdentifierNode(language, funcNode) {
 		return SafeContent(funcNode, content)
-	case "member_expression", "field_access", "scoped_identifier", "member_access_expression":
-		// The function is a method of an object (e.g., object.method)
-		propertyNode := funcNode.ChildByFieldName("property")
-		if propertyNode == nil {
-			// For some languages, the property might be under "field" or "name"
-			propertyNode = funcNode.ChildByFieldName("field")
-		}
-		if propertyNode != nil {
-			return SafeContent(propertyNode, content)
+	}
+	switch {
+	case languageService.IsSyntaxNode(language, funcNode, languageService.SyntaxConstant) || languageService.IsSyntaxNode(language, funcNode, languageService.SyntaxCommandName):
+		// Ruby constants and shell commands name the function directly
+		return SafeContent(funcNode, content)
+	case languageService.IsCallNode(language, funcNode) && funcNode.ChildByFieldName("function") != nil:
+		// In nested calls, recursively extract the function name
+		return extractFunctionName(language, funcNode.ChildByFieldName("function"), content)
+	case languageService.IsSyntaxNode(language, funcNode, languageService.SyntaxMemberAccess) || languageService.IsSyntaxNode(language, funcNode, languageService.SyntaxScopedName):
+		// The function is a method of an object (e.g., object.method, file.exists in Kotlin), or of a type (Type::method)
+		if member := getAccessMember(language, funcNode); member != nil {
+			return SafeContent(member, content)
 		}
 		// If we can't find the property, attempt to extract from the function node
 		return SafeContent(funcNode, content)
-	case "call":
-		// In nested calls, recursively extract the function name
-		return extractFunctionName(funcNode.ChildByFieldName("function"), content)
 	default:
 		// Attempt to find an identifier among the children
 		for i := 0; i < int(funcNode.NamedChildCount()); i++ {
 			child := funcNode.NamedChild(i)
-			if child.Type() == "identifier" || child.Type() == "variable_name" || child.Type() == "name" || child.Type() == "constant" {
+			if IsIdentifierNode(language, child) || languageService.IsSyntaxNode(language, child, languageService.SyntaxConstant) {
 				return SafeContent(child, content)
 			}
 		}
@@ -1003,6 +1095,7 @@ func extractFunctionName(funcNode *sitter.Node, content []byte) string {
 // -----------------------------------------------------------------------------
 //
 // Parameters:
+//   - language (string): The language of the syntax tree.
 //   - functionNode (*sitter.Node): The function node containing the parameter.
 //   - content ([]byte): The content of the source code.
 //   - originalVariable (string): The original variable name.
@@ -1012,74 +1105,221 @@ func extractFunctionName(funcNode *sitter.Node, content []byte) string {
 //   - (string): The name of the parameter corresponding to the variable.
 //
 // -----------------------------------------------------------------------------
-func GetParameterName(functionNode *sitter.Node, content []byte, originalVariable string, callSite *sitter.Node) string {
-	// Find the position of the variable in the function call arguments
-	arguments := getArgumentsNode(callSite)
-	if arguments != nil {
-		for i := 0; i < int(arguments.NamedChildCount()); i++ {
-			arg := arguments.NamedChild(i)
-			argName := SafeContent(arg, content)
-			if argName == originalVariable {
-				// Now, i is the index of the parameter in the call
-				parameters := getParametersNode(functionNode)
-				if parameters != nil && i < int(parameters.NamedChildCount()) {
-					param := parameters.NamedChild(i)
-					paramName := extractParameterName(param, content)
-					if paramName != "" {
-						return paramName
-					}
-				}
-			}
-		}
-	}
-	return originalVariable // Return the original variable if no parameter name is found
+func GetParameterName(language string, functionNode *sitter.Node, content []byte, originalVariable string, callSite *sitter.Node) string {
+	return GetParameterNameFromContents(language, functionNode, content, originalVariable, callSite, content)
 }
 
 // -----------------------------------------------------------------------------
-// getParametersNode - Returns the node containing the parameters of a function definition.
+// GetParameterNameFromContents - Finds the name of the parameter corresponding to a variable in a function call
+// when the function declaration and the function call live in different files.
 // -----------------------------------------------------------------------------
 //
 // Parameters:
-//   - functionNode (*sitter.Node): The function node.
+//   - language (string): The language of the syntax tree.
+//   - functionNode (*sitter.Node): The function node containing the parameter.
+//   - functionContent ([]byte): The content of the file containing the function.
+//   - originalVariable (string): The original variable name.
+//   - callSite (*sitter.Node): The function call node.
+//   - callContent ([]byte): The content of the file containing the call.
 //
 // Returns:
-//   - (*sitter.Node): The parameters node if found, otherwise nil.
+//   - (string): The name of the parameter corresponding to the variable.
 //
 // -----------------------------------------------------------------------------
-func getParametersNode(functionNode *sitter.Node) *sitter.Node {
-	switch functionNode.Type() {
-	case "function_declaration", "method_declaration", "function_definition", "function_item", "method":
-		// Common field names for parameters
-		parameters := functionNode.ChildByFieldName("parameters")
-		if parameters != nil {
-			return parameters
+func GetParameterNameFromContents(language string, functionNode *sitter.Node, functionContent []byte, originalVariable string, callSite *sitter.Node, callContent []byte) string {
+	// Find the parameter receiving the variable, by position, by keyword or through a spread, the parameters receiving
+	// the whole argument (**kwargs for **opts) before the ones receiving one of its keys
+	bindings := bindArguments(language, functionNode, functionContent, callSite, callContent)
+	sort.SliceStable(bindings, func(i, j int) bool {
+		return bindings[i].argumentKey == "" && bindings[j].argumentKey != ""
+	})
+	for _, binding := range bindings {
+		if binding.argument == nil || !ArgumentRefersToVariable(language, binding.argument, callContent, originalVariable) {
+			continue
 		}
-		// For C and C++, parameters may be under nested 'declarator' fields
-		if functionNode.Type() == "function_definition" {
-			declarator := functionNode.ChildByFieldName("declarator")
-			parameters := findParametersInDeclarator(declarator)
-			if parameters != nil {
-				return parameters
+
+		// The fields of an object passed as a whole are the fields of the parameter (req.Path passed as req is r.Path in the function)
+		argPath := GetAccessPath(language, binding.argument, callContent)
+		if argPath != "" && binding.argumentKey != "" {
+			// A dictionary spread over the parameters passes each of them one of its keys (opts["path"] to path in f(**opts))
+			argPath += binding.argumentKey
+			if !AccessPathsOverlap(argPath, originalVariable) {
+				continue
 			}
 		}
+		return RebaseAccessPath(originalVariable, argPath, binding.parameter.name+binding.parameterKey)
 	}
-	return nil
-}
 
-// -----------------------------------------------------------------------------
+	// Without declared parameters, the argument may be read by position (e.g. $1 in a shell script)
+	if len(getParameterNodes(language, functionNode, functionContent)) == 0 {
+		for i, arg := range GetCallArguments(language, callSite, callContent) {
+			if !ArgumentRefersToVariable(language, arg, callContent, originalVariable) {
+				continue
+			}
+			if paramName := languageService.GetPositionalParameterName(language, functionNode, i); paramName != "" {
+				return RebaseAccessPath(originalVariable, GetAccessPath(language, arg, callContent), paramName)
+			}
+		}
+	}
+	return originalVariable // Return the original variable if no parameter name is found
+}
+
+// -----------------------------------------------------------------------------
+// GetCallee - Returns the node of the function called by a call, from the calls query of its language.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - callNode (*sitter.Node): The function call node.
+//   - content ([]byte): The content of the source code.
+//
+// Returns:
+//   - (*sitter.Node): The called function node if found, otherwise nil.
+//
+// -----------------------------------------------------------------------------
+func GetCallee(language string, callNode *sitter.Node, content []byte) *sitter.Node {
+	if captures, ok := languageService.MatchQuery(language, languageService.QueryCalls, callNode, content); ok && len(captures["callee"]) > 0 {
+		return captures["callee"][0]
+	}
+	return getFunctionNode(language, callNode)
+}
+
+// -----------------------------------------------------------------------------
+// GetCallTarget - Returns the code of a call before its arguments.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - callNode (*sitter.Node): The function call node.
+//   - content ([]byte): The content of the source code.
+//
+// Returns:
+//   - (string): The called function with its receiver (html.EscapeString in html.EscapeString(s), request.getParameter
+//     in request.getParameter("id")), without spaces.
+//
+// -----------------------------------------------------------------------------
+func GetCallTarget(language string, callNode *sitter.Node, content []byte) string {
+	var target string
+	if arguments := getArgumentsNode(language, callNode); arguments != nil && arguments.StartByte() > callNode.StartByte() {
+		target = string(content[callNode.StartByte():arguments.StartByte()])
+	} else if callee := GetCallee(language, callNode, content); callee != nil {
+		target = callee.Content(content)
+	}
+	return strings.Join(strings.Fields(target), "")
+}
+
+// -----------------------------------------------------------------------------
+// GetCallArguments - Returns the arguments of a call, from the calls query of its language.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - callNode (*sitter.Node): The function call node.
+//   - content ([]byte): The content of the source code.
+//
+// Returns:
+//   - ([]*sitter.Node): The argument nodes, in order.
+//
+// -----------------------------------------------------------------------------
+func GetCallArguments(language string, callNode *sitter.Node, content []byte) []*sitter.Node {
+	if captures, ok := languageService.MatchQuery(language, languageService.QueryCalls, callNode, content); ok && len(captures["arg"]) > 0 {
+		return captures["arg"]
+	}
+	return namedChildren(getArgumentsNode(language, callNode))
+}
+
+// -----------------------------------------------------------------------------
+// getParameterNodes - Returns the parameters of a function definition, from the parameters query of its language.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - functionNode (*sitter.Node): The function node.
+//   - content ([]byte): The content of the source code.
+//
+// Returns:
+//   - ([]*sitter.Node): The parameter nodes, in order.
+//
+// -----------------------------------------------------------------------------
+func getParameterNodes(language string, functionNode *sitter.Node, content []byte) []*sitter.Node {
+	if captures, ok := languageService.MatchQuery(language, languageService.QueryParameters, functionNode, content); ok && len(captures["parameter"]) > 0 {
+		return captures["parameter"]
+	}
+	return namedChildren(getParametersNode(language, functionNode))
+}
+
+// -----------------------------------------------------------------------------
+// namedChildren - Returns the named children of a node.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - node (*sitter.Node): The node, or nil.
+//
+// Returns:
+//   - ([]*sitter.Node): The named children of the node, nil if the node is nil.
+//
+// -----------------------------------------------------------------------------
+func namedChildren(node *sitter.Node) []*sitter.Node {
+	if node == nil {
+		return nil
+	}
+	var children []*sitter.Node
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		children = append(children, node.NamedChild(i))
+	}
+	return children
+}
+
+// -----------------------------------------------------------------------------
+// getParametersNode - Returns the node containing the parameters of a function definition.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - functionNode (*sitter.Node): The function node.
+//
+// Returns:
+//   - (*sitter.Node): The parameters node if found, otherwise nil.
+//
+// -----------------------------------------------------------------------------
+func getParametersNode(language string, functionNode *sitter.Node) *sitter.Node {
+	if parameters := languageService.FindParametersNode(language, functionNode); parameters != nil {
+		return parameters
+	}
+	if languageService.IsFunctionNode(language, functionNode) {
+		// Common field names for parameters
+		parameters := functionNode.ChildByFieldName("parameters")
+		if parameters != nil {
+			return parameters
+		}
+		// For C and C++, parameters may be under nested 'declarator' fields
+		if declarator := functionNode.ChildByFieldName("declarator"); declarator != nil {
+			parameters := findParametersInDeclarator(declarator)
+			if parameters != nil {
+				return parameters
+			}
+		}
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
 // getArgumentsNode - Returns the node containing the arguments of a function call.
 // -----------------------------------------------------------------------------
 //
 // Parameters:
+//   - language (string): The language of the syntax tree.
 //   - callSite (*sitter.Node): The function call node.
 //
 // Returns:
 //   - (*sitter.Node): The arguments node if found, otherwise nil.
 //
 // -----------------------------------------------------------------------------
-func getArgumentsNode(callSite *sitter.Node) *sitter.Node {
-	switch callSite.Type() {
-	case "call_expression", "method_invocation", "invocation_expression", "function_call_expression", "call", "macro_invocation":
+func getArgumentsNode(language string, callSite *sitter.Node) *sitter.Node {
+	if arguments := languageService.FindArgumentsNode(language, callSite); arguments != nil {
+		return arguments
+	}
+	if languageService.IsCallNode(language, callSite) {
 		// Common field names for arguments
 		arguments := callSite.ChildByFieldName("arguments")
 		if arguments != nil {
@@ -1126,6 +1366,7 @@ func findParametersInDeclarator(node *sitter.Node) *sitter.Node {
 // -----------------------------------------------------------------------------
 //
 // Parameters:
+//   - language (string): The language of the syntax tree.
 //   - param (*sitter.Node): The parameter node.
 //   - content ([]byte): The content of the source code.
 //
@@ -1133,12 +1374,12 @@ func findParametersInDeclarator(node *sitter.Node) *sitter.Node {
 //   - (string): The name of the parameter.
 //
 // -----------------------------------------------------------------------------
-func extractParameterName(param *sitter.Node, content []byte) string {
+func extractParameterName(language string, param *sitter.Node, content []byte) string {
 	if param == nil {
 		return ""
 	}
-	switch param.Type() {
-	case "parameter_declaration", "parameter", "simple_parameter", "formal_parameter":
+	switch {
+	case languageService.IsParameterNode(language, param):
 		// Try to get the name field
 		paramNameNode := param.ChildByFieldName("name")
 		if paramNameNode != nil {
@@ -1147,11 +1388,9 @@ func extractParameterName(param *sitter.Node, content []byte) string {
 		// For C and C++, parameter name may be under 'declarator'
 		declaratorNode := param.ChildByFieldName("declarator")
 		if declaratorNode != nil {
-			identifierNode := findIdentifierInDeclarator(declaratorNode)
+			identifierNode := findIdentifierInDeclarator(language, declaratorNode)
 			if identifierNode != nil {
-				paramName := SafeContent(identifierNode, content)
-				logger.PrintDebug("Extracted parameter name: %s", paramName)
-				return paramName
+				return SafeContent(identifierNode, content)
 			}
 		}
 		// For languages like Rust, the parameter pattern contains the name
@@ -1159,14 +1398,20 @@ func extractParameterName(param *sitter.Node, content []byte) string {
 		if patternNode != nil {
 			return SafeContent(patternNode, content)
 		}
-	case "identifier":
+		// For languages without fields like Kotlin, the name is the first identifier
+		for i := 0; i < int(param.NamedChildCount()); i++ {
+			if child := param.NamedChild(i); IsIdentifierNode(language, child) {
+				return SafeContent(child, content)
+			}
+		}
+	case languageService.IsSyntaxNode(language, param, languageService.SyntaxIdentifier):
 		// For languages where parameters are identifiers directly
 		return SafeContent(param, content)
 	default:
 		// Try to find an identifier among the children
 		for i := 0; i < int(param.NamedChildCount()); i++ {
 			child := param.NamedChild(i)
-			if child.Type() == "identifier" || child.Type() == "variable_name" || child.Type() == "name" {
+			if IsIdentifierNode(language, child) {
 				return SafeContent(child, content)
 			}
 		}
@@ -1179,6 +1424,7 @@ func extractParameterName(param *sitter.Node, content []byte) string {
 // -----------------------------------------------------------------------------
 //
 // Parameters:
+//   - language (string): The language of the syntax tree.
 //   - param (*sitter.Node): The parameter node.
 //   - content ([]byte): The content of the source code.
 //
@@ -1186,7 +1432,7 @@ func extractParameterName(param *sitter.Node, content []byte) string {
 //   - ([]string): A slice of parameter names.
 //
 // -----------------------------------------------------------------------------
-func extractParameterNames(param *sitter.Node, content []byte) []string {
+func extractParameterNames(language string, param *sitter.Node, content []byte) []string {
 	var names []string
 	seen := make(map[string]bool) // Map pour éviter les doublons
 
@@ -1194,11 +1440,11 @@ func extractParameterNames(param *sitter.Node, content []byte) []string {
 		return names
 	}
 
-	switch param.Type() {
-	case "parameter_declaration", "parameter", "simple_parameter", "formal_parameter":
+	switch {
+	case languageService.IsParameterNode(language, param):
 		for i := 0; i < int(param.NamedChildCount()); i++ {
 			child := param.NamedChild(i)
-			if child.Type() == "identifier" {
+			if IsIdentifierNode(language, child) {
 				paramName := SafeContent(child, content)
 				if !seen[paramName] { // Vérifier si le nom est déjà ajouté
 					names = append(names, paramName)
@@ -1218,7 +1464,7 @@ func extractParameterNames(param *sitter.Node, content []byte) []string {
 
 		declaratorNode := param.ChildByFieldName("declarator")
 		if declaratorNode != nil {
-			identifierNode := findIdentifierInDeclarator(declaratorNode)
+			identifierNode := findIdentifierInDeclarator(language, declaratorNode)
 			if identifierNode != nil {
 				paramName := SafeContent(identifierNode, content)
 				if !seen[paramName] {
@@ -1236,7 +1482,7 @@ func extractParameterNames(param *sitter.Node, content []byte) []string {
 				seen[paramName] = true
 			}
 		}
-	case "identifier":
+	case languageService.IsSyntaxNode(language, param, languageService.SyntaxIdentifier):
 		paramName := SafeContent(param, content)
 		if !seen[paramName] {
 			names = append(names, paramName)
@@ -1245,7 +1491,7 @@ func extractParameterNames(param *sitter.Node, content []byte) []string {
 	default:
 		for i := 0; i < int(param.NamedChildCount()); i++ {
 			child := param.NamedChild(i)
-			if child.Type() == "identifier" || child.Type() == "variable_name" || child.Type() == "name" {
+			if IsIdentifierNode(language, child) {
 				paramName := SafeContent(child, content)
 				if !seen[paramName] {
 					names = append(names, paramName)
@@ -1254,400 +1500,2890 @@ func extractParameterNames(param *sitter.Node, content []byte) []string {
 			}
 		}
 	}
-
-	return names
+
+	return names
+}
+
+/**** Method Resolution Functions ****/
+
+// Names of the current object in a method (self.read, this.read, $this->read, Self::new)
+var selfReceiverNames = []string{"self", "this", "$this", "Self", "cls"}
+
+// Declared types leaving the type to the value (var a = new Store())
+var inferredTypeNames = []string{"var", "auto", "dynamic"}
+
+// -----------------------------------------------------------------------------
+// FindFunctionDeclarations - Finds all the declarations of a function or a method by its name in the syntax tree.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - root (*sitter.Node): The root node of the syntax tree.
+//   - functionName (string): The name of the function to find, without its receiver.
+//   - content ([]byte): The content of the source code.
+//
+// Returns:
+//   - ([]*sitter.Node): The function declarations of that name, in source order (Open of each type declaring one).
+//
+// -----------------------------------------------------------------------------
+func FindFunctionDeclarations(language string, root *sitter.Node, functionName string, content []byte) []*sitter.Node {
+	var functions []*sitter.Node
+	var traverse func(node *sitter.Node)
+	traverse = func(node *sitter.Node) {
+		if node == nil {
+			return
+		}
+		if languageService.IsFunctionNode(language, node) {
+			funcNameNode := getFunctionNameNode(language, node, content)
+			if funcNameNode != nil && SafeContent(funcNameNode, content) == functionName {
+				functions = append(functions, node)
+			}
+		}
+		for i := 0; i < int(node.NamedChildCount()); i++ {
+			traverse(node.NamedChild(i))
+		}
+	}
+	traverse(root)
+	return functions
+}
+
+// -----------------------------------------------------------------------------
+// GetCalledFunctionName - Returns the name of the function or the method called, without its receiver.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - callNode (*sitter.Node): The function call node.
+//   - content ([]byte): The content of the source code.
+//
+// Returns:
+//   - (string): The name of the called function (Open in store.Open(path), new in Store::new()).
+//
+// -----------------------------------------------------------------------------
+func GetCalledFunctionName(language string, callNode *sitter.Node, content []byte) string {
+	return extractFunctionName(language, GetCallee(language, callNode, content), content)
+}
+
+// -----------------------------------------------------------------------------
+// GetMethodOwner - Returns the type a method is declared for.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - functionNode (*sitter.Node): The function declaration node.
+//   - content ([]byte): The content of the source code.
+//
+// Returns:
+//   - (string): The type of the Go receiver, the class, module or struct of the impl block declaring the method, or an
+//     empty string for a function declared outside of them.
+//
+// -----------------------------------------------------------------------------
+func GetMethodOwner(language string, functionNode *sitter.Node, content []byte) string {
+	if functionNode == nil {
+		return ""
+	}
+
+	// Go declares the type of a method in its receiver
+	if receiver := functionNode.ChildByFieldName("receiver"); receiver != nil {
+		for _, param := range namedChildren(receiver) {
+			if typeNode := param.ChildByFieldName("type"); typeNode != nil {
+				return typeName(SafeContent(typeNode, content))
+			}
+		}
+		return ""
+	}
+
+	ownerNode := getMethodOwnerNode(language, functionNode)
+	if ownerNode == nil {
+		return ""
+	}
+	// Rust impl blocks name the type of their methods in a type field
+	if typeNode := ownerNode.ChildByFieldName("type"); typeNode != nil {
+		return typeName(SafeContent(typeNode, content))
+	}
+	if nameNode := ownerNode.ChildByFieldName("name"); nameNode != nil {
+		return typeName(SafeContent(nameNode, content))
+	}
+	// Kotlin names its classes without a field
+	for _, child := range namedChildren(ownerNode) {
+		if languageService.IsSyntaxNode(language, child, languageService.SyntaxTypeName) {
+			return SafeContent(child, content)
+		}
+	}
+	return ""
+}
+
+// -----------------------------------------------------------------------------
+// getMethodOwnerNode - Returns the class, module or impl block declaring a method.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - functionNode (*sitter.Node): The function declaration node.
+//
+// Returns:
+//   - (*sitter.Node): The closest declaration of a type around the function, or nil if the function is not a method
+//     (declared at the top level or inside another function).
+//
+// -----------------------------------------------------------------------------
+func getMethodOwnerNode(language string, functionNode *sitter.Node) *sitter.Node {
+	for node := functionNode.Parent(); node != nil; node = node.Parent() {
+		if languageService.IsSyntaxNode(language, node, languageService.SyntaxMethodOwner) {
+			return node
+		}
+		if languageService.IsFunctionNode(language, node) {
+			return nil
+		}
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// FindEnclosingFunctionNode - Returns the function declaration containing a node.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): The node inside the function.
+//
+// Returns:
+//   - (*sitter.Node): The closest function declaration around the node, or nil at the top level.
+//
+// -----------------------------------------------------------------------------
+func FindEnclosingFunctionNode(language string, node *sitter.Node) *sitter.Node {
+	for current := node.Parent(); current != nil; current = current.Parent() {
+		if languageService.IsFunctionNode(language, current) {
+			return current
+		}
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// GetEnclosingMethodOwner - Returns the type the method containing a node is declared for.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): The node inside the method (a call without receiver).
+//   - content ([]byte): The content of the source code.
+//
+// Returns:
+//   - (string): The type of the enclosing method, or an empty string outside of a method.
+//
+// -----------------------------------------------------------------------------
+func GetEnclosingMethodOwner(language string, node *sitter.Node, content []byte) string {
+	return GetMethodOwner(language, FindEnclosingFunctionNode(language, node), content)
+}
+
+// -----------------------------------------------------------------------------
+// InferReceiverType - Infers the type of the object a method is called on.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - callNode (*sitter.Node): The function call node.
+//   - content ([]byte): The content of the source code.
+//
+// Returns:
+//   - (string): The type of the receiver: the type of the enclosing method for self and this, the type declared or
+//     instantiated for a variable, or the name of the receiver itself for a call on a type or a module (Store.open,
+//     Store::new, os.Open). Empty if the type cannot be inferred.
+//   - (bool): True if the call has a receiver, false for a plain function call.
+//
+// -----------------------------------------------------------------------------
+func InferReceiverType(language string, callNode *sitter.Node, content []byte) (string, bool) {
+	receiver := getCallReceiver(language, callNode, content)
+	if receiver == nil {
+		return "", false
+	}
+
+	function := FindEnclosingFunctionNode(language, callNode)
+	switch {
+	case isSelfReceiver(receiver, content):
+		return GetMethodOwner(language, function, content), true
+
+	case IsIdentifierNode(language, receiver) || languageService.IsSyntaxNode(language, receiver, languageService.SyntaxConstant) || languageService.IsSyntaxNode(language, receiver, languageService.SyntaxInstanceField):
+		name := SafeContent(receiver, content)
+		if languageService.IsSyntaxNode(language, receiver, languageService.SyntaxInstanceField) {
+			// Ruby instance variables are assigned in any method of the class
+			if ownerNode := getMethodOwnerNode(language, function); ownerNode != nil {
+				declaredType, _ := findDeclaredType(language, ownerNode, name, nil, content)
+				return declaredType, true
+			}
+			return "", true
+		}
+		if function != nil {
+			if declaredType, declared := findDeclaredType(language, function, name, callNode, content); declared {
+				return declaredType, true
+			}
+		}
+		root := findRootNode(callNode)
+		if declaredType, declared := findDeclaredType(language, root, name, callNode, content); declared {
+			return declaredType, true
+		}
+		// A receiver without a declaration names a type or an imported module (Store.open(path), os.Open(path))
+		if isTypeOrModuleName(language, root, name, content) {
+			return name, true
+		}
+		return "", true
+
+	default:
+		// The fields of the current object are declared in its class (self.store, this.store)
+		if object := getAccessObject(language, receiver); object != nil && isSelfReceiver(object, content) {
+			if ownerNode := getMethodOwnerNode(language, function); ownerNode != nil {
+				declaredType, _ := findDeclaredType(language, ownerNode, SafeContent(getAccessMember(language, receiver), content), nil, content)
+				return declaredType, true
+			}
+			return "", true
+		}
+		return getValueType(language, receiver, content), true
+	}
+}
+
+// -----------------------------------------------------------------------------
+// getCallReceiver - Returns the object or the type a method is called on.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - callNode (*sitter.Node): The function call node.
+//   - content ([]byte): The content of the source code.
+//
+// Returns:
+//   - (*sitter.Node): The receiver (store in store.Open(path), Store in Store::new()), or nil for a plain function call.
+//
+// -----------------------------------------------------------------------------
+func getCallReceiver(language string, callNode *sitter.Node, content []byte) *sitter.Node {
+	callee := GetCallee(language, callNode, content)
+	if callee != nil {
+		// Kotlin names the method in the last suffix of a navigation expression
+		if parent := callee.Parent(); parent != nil && languageService.IsSyntaxNode(language, parent, languageService.SyntaxMemberSuffix) && parent.Parent() != nil {
+			return parent.Parent().NamedChild(0)
+		}
+		if object := getAccessObject(language, callee); object != nil {
+			return object
+		}
+		if languageService.IsSyntaxNode(language, callee, languageService.SyntaxScopedName) {
+			return callee.ChildByFieldName("path")
+		}
+		// C# accesses the members of the current object through an anonymous this
+		if callee.ChildCount() > 1 && languageService.IsSyntaxNode(language, callee.Child(0), languageService.SyntaxAccessBase) {
+			return callee.Child(0)
+		}
+	}
+
+	// Java and Ruby calls hold their receiver
+	for _, field := range []string{"object", "receiver", "scope"} {
+		if object := callNode.ChildByFieldName(field); object != nil {
+			return object
+		}
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// getAccessMember - Returns the member name of a member access.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): The member access node.
+//
+// Returns:
+//   - (*sitter.Node): The member accessed (store in self.store), or nil if the node is not a member access.
+//
+// -----------------------------------------------------------------------------
+func getAccessMember(language string, node *sitter.Node) *sitter.Node {
+	for _, field := range languageService.GetFieldNames(language, languageService.FieldMember) {
+		if member := node.ChildByFieldName(field); member != nil {
+			return member
+		}
+	}
+	// Kotlin names the member in the suffix of the object
+	if suffix := getMemberSuffix(language, node); suffix != nil {
+		return suffix.NamedChild(0)
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// getMemberSuffix - Returns the suffix naming the member of an access without fields.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): The member access node.
+//
+// Returns:
+//   - (*sitter.Node): The last suffix of the access (.exists in file.exists), or nil if it does not name a member.
+//
+// -----------------------------------------------------------------------------
+func getMemberSuffix(language string, node *sitter.Node) *sitter.Node {
+	if !languageService.IsSyntaxNode(language, node, languageService.SyntaxSuffixedAccess) || node.NamedChildCount() < 2 {
+		return nil
+	}
+	if suffix := node.NamedChild(int(node.NamedChildCount()) - 1); languageService.IsSyntaxNode(language, suffix, languageService.SyntaxMemberSuffix) {
+		return suffix
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// isSelfReceiver - Checks if a node names the current object of a method.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - node (*sitter.Node): The receiver node.
+//   - content ([]byte): The content of the source code.
+//
+// Returns:
+//   - (bool): True for self, this, $this and Self, this@Store included.
+//
+// -----------------------------------------------------------------------------
+func isSelfReceiver(node *sitter.Node, content []byte) bool {
+	name := SafeContent(node, content)
+	// Kotlin labels the object of an outer class (this@Store)
+	if label := strings.Index(name, "@"); label > 0 {
+		name = name[:label]
+	}
+	return utilityService.ContainsString(selfReceiverNames, name)
+}
+
+// -----------------------------------------------------------------------------
+// findDeclaredType - Finds the type of the last declaration or assignment of a variable in a node.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - scope (*sitter.Node): The function, the class or the file declaring the variable.
+//   - name (string): The name of the variable, or of the field of the class.
+//   - before (*sitter.Node): The call using the variable, the declarations after it being ignored (nil to read the
+//     whole class).
+//   - content ([]byte): The content of the source code.
+//
+// Returns:
+//   - (string): The type declared for the variable or instantiated in its value, empty if it is not known.
+//   - (bool): True if the variable is declared or assigned in the node, even without a known type.
+//
+// -----------------------------------------------------------------------------
+func findDeclaredType(language string, scope *sitter.Node, name string, before *sitter.Node, content []byte) (string, bool) {
+	declaredType, declared := "", false
+	var traverse func(node *sitter.Node)
+	traverse = func(node *sitter.Node) {
+		if before != nil && node.StartByte() >= before.StartByte() {
+			return
+		}
+		// The top level of a file only holds the variables declared outside of the functions
+		if before != nil && scope.Parent() == nil && languageService.IsFunctionNode(language, node) {
+			return
+		}
+		if (IsIdentifierNode(language, node) || languageService.IsSyntaxNode(language, node, languageService.SyntaxInstanceField)) && SafeContent(node, content) == name {
+			if occurrenceType, isDeclaration := getDeclaredType(language, node, content); isDeclaration {
+				declaredType, declared = occurrenceType, true
+			}
+		}
+		for i := 0; i < int(node.NamedChildCount()); i++ {
+			traverse(node.NamedChild(i))
+		}
+	}
+	traverse(scope)
+	return declaredType, declared
+}
+
+// -----------------------------------------------------------------------------
+// getDeclaredType - Returns the type given to a variable by the declaration or the assignment naming it.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - occurrence (*sitter.Node): The identifier naming the variable.
+//   - content ([]byte): The content of the source code.
+//
+// Returns:
+//   - (string): The type instantiated in the value assigned (Store in s := &Store{}) or else the declared type (Store in
+//     var s *Store, s: &Store, Store s), empty if it is not known.
+//   - (bool): True if the occurrence declares or assigns the variable (a parameter, a declaration, an assignment).
+//
+// -----------------------------------------------------------------------------
+func getDeclaredType(language string, occurrence *sitter.Node, content []byte) (string, bool) {
+	declared := false
+	node := occurrence
+	for depth := 0; depth < 3 && node.Parent() != nil; depth++ {
+		parent := node.Parent()
+		if languageService.IsFunctionNode(language, parent) || languageService.IsCallNode(language, parent) || getAccessObject(language, parent) != nil && getAccessObject(language, parent).Equal(node) {
+			// The variable is read (store.Open, f(store)), not declared
+			break
+		}
+		if strings.Contains(parent.Type(), "parameter") {
+			declared = true
+		}
+
+		if value := getDeclaredValue(language, parent, node, occurrence); value != nil {
+			declared = true
+			if valueType := getValueType(language, value, content); valueType != "" {
+				return valueType, true
+			}
+		}
+		if typeNode := getDeclaredTypeNode(language, parent, occurrence); typeNode != nil {
+			declared = true
+			if declaredType := typeName(SafeContent(typeNode, content)); declaredType != "" && !utilityService.ContainsString(inferredTypeNames, declaredType) {
+				return declaredType, true
+			}
+		}
+		node = parent
+	}
+	return "", declared
+}
+
+// -----------------------------------------------------------------------------
+// isTypeOrModuleName - Checks if a name used without declaration names a type or an imported module.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - root (*sitter.Node): The root node of the syntax tree.
+//   - name (string): The name of the receiver.
+//   - content ([]byte): The content of the source code.
+//
+// Returns:
+//   - (bool): True if the name is capitalized like the types (Store, File) or imported by the file (os in import "os",
+//     json in import json).
+//
+// -----------------------------------------------------------------------------
+func isTypeOrModuleName(language string, root *sitter.Node, name string, content []byte) bool {
+	return isTypeName(name) || isModuleName(language, root, name, content)
+}
+
+// -----------------------------------------------------------------------------
+// isModuleName - Checks if a name is imported by a file.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - root (*sitter.Node): The root node of the syntax tree.
+//   - name (string): The name to check.
+//   - content ([]byte): The content of the source code.
+//
+// Returns:
+//   - (bool): True if an import of the file names it (os in import "os", json in import json, fs in
+//     const fs = require('fs')).
+//
+// -----------------------------------------------------------------------------
+func isModuleName(language string, root *sitter.Node, name string, content []byte) bool {
+	imported := false
+	var traverse func(node *sitter.Node, inImport bool)
+	traverse = func(node *sitter.Node, inImport bool) {
+		if imported {
+			return
+		}
+		inImport = inImport || languageService.IsSyntaxNode(language, node, languageService.SyntaxImport)
+
+		// CommonJS modules are imported by the value of a variable
+		if languageService.IsCallNode(language, node) && GetCalledFunctionName(language, node, content) == "require" && FindAssignedVariable(language, node, content) == name {
+			imported = true
+			return
+		}
+		if inImport && node.NamedChildCount() == 0 {
+			// Go imports name the package by the last element of its path
+			text := strings.Trim(SafeContent(node, content), "\"'`")
+			if text == name || strings.HasSuffix(text, "/"+name) {
+				imported = true
+				return
+			}
+		}
+		for i := 0; i < int(node.NamedChildCount()); i++ {
+			traverse(node.NamedChild(i), inImport)
+		}
+	}
+	traverse(root, false)
+	return imported
+}
+
+// -----------------------------------------------------------------------------
+// getDeclaredValue - Returns the value a declaration or an assignment gives to a variable.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - parent (*sitter.Node): The declaration or assignment node.
+//   - target (*sitter.Node): The child of the declaration holding the variable (a, or the list a, b).
+//   - occurrence (*sitter.Node): The identifier naming the variable.
+//
+// Returns:
+//   - (*sitter.Node): The value after the variable, the value at the same position for several targets (b in a, b :=
+//     x, y), or nil.
+//
+// -----------------------------------------------------------------------------
+func getDeclaredValue(language string, parent, target, occurrence *sitter.Node) *sitter.Node {
+	var value *sitter.Node
+	for _, field := range []string{"value", "right", "init"} {
+		if candidate := parent.ChildByFieldName(field); candidate != nil && candidate.StartByte() >= occurrence.EndByte() {
+			value = candidate
+			break
+		}
+	}
+	// C# declarators and Kotlin properties end with their value without a field
+	if value == nil && languageService.IsSyntaxNode(language, parent, languageService.SyntaxTrailingValue) && parent.NamedChildCount() > 1 {
+		last := parent.NamedChild(int(parent.NamedChildCount()) - 1)
+		if last.StartByte() >= occurrence.EndByte() && !languageService.IsSyntaxNode(language, last, languageService.SyntaxDeclaredType) {
+			value = last
+		}
+	}
+	if value == nil {
+		return nil
+	}
+
+	// Each target of a multiple assignment receives the value at its position
+	targets := namedChildren(target)
+	if languageService.IsSyntaxNode(language, target, languageService.SyntaxPositionalPattern) && languageService.IsSyntaxNode(language, value, languageService.SyntaxPositionalValue) && int(value.NamedChildCount()) == len(targets) {
+		for i, element := range targets {
+			if element.StartByte() <= occurrence.StartByte() && occurrence.EndByte() <= element.EndByte() {
+				return value.NamedChild(i)
+			}
+		}
+	}
+	return value
+}
+
+// -----------------------------------------------------------------------------
+// getDeclaredTypeNode - Returns the type of a declaration.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - parent (*sitter.Node): The declaration or parameter node.
+//   - occurrence (*sitter.Node): The identifier naming the variable, which is not part of the type.
+//
+// Returns:
+//   - (*sitter.Node): The type node, or nil if the declaration has no type.
+//
+// -----------------------------------------------------------------------------
+func getDeclaredTypeNode(language string, parent, occurrence *sitter.Node) *sitter.Node {
+	contains := func(node *sitter.Node) bool {
+		return node.StartByte() <= occurrence.StartByte() && occurrence.EndByte() <= node.EndByte()
+	}
+	if typeNode := parent.ChildByFieldName("type"); typeNode != nil && !contains(typeNode) {
+		return typeNode
+	}
+	for _, child := range namedChildren(parent) {
+		if languageService.IsSyntaxNode(language, child, languageService.SyntaxDeclaredType) && !contains(child) {
+			return child
+		}
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// getValueType - Returns the type of the instance created by an expression.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - value (*sitter.Node): The expression.
+//   - content ([]byte): The content of the source code.
+//
+// Returns:
+//   - (string): The type instantiated (Store in &Store{}, new Store(), Store(), Store.new, Store::new()), or an
+//     empty string for another expression.
+//
+// -----------------------------------------------------------------------------
+func getValueType(language string, value *sitter.Node, content []byte) string {
+	for value != nil && languageService.IsSyntaxNode(language, value, languageService.SyntaxTypeWrapper) {
+		inner := value.ChildByFieldName("operand")
+		if inner == nil {
+			inner = value.ChildByFieldName("value")
+		}
+		if inner == nil {
+			inner = value.NamedChild(0)
+		}
+		value = inner
+	}
+	if value == nil {
+		return ""
+	}
+
+	switch {
+	case languageService.IsSyntaxNode(language, value, languageService.SyntaxInstantiation):
+		for _, field := range []string{"type", "constructor", "name"} {
+			if typeNode := value.ChildByFieldName(field); typeNode != nil {
+				return typeName(SafeContent(typeNode, content))
+			}
+		}
+	case languageService.IsCallNode(language, value):
+		callee := GetCallee(language, value, content)
+		if callee == nil {
+			return ""
+		}
+		// Constructors called on their type (Store.new, Store::new(), Store::open(path))
+		if name := GetCalledFunctionName(language, value, content); name == "new" || languageService.IsSyntaxNode(language, callee, languageService.SyntaxScopedName) {
+			if receiver := getCallReceiver(language, value, content); receiver != nil {
+				return typeName(SafeContent(receiver, content))
+			}
+		}
+		// Classes called as functions (Store() in Python and Kotlin), capitalized unlike the functions declared in the file
+		if name := SafeContent(callee, content); IsIdentifierNode(language, callee) && isTypeName(name) && !languageService.IsSyntaxNode(language, callee.Parent(), languageService.SyntaxMemberSuffix) {
+			if root := findRootNode(value); !IsFunctionDeclared(language, root, name, content) {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// -----------------------------------------------------------------------------
+// isTypeName - Checks if a name is capitalized like the names of the types.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - name (string): The name to check.
+//
+// Returns:
+//   - (bool): True if the name starts with an uppercase letter (Store, File).
+//
+// -----------------------------------------------------------------------------
+func isTypeName(name string) bool {
+	return name != "" && strings.ToUpper(name[:1]) == name[:1] && strings.ToLower(name[:1]) != name[:1]
+}
+
+// -----------------------------------------------------------------------------
+// findRootNode - Returns the root node of the syntax tree of a node.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - node (*sitter.Node): The node.
+//
+// Returns:
+//   - (*sitter.Node): The root node of its syntax tree.
+//
+// -----------------------------------------------------------------------------
+func findRootNode(node *sitter.Node) *sitter.Node {
+	for node.Parent() != nil {
+		node = node.Parent()
+	}
+	return node
+}
+
+// -----------------------------------------------------------------------------
+// typeName - Returns the name of a type, without its pointer, reference, generic arguments and package.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - text (string): The text of the type (*store.Store, &mut Store, List<String>, : Store).
+//
+// Returns:
+//   - (string): The name of the type (Store, List).
+//
+// -----------------------------------------------------------------------------
+func typeName(text string) string {
+	text = strings.TrimSpace(strings.TrimLeft(text, ":*&? \t"))
+	text = strings.TrimLeft(strings.TrimPrefix(text, "mut "), "*& ")
+	if index := strings.IndexAny(text, "<[({ \t?"); index >= 0 {
+		text = text[:index]
+	}
+	for _, separator := range []string{"::", ".", "\\"} {
+		if index := strings.LastIndex(text, separator); index >= 0 {
+			text = text[index+len(separator):]
+		}
+	}
+	return text
+}
+
+// -----------------------------------------------------------------------------
+// AcceptsArguments - Checks if a function can receive the arguments of a call.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - functionNode (*sitter.Node): The function declaration node.
+//   - functionContent ([]byte): The content of the file containing the function.
+//   - callNode (*sitter.Node): The function call node.
+//   - callContent ([]byte): The content of the file containing the call.
+//
+// Returns:
+//   - (bool): True if the call passes at least the parameters without a default value and no more arguments than the
+//     function has parameters, always true when the function or the call spreads its arguments.
+//
+// -----------------------------------------------------------------------------
+func AcceptsArguments(language string, functionNode *sitter.Node, functionContent []byte, callNode *sitter.Node, callContent []byte) bool {
+	parameters := getCallParameters(language, functionNode, functionContent)
+	if bindsReceiver(language, functionNode, functionContent, callNode, callContent) && len(parameters) > 0 {
+		parameters = parameters[1:]
+	}
+
+	required, accepted := 0, 0
+	for _, parameter := range parameters {
+		if parameter.variadic || parameter.keywords {
+			return true
+		}
+		accepted++
+		if parameter.defaultValue == nil && !parameter.keywordOnly {
+			required++
+		}
+	}
+
+	arguments := 0
+	for _, arg := range GetCallArguments(language, callNode, callContent) {
+		if argument := describeArgument(language, arg, callContent); argument.spread || argument.keywordSpread {
+			return true
+		}
+		arguments++
+	}
+	return required <= arguments && arguments <= accepted
+}
+
+// -----------------------------------------------------------------------------
+// bindsReceiver - Checks if the first parameter of a method receives the object it is called on.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - functionNode (*sitter.Node): The function declaration node.
+//   - functionContent ([]byte): The content of the file containing the function.
+//   - callNode (*sitter.Node): The function call node.
+//   - callContent ([]byte): The content of the file containing the call.
+//
+// Returns:
+//   - (bool): True for a Python method called on an instance (self receives store in store.read(path)), false for a
+//     static method, a function or a method called on its class.
+//
+// -----------------------------------------------------------------------------
+func bindsReceiver(language string, functionNode *sitter.Node, functionContent []byte, callNode *sitter.Node, callContent []byte) bool {
+	ownerNode := getMethodOwnerNode(language, functionNode)
+	if ownerNode == nil || !languageService.IsSyntaxNode(language, ownerNode, languageService.SyntaxInstanceParameter) {
+		return false
+	}
+	receiver := getCallReceiver(language, callNode, callContent)
+	if receiver == nil {
+		return false
+	}
+
+	decorators := ""
+	if parent := functionNode.Parent(); parent != nil && languageService.IsSyntaxNode(language, parent, languageService.SyntaxDecorated) {
+		decorators = SafeContent(parent, functionContent)[:functionNode.StartByte()-parent.StartByte()]
+	}
+	switch {
+	case strings.Contains(decorators, "@staticmethod"):
+		return false
+	case strings.Contains(decorators, "@classmethod"):
+		return true
+	default:
+		// A method called on its class receives the instance as its first argument (Store.read(store, path))
+		return SafeContent(receiver, callContent) != GetMethodOwner(language, functionNode, functionContent)
+	}
+}
+
+/**** Argument Binding Functions ****/
+
+// callParameter - A parameter of a function definition, with the way a call passes it a value
+type callParameter struct {
+	name         string       // Name of the parameter
+	defaultValue *sitter.Node // Value of the parameter when a call leaves it out (1 in b=1)
+	variadic     bool         // Receives the positional arguments left (*args, ...rest, xs ...string, params string[] rest)
+	keywords     bool         // Receives the keyword arguments left (**kwargs, Ruby **opts)
+	keywordOnly  bool         // Only passed by keyword (Python parameters after *args, Ruby name:)
+}
+
+// callArgument - An argument of a call, with the way it passes its value
+type callArgument struct {
+	value         *sitter.Node // Expression passed
+	keyword       string       // Name of the parameter of a keyword argument (path in f(path=p))
+	spread        bool         // Spreads a list over the positional parameters (*xs, ...xs, xs...)
+	keywordSpread bool         // Spreads a dictionary over the named parameters (**opts)
+}
+
+// argumentBinding - An argument passed to a parameter, or the default value of a parameter a call leaves out
+type argumentBinding struct {
+	parameter    callParameter
+	argument     *sitter.Node // Expression passed, nil when the parameter takes its default value
+	parameterKey string       // Key of the parameter receiving the argument (["path"] for kwargs in f(path=p))
+	argumentKey  string       // Key of the argument passed to the parameter (["path"] for opts in f(**opts))
+}
+
+// -----------------------------------------------------------------------------
+// bindArguments - Pairs the arguments of a call with the parameters of the called function.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - functionNode (*sitter.Node): The function declaration node.
+//   - functionContent ([]byte): The content of the file containing the function.
+//   - callNode (*sitter.Node): The function call node.
+//   - callContent ([]byte): The content of the file containing the call.
+//
+// Returns:
+//   - ([]argumentBinding): The arguments passed to each parameter, by keyword first, then by position and through
+//     the spread lists and dictionaries, followed by the parameters left to their default value.
+//
+// -----------------------------------------------------------------------------
+func bindArguments(language string, functionNode *sitter.Node, functionContent []byte, callNode *sitter.Node, callContent []byte) []argumentBinding {
+	parameters := getCallParameters(language, functionNode, functionContent)
+	if bindsReceiver(language, functionNode, functionContent, callNode, callContent) && len(parameters) > 0 {
+		// The first parameter of a method receives the object it is called on, not an argument
+		parameters = parameters[1:]
+	}
+
+	// Positional arguments go to the parameters before the keyword-only ones, the variadic one receiving the arguments left
+	var positional []callParameter
+	var keywords *callParameter
+	for i, parameter := range parameters {
+		switch {
+		case parameter.keywords:
+			keywords = &parameters[i]
+		case !parameter.keywordOnly:
+			positional = append(positional, parameter)
+		}
+	}
+
+	var bindings []argumentBinding
+	bound := make(map[string]bool)
+	bind := func(parameter callParameter, argument *sitter.Node, parameterKey, argumentKey string) {
+		bindings = append(bindings, argumentBinding{parameter: parameter, argument: argument, parameterKey: parameterKey, argumentKey: argumentKey})
+		bound[parameter.name] = true
+	}
+
+	// The elements of a spread list are positional arguments (f(*[a, b]) is f(a, b), f(*xs) passes xs to as many parameters)
+	var arguments []callArgument
+	var argumentNodes []*sitter.Node
+	for _, arg := range GetCallArguments(language, callNode, callContent) {
+		argument := describeArgument(language, arg, callContent)
+		if literal := resolveLiteralValue(language, argument.value, callContent); argument.spread && literal != nil && languageService.IsSyntaxNode(language, literal, languageService.SyntaxPositionalValue) {
+			for _, element := range getPositionalElements(language, literal) {
+				if element == nil {
+					continue
+				}
+				if literal != argument.value {
+					element = argument.value
+				}
+				arguments = append(arguments, callArgument{value: element})
+				argumentNodes = append(argumentNodes, element)
+			}
+			continue
+		}
+		arguments = append(arguments, argument)
+		argumentNodes = append(argumentNodes, arg)
+	}
+
+	// The keyword arguments name their parameter, whatever their position
+	inHash := make(map[int]bool)
+	for i, argument := range arguments {
+		if argument.keyword == "" {
+			continue
+		}
+		if parameter, ok := findKeywordParameter(parameters, argument.keyword); ok {
+			bind(parameter, argument.value, "", "")
+		} else if keywords != nil {
+			bind(*keywords, argument.value, formatKeySegment(argument.keyword), "")
+		} else if languageService.IsSyntaxNode(language, argumentNodes[i], languageService.SyntaxDictionaryEntry) {
+			// Ruby passes the keywords of a method without keyword parameters in a hash, at their position
+			inHash[i] = true
+		}
+	}
+
+	// The positional arguments go to the positional parameters left, in order
+	position := 0
+	nextPositional := func() {
+		for position < len(positional) && bound[positional[position].name] && !positional[position].variadic {
+			position++
+		}
+	}
+	for i, argument := range arguments {
+		nextPositional()
+		switch {
+		case (argument.keyword != "" && !inHash[i]) || argument.keywordSpread:
+			continue
+
+		case argument.spread:
+			// The elements of an unknown list may go to any of the positional parameters left
+			for ; position < len(positional); position++ {
+				if !bound[positional[position].name] || positional[position].variadic {
+					bind(positional[position], argument.value, "", "")
+				}
+			}
+			if len(positional) > 0 && positional[len(positional)-1].variadic {
+				position = len(positional) - 1
+			}
+
+		case inHash[i] && position < len(positional):
+			// The pairs of the hash all go to the same parameter
+			bind(positional[position], argument.value, formatKeySegment(argument.keyword), "")
+
+		case position < len(positional):
+			bind(positional[position], argument.value, "", "")
+			if !positional[position].variadic {
+				position++
+			}
+		}
+	}
+
+	// A spread dictionary passes its keys to the named parameters left, and the other keys to the keywords parameter
+	for i, argument := range arguments {
+		if !argument.keywordSpread {
+			continue
+		}
+		rubyHash := languageService.IsSyntaxNode(language, argumentNodes[i], languageService.SyntaxKeywordHashArgument)
+		literal := resolveLiteralValue(language, argument.value, callContent)
+		entries, known := getDictionaryEntries(language, literal, callContent)
+		bindEntry := func(parameter callParameter, entry dictionaryEntry, parameterKey string) {
+			if literal == argument.value {
+				bind(parameter, entry.value, parameterKey, "")
+			} else {
+				// The key of the variable holding the dictionary (opts["path"] for path in f(**opts))
+				bind(parameter, argument.value, parameterKey, formatKeySegment(entry.key))
+			}
+		}
+		for _, parameter := range parameters {
+			switch {
+			case parameter.variadic || bound[parameter.name] || (rubyHash && !parameter.keywordOnly && !parameter.keywords):
+			case parameter.keywords && !known:
+				bind(parameter, argument.value, "", "")
+			case parameter.keywords:
+				for _, entry := range entries {
+					if _, ok := findKeywordParameter(parameters, entry.key); !ok {
+						bindEntry(parameter, entry, formatKeySegment(entry.key))
+					}
+				}
+			case !known:
+				// Without the content of the dictionary, each of its keys may be the parameter
+				bind(parameter, argument.value, "", formatKeySegment(strings.TrimPrefix(parameter.name, "$")))
+			default:
+				for _, entry := range entries {
+					if entry.key == strings.TrimPrefix(parameter.name, "$") {
+						bindEntry(parameter, entry, "")
+					}
+				}
+			}
+		}
+	}
+
+	// The parameters left out take their default value
+	for _, parameter := range parameters {
+		if !bound[parameter.name] && parameter.defaultValue != nil {
+			bindings = append(bindings, argumentBinding{parameter: parameter})
+		}
+	}
+	return bindings
+}
+
+// -----------------------------------------------------------------------------
+// getCallParameters - Returns the parameters of a function definition with the way a call passes them a value.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - functionNode (*sitter.Node): The function declaration node.
+//   - content ([]byte): The content of the file containing the function.
+//
+// Returns:
+//   - ([]callParameter): The parameters, in order, one per name (a and b for a, b string).
+//
+// -----------------------------------------------------------------------------
+func getCallParameters(language string, functionNode *sitter.Node, content []byte) []callParameter {
+	var parameters []callParameter
+	keywordOnly := false
+	for _, param := range getParameterNodes(language, functionNode, content) {
+		// Python / separators and C# params array types are not parameters
+		if languageService.IsSyntaxNode(language, param, languageService.SyntaxPositionalSeparator) || getFieldName(param) == "type" {
+			continue
+		}
+
+		parameter := callParameter{
+			defaultValue: getParameterDefault(param),
+			variadic:     languageService.IsSyntaxNode(language, param, languageService.SyntaxVariadicParameter) || hasVariadicModifier(language, param, content),
+			keywords:     languageService.IsSyntaxNode(language, param, languageService.SyntaxKeywordsParameter),
+			keywordOnly:  keywordOnly || languageService.IsSyntaxNode(language, param, languageService.SyntaxKeywordParameter),
+		}
+		if pattern := param.ChildByFieldName("pattern"); pattern != nil && languageService.IsSyntaxNode(language, pattern, languageService.SyntaxRestPattern) {
+			parameter.variadic = true
+		}
+		for _, name := range getParameterNames(language, param, content) {
+			parameter.name = name
+			parameters = append(parameters, parameter)
+		}
+
+		// Python parameters after a bare * or after *args are passed by keyword only
+		if languageService.IsSyntaxNode(language, param, languageService.SyntaxKeywordSeparator) {
+			keywordOnly = true
+		}
+	}
+	return parameters
+}
+
+// -----------------------------------------------------------------------------
+// getParameterNames - Returns the names declared by a parameter node.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - param (*sitter.Node): The parameter node.
+//   - content ([]byte): The content of the file containing the function.
+//
+// Returns:
+//   - ([]string): The names of the parameter, several for Go parameters sharing a type (a, b string).
+//
+// -----------------------------------------------------------------------------
+func getParameterNames(language string, param *sitter.Node, content []byte) []string {
+	var names []string
+	for i := 0; i < int(param.ChildCount()); i++ {
+		if param.FieldNameForChild(i) == "name" && IsIdentifierNode(language, param.Child(i)) {
+			names = append(names, SafeContent(param.Child(i), content))
+		}
+	}
+	if len(names) > 1 {
+		return names
+	}
+
+	name := ""
+	if pattern := param.ChildByFieldName("pattern"); pattern != nil && languageService.IsSyntaxNode(language, pattern, languageService.SyntaxRestPattern) {
+		// TypeScript rest parameters (...rest: string[])
+		name = extractParameterName(language, pattern, content)
+	} else {
+		name = extractParameterName(language, param, content)
+	}
+	if name == "" {
+		// Java varargs name their variable in a declarator (String... names)
+		for _, child := range namedChildren(param) {
+			if languageService.IsSyntaxNode(language, child, languageService.SyntaxDeclarator) {
+				name = SafeContent(child.ChildByFieldName("name"), content)
+			}
+		}
+	}
+	if name == "" {
+		return nil
+	}
+	return []string{name}
+}
+
+// -----------------------------------------------------------------------------
+// getParameterDefault - Returns the default value of a parameter.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - param (*sitter.Node): The parameter node.
+//
+// Returns:
+//   - (*sitter.Node): The default value (1 in b=1, b = 1 or int b = 1), or nil if the parameter has none.
+//
+// -----------------------------------------------------------------------------
+func getParameterDefault(param *sitter.Node) *sitter.Node {
+	for _, field := range []string{"value", "default_value", "right"} {
+		if value := param.ChildByFieldName(field); value != nil {
+			return value
+		}
+	}
+
+	// C# puts the default value after an = inside the parameter, Kotlin after the parameter
+	for i := 0; i+1 < int(param.ChildCount()); i++ {
+		if param.Child(i).Type() == "=" {
+			return param.Child(i + 1)
+		}
+	}
+	if next := param.NextSibling(); next != nil && next.Type() == "=" {
+		return next.NextSibling()
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// hasVariadicModifier - Checks if a parameter is declared variadic by a modifier preceding it.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - param (*sitter.Node): The parameter node.
+//   - content ([]byte): The content of the file containing the function.
+//
+// Returns:
+//   - (bool): True for the C# params arrays and the Kotlin vararg parameters.
+//
+// -----------------------------------------------------------------------------
+func hasVariadicModifier(language string, param *sitter.Node, content []byte) bool {
+	for sibling := param.PrevSibling(); sibling != nil && sibling.Type() != "," && sibling.Type() != "("; sibling = sibling.PrevSibling() {
+		if !languageService.IsSyntaxNode(language, sibling, languageService.SyntaxVariadicModifier) {
+			continue
+		}
+		for _, modifier := range strings.Fields(SafeContent(sibling, content)) {
+			if modifier == "params" || modifier == "vararg" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// -----------------------------------------------------------------------------
+// getFieldName - Returns the name of the field a node is in its parent.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - node (*sitter.Node): The node.
+//
+// Returns:
+//   - (string): The field name (type for the type of a declaration), or an empty string.
+//
+// -----------------------------------------------------------------------------
+func getFieldName(node *sitter.Node) string {
+	parent := node.Parent()
+	if parent == nil {
+		return ""
+	}
+	for i := 0; i < int(parent.ChildCount()); i++ {
+		if parent.Child(i).Equal(node) {
+			return parent.FieldNameForChild(i)
+		}
+	}
+	return ""
+}
+
+// -----------------------------------------------------------------------------
+// describeArgument - Returns the value of a call argument and the way it is passed.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - arg (*sitter.Node): The argument node.
+//   - content ([]byte): The content of the file containing the call.
+//
+// Returns:
+//   - (callArgument): The expression passed, with its keyword or its spread.
+//
+// -----------------------------------------------------------------------------
+func describeArgument(language string, arg *sitter.Node, content []byte) callArgument {
+	argument := callArgument{value: arg}
+
+	if languageService.IsSyntaxNode(language, arg, languageService.SyntaxKeywordArgument) {
+		// Python, Ruby, C# and PHP arguments name their parameter (path=p, path: p or :path => p) before their value
+		for _, field := range []string{"name", "key"} {
+			if name := arg.ChildByFieldName(field); name != nil {
+				argument.keyword = strings.TrimPrefix(strings.TrimSuffix(SafeContent(name, content), ":"), ":")
+				break
+			}
+		}
+		if value := arg.ChildByFieldName("value"); value != nil {
+			argument.value = value
+		} else if arg.NamedChildCount() > 0 {
+			argument.value = arg.NamedChild(int(arg.NamedChildCount()) - 1)
+		}
+	}
+
+	// Kotlin named arguments (path = p) name the parameter before their value
+	if parent := arg.Parent(); parent != nil && languageService.IsSyntaxNode(language, parent, languageService.SyntaxKeywordArgument) && parent.NamedChildCount() > 1 && !parent.NamedChild(0).Equal(arg) {
+		argument.keyword = SafeContent(parent.NamedChild(0), content)
+	}
+
+	if argument.value == nil {
+		argument.value = arg
+	}
+	switch {
+	case languageService.IsSyntaxNode(language, argument.value, languageService.SyntaxSpreadArgument) && argument.value.NamedChildCount() > 0:
+		argument.spread = true
+		argument.value = argument.value.NamedChild(0)
+	case languageService.IsSyntaxNode(language, argument.value, languageService.SyntaxKeywordSpreadArgument) && argument.value.NamedChildCount() > 0:
+		argument.keywordSpread = true
+		argument.value = argument.value.NamedChild(0)
+	}
+	return argument
+}
+
+// dictionaryEntry - An entry of a dictionary literal with a constant key
+type dictionaryEntry struct {
+	key   string
+	value *sitter.Node
+}
+
+// -----------------------------------------------------------------------------
+// resolveLiteralValue - Returns the list or dictionary literal a spread argument refers to.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - value (*sitter.Node): The spread value ([a, b] in f(*[a, b]), settings in f(**settings)).
+//   - content ([]byte): The content of the file containing the call.
+//
+// Returns:
+//   - (*sitter.Node): The literal itself, or the literal last assigned to the variable before the call in its function
+//     or module, or nil if the value is not known.
+//
+// -----------------------------------------------------------------------------
+func resolveLiteralValue(language string, value *sitter.Node, content []byte) *sitter.Node {
+	if value == nil {
+		return nil
+	}
+	isLiteral := func(node *sitter.Node) bool {
+		return node != nil && (languageService.IsSyntaxNode(language, node, languageService.SyntaxDictionary) || languageService.IsSyntaxNode(language, node, languageService.SyntaxPositionalValue))
+	}
+	if isLiteral(value) {
+		return value
+	}
+	if !IsIdentifierNode(language, value) {
+		return nil
+	}
+
+	// The last assignment of the variable before the call, outside of the nested functions
+	scope := FindEnclosingFunctionNode(language, value)
+	if scope == nil {
+		scope = value
+		for scope.Parent() != nil {
+			scope = scope.Parent()
+		}
+	}
+	name := SafeContent(value, content)
+	var literal *sitter.Node
+	var visit func(node *sitter.Node)
+	visit = func(node *sitter.Node) {
+		for _, child := range namedChildren(node) {
+			if child.StartByte() >= value.StartByte() {
+				return
+			}
+			if languageService.IsFunctionNode(language, child) {
+				continue
+			}
+			if languageService.IsAssignmentNode(language, child) {
+				target, assigned := child.ChildByFieldName("left"), child.ChildByFieldName("right")
+				if target == nil {
+					target, assigned = child.ChildByFieldName("name"), child.ChildByFieldName("value")
+				}
+				if target != nil && SafeContent(target, content) == name {
+					literal = nil
+					if isLiteral(assigned) {
+						literal = assigned
+					}
+				}
+			}
+			visit(child)
+		}
+	}
+	visit(scope)
+	return literal
+}
+
+// -----------------------------------------------------------------------------
+// getDictionaryEntries - Returns the entries of a dictionary literal.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - literal (*sitter.Node): The dictionary literal, or nil.
+//   - content ([]byte): The content of the source code.
+//
+// Returns:
+//   - ([]dictionaryEntry): The entries with their constant key ("encoding" in {"encoding": e}, path in {path: p}).
+//   - (bool): True if all the keys of the dictionary are known.
+//
+// -----------------------------------------------------------------------------
+func getDictionaryEntries(language string, literal *sitter.Node, content []byte) ([]dictionaryEntry, bool) {
+	if literal == nil || !languageService.IsSyntaxNode(language, literal, languageService.SyntaxDictionary) {
+		return nil, false
+	}
+
+	var entries []dictionaryEntry
+	for _, child := range namedChildren(literal) {
+		if child.Type() == "comment" {
+			continue
+		}
+		keyNode := child.ChildByFieldName("key")
+		if !languageService.IsSyntaxNode(language, child, languageService.SyntaxDictionaryEntry) || keyNode == nil {
+			// A spread or a shorthand property hides some of the keys
+			return nil, false
+		}
+
+		key, ok := getStringKey(language, keyNode, content)
+		switch {
+		case ok:
+		case languageService.IsSyntaxNode(language, keyNode, languageService.SyntaxSymbol) || IsIdentifierNode(language, keyNode):
+			// Ruby symbols (:path => p, path: p) and JavaScript property names
+			key = strings.TrimPrefix(SafeContent(keyNode, content), ":")
+		default:
+			return nil, false
+		}
+		entries = append(entries, dictionaryEntry{key: key, value: child.ChildByFieldName("value")})
+	}
+	return entries, true
+}
+
+// -----------------------------------------------------------------------------
+// findKeywordParameter - Finds the parameter a keyword argument is passed to.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - parameters ([]callParameter): The parameters of the function.
+//   - keyword (string): The name used by the argument (path in f(path=p)).
+//
+// Returns:
+//   - (callParameter): The parameter of that name.
+//   - (bool): True if the function declares the parameter ($path in PHP).
+//
+// -----------------------------------------------------------------------------
+func findKeywordParameter(parameters []callParameter, keyword string) (callParameter, bool) {
+	for _, parameter := range parameters {
+		if !parameter.variadic && !parameter.keywords && (parameter.name == keyword || parameter.name == "$"+keyword) {
+			return parameter, true
+		}
+	}
+	return callParameter{}, false
+}
+
+/**** Variable Functions ****/
+
+// -----------------------------------------------------------------------------
+// IsAssignment - Checks if a variable is used in an assignment or declaration across multiple languages.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): The node to check.
+//   - content ([]byte): The content of the source code.
+//   - variable (string): The variable to check for.
+//
+// Returns:
+//   - (bool, []string): True if the variable is found in the assignment, and the values assigned to it: the
+//     variables or paths its target receives (b for a in a, b = b, a; opts.path for path in const {path} = opts;
+//     base and name for full = base + "/" + name), or the target receiving it when the variable is on the
+//     right-hand side.
+//
+// -----------------------------------------------------------------------------
+func IsAssignment(language string, node *sitter.Node, content []byte, variable string) (bool, []string) {
+	// Handle the expression statements that may contain assignments
+	if languageService.IsSyntaxNode(language, node, languageService.SyntaxStatement) && node.NamedChildCount() > 0 {
+		return IsAssignment(language, node.NamedChild(0), content, variable)
+	}
+
+	pairs, isAssignmentNode := ExtractAssignmentPairs(language, node, content)
+	if !isAssignmentNode {
+		return false, nil
+	}
+
+	// Check if the variable is on the left-hand side (assigned), as a whole or through one of its fields
+	for _, pair := range pairs {
+		if AccessPathsOverlap(pair.Target, variable) || (languageService.IsSyntaxNode(language, node, languageService.SyntaxStatic) && strings.EqualFold(pair.Target, variable)) {
+			switch {
+			case pair.Copied != "" && IsAccessPathPrefix(pair.Target, variable):
+				// The fields of a copied variable come from the same fields of the copied one
+				return true, []string{RebaseAccessPath(variable, pair.Target, pair.Copied)}
+			case pair.Copied != "":
+				return true, []string{pair.Copied}
+			}
+			return true, pair.Sources
+		}
+	}
+
+	// Check if the variable is on the right-hand side (used)
+	for _, pair := range pairs {
+		if ContainsAccessPath(pair.Sources, variable) {
+			return true, []string{pair.Target}
+		}
+	}
+
+	return false, nil
+}
+
+// -----------------------------------------------------------------------------
+// IsAssignedVariable - Checks if an assignment or declaration assigns a variable, as a whole or through one of its fields.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): The node to check.
+//   - content ([]byte): The content of the source code.
+//   - variable (string): The variable to check.
+//
+// Returns:
+//   - (bool): True if the variable is on the left-hand side of the assignment.
+//
+// -----------------------------------------------------------------------------
+func IsAssignedVariable(language string, node *sitter.Node, content []byte, variable string) bool {
+	if languageService.IsSyntaxNode(language, node, languageService.SyntaxStatement) && node.NamedChildCount() > 0 {
+		return IsAssignedVariable(language, node.NamedChild(0), content, variable)
+	}
+
+	pairs, _ := ExtractAssignmentPairs(language, node, content)
+	for _, pair := range pairs {
+		if AccessPathsOverlap(pair.Target, variable) {
+			return true
+		}
+	}
+	return false
+}
+
+// -----------------------------------------------------------------------------
+// IsStartAssignment - Checks if the assignment on the start line of a backward analysis leads to the value of its variable.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): The assignment or declaration node.
+//   - content ([]byte): The content of the source code.
+//   - variable (string): The variable to check.
+//
+// Returns:
+//   - (bool): True if the functions of the language name their arguments in assignments (local path="$1" in a shell
+//     script) and the node assigns the variable.
+//
+// -----------------------------------------------------------------------------
+func IsStartAssignment(language string, node *sitter.Node, content []byte, variable string) bool {
+	return languageService.HasPositionalParameters(language, node) && IsAssignedVariable(language, node, content, variable)
+}
+
+// -----------------------------------------------------------------------------
+// ExtractAssignmentVariables - Extracts the identifiers on both sides of an assignment or declaration.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): The node to check.
+//   - content ([]byte): The content of the source code.
+//
+// Returns:
+//   - ([]string): The identifiers of the left-hand side (assigned variables).
+//   - ([]string): The identifiers of the right-hand side (used variables).
+//   - (bool): True if the node is an assignment or a declaration.
+//
+// -----------------------------------------------------------------------------
+func ExtractAssignmentVariables(language string, node *sitter.Node, content []byte) ([]string, []string, bool) {
+	// Handle the expression statements that may contain assignments
+	if languageService.IsSyntaxNode(language, node, languageService.SyntaxStatement) && node.NamedChildCount() > 0 {
+		return ExtractAssignmentVariables(language, node.NamedChild(0), content)
+	}
+
+	// Languages with their own extraction handle the node first
+	if lhsVars, rhsVars, ok := languageService.ExtractAssignment(language, node, content); ok {
+		return lhsVars, rhsVars, true
+	}
+
+	// Then the assignments query of the language
+	if captures, ok := languageService.MatchQuery(language, languageService.QueryAssignments, node, content); ok {
+		var lhsVars []string
+		var rhsVars []string
+		for _, lhs := range captures["lhs"] {
+			lhsVars = append(lhsVars, extractIdentifiers(language, lhs, content)...)
+		}
+		for _, rhs := range captures["rhs"] {
+			rhsVars = append(rhsVars, extractIdentifiers(language, rhs, content)...)
+		}
+		return lhsVars, rhsVars, true
+	}
+
+	// Check if the node is an assignment or declaration
+	if !languageService.IsAssignmentNode(language, node) {
+		return nil, nil, false
+	}
+
+	// The assignments the query does not describe are read through their left and right fields
+	var lhsVars []string
+	var rhsVars []string
+	if leftSide := node.ChildByFieldName("left"); leftSide != nil {
+		lhsVars = extractIdentifiers(language, leftSide, content)
+	}
+	if rightSide := node.ChildByFieldName("right"); rightSide != nil {
+		rhsVars = extractIdentifiers(language, rightSide, content)
+	}
+
+	return lhsVars, rhsVars, true
+}
+
+// -----------------------------------------------------------------------------
+// extractIdentifiers - Extracts identifier names from a node.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): The node to extract identifiers from.
+//   - content ([]byte): The content of the source code.
+//
+// Returns:
+//   - ([]string): A slice of identifier names.
+//
+// -----------------------------------------------------------------------------
+func extractIdentifiers(language string, node *sitter.Node, content []byte) []string {
+	var identifiers []string
+
+	if node == nil {
+		return identifiers
+	}
+
+	if IsIdentifierNode(language, node) || languageService.IsSyntaxNode(language, node, languageService.SyntaxConstant) {
+		identifiers = append(identifiers, SafeContent(node, content))
+		return identifiers
+	}
+
+	// Handle destructured properties named after their field (e.g., path in const {path} = opts, x in Point { x })
+	if languageService.IsSyntaxNode(language, node, languageService.SyntaxShorthandProperty) {
+		identifiers = append(identifiers, SafeContent(node, content))
+		return identifiers
+	}
+
+	// Handle member accesses and constant keys as access paths (e.g., req.Path, cfg["path"]), the object of a called method being the variable used
+	if object := getAccessObject(language, node); object != nil && isCalleeNode(language, node) {
+		return extractIdentifiers(language, object, content)
+	}
+	if path := GetAccessPath(language, node, content); path != "" {
+		identifiers = append(identifiers, path)
+		return identifiers
+	}
+
+	// Handle the nodes whose identifiers are not all variables in their language (e.g., Kotlin member names and named arguments)
+	if variableNodes, ok := languageService.FindVariableNodes(language, node); ok {
+		for _, variableNode := range variableNodes {
+			identifiers = append(identifiers, extractIdentifiers(language, variableNode, content)...)
+		}
+		return identifiers
+	}
+
+	// Handle Rust static items
+	if languageService.IsSyntaxNode(language, node, languageService.SyntaxStatic) {
+		// Extract the name of the static variable
+		nameNode := node.ChildByFieldName("name")
+		if nameNode != nil {
+			identifiers = append(identifiers, SafeContent(nameNode, content))
+		}
+		// Extract identifiers from the value if necessary (e.g., complex expressions)
+		valueNode := node.ChildByFieldName("value")
+		if valueNode != nil {
+			identifiers = append(identifiers, extractIdentifiers(language, valueNode, content)...)
+		}
+		return identifiers
+	}
+
+	// Recursively extract identifiers from children, the C/C++ declarators and the Rust patterns included
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		child := node.NamedChild(i)
+		identifiers = append(identifiers, extractIdentifiers(language, child, content)...)
+	}
+
+	return identifiers
+}
+
+// -----------------------------------------------------------------------------
+// IsCalledName - Checks if a name read by a value only names the functions it calls.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - root (*sitter.Node): The root node of the syntax tree.
+//   - node (*sitter.Node): The value.
+//   - name (string): The name to check.
+//   - content ([]byte): The content of the source code.
+//
+// Returns:
+//   - (bool): True if each occurrence of the name in the value is a called function (fopen in fopen(path)), a part of
+//     a qualified called name (fs and read_to_string in fs::read_to_string(path)), an instantiated type (URLSearchParams
+//     in new URLSearchParams(query)) or an imported module whose function is called (regexp in regexp.MustCompile(pattern)).
+//
+// -----------------------------------------------------------------------------
+func IsCalledName(language string, root, node *sitter.Node, name string, content []byte) bool {
+	called := false
+	var traverse func(current *sitter.Node) bool
+	traverse = func(current *sitter.Node) bool {
+		if IsIdentifierNode(language, current) && SafeContent(current, content) == name {
+			var object *sitter.Node
+			if parent := current.Parent(); parent != nil && isCalleeNode(language, parent) {
+				object = getAccessObject(language, parent)
+			}
+			switch {
+			case isCalleeNode(language, current) || isCalledScopedName(language, current) || isInstantiatedType(language, current):
+				called = true
+			case object != nil && object.Equal(current) && isModuleName(language, root, name, content):
+				called = true
+			default:
+				return false
+			}
+			return true
+		}
+		for i := 0; i < int(current.NamedChildCount()); i++ {
+			if !traverse(current.NamedChild(i)) {
+				return false
+			}
+		}
+		return true
+	}
+	return node != nil && traverse(node) && called
+}
+
+// -----------------------------------------------------------------------------
+// IsVariableUsedInExpression - Checks if a variable is used in an expression within a node.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): The node to check.
+//   - variable (string): The variable to check for.
+//   - content ([]byte): The content of the source code.
+//
+// Returns:
+//   - (bool): True if the variable is used in the expression, false otherwise.
+//
+// -----------------------------------------------------------------------------
+func IsVariableUsedInExpression(language string, node *sitter.Node, variable string, content []byte) bool {
+	if node == nil {
+		return false
+	}
+
+	if IsIdentifierNode(language, node) {
+		// Extraire le texte du nœud et comparer avec la variable
+		// Un nom de membre n'est pas une variable, un identifiant objet d'un champ ou d'une clé n'utilise que ce champ (req dans req.Name)
+		if isAccessMemberNode(language, node) {
+			return false
+		}
+		return AccessPathsOverlap(getEnclosingAccessPath(language, node, content), variable)
+	}
+
+	// Un champ ou une clé n'utilise que la partie de l'objet qu'il désigne, un appel de méthode utilise l'objet entier
+	if object := getAccessObject(language, node); object != nil && isCalleeNode(language, node) {
+		return IsVariableUsedInExpression(language, object, variable, content)
+	}
+	if path := GetAccessPath(language, node, content); path != "" {
+		return AccessPathsOverlap(path, variable)
+	}
+
+	// Parcourir récursivement tous les enfants du nœud
+	for i := 0; i < int(node.ChildCount()); i++ {
+		child := node.Child(i)
+		if IsVariableUsedInExpression(language, child, variable, content) {
+			return true
+		}
+	}
+	return false
+}
+
+// -----------------------------------------------------------------------------
+// IsVariableInScope - Checks if the given variable is in scope within the provided function node.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - varName (string): The name of the variable to check.
+//   - functionNode (*sitter.Node): The function node to check within.
+//   - content ([]byte): The content of the source code.
+//
+// Returns:
+//   - (bool): True if the variable is in scope, false otherwise.
+//
+// -----------------------------------------------------------------------------
+func IsVariableInScope(language string, varName string, functionNode *sitter.Node, content []byte) bool {
+	if functionNode == nil {
+		return false
+	}
+
+	// Vérifier si la variable est définie dans les paramètres de la fonction
+	parameters := functionNode.ChildByFieldName("parameters")
+	if parameters != nil {
+		for i := 0; i < int(parameters.NamedChildCount()); i++ {
+			param := parameters.NamedChild(i)
+			paramName := extractParameterName(language, param, content)
+			if paramName == varName {
+				return true // La variable est un paramètre de la fonction
+			}
+		}
+	}
+
+	// Vérifier si la variable est définie dans les déclarations locales
+	var traverse func(*sitter.Node) bool
+	traverse = func(n *sitter.Node) bool {
+		if n == nil {
+			return false
+		}
+
+		// Vérifier les déclarations locales (e.g. short_var_declaration)
+		if languageService.IsSyntaxNode(language, n, languageService.SyntaxLocalDeclaration) {
+			leftSide := n.ChildByFieldName("left")
+			if leftSide != nil {
+				for i := 0; i < int(leftSide.NamedChildCount()); i++ {
+					child := leftSide.NamedChild(i)
+					if IsIdentifierNode(language, child) {
+						varNameInNode := SafeContent(child, content)
+						if varNameInNode == varName {
+							return true
+						}
+					}
+				}
+			}
+		}
+
+		// Parcourir récursivement les enfants
+		for i := 0; i < int(n.NamedChildCount()); i++ {
+			if traverse(n.NamedChild(i)) {
+				return true
+			}
+		}
+		return false
+	}
+
+	// Vérifier dans le corps de la fonction
+	body := functionNode.ChildByFieldName("body")
+	if body != nil {
+		return traverse(body)
+	}
+
+	return false
+}
+
+/**** Assignment Pair Functions ****/
+
+// -----------------------------------------------------------------------------
+// ExtractAssignmentPairs - Pairs each target of an assignment or declaration with the part of the value it receives.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): The node to check.
+//   - content ([]byte): The content of the source code.
+//
+// Returns:
+//   - ([]models.AssignmentPair): The targets in source order, with the variables their values are read from
+//     (a from b and b from a in a, b = b, a; path from opts.path in const {path} = opts).
+//   - (bool): True if the node is an assignment or a declaration.
+//
+// -----------------------------------------------------------------------------
+func ExtractAssignmentPairs(language string, node *sitter.Node, content []byte) ([]models.AssignmentPair, bool) {
+	if languageService.IsSyntaxNode(language, node, languageService.SyntaxStatement) && node.NamedChildCount() > 0 {
+		return ExtractAssignmentPairs(language, node.NamedChild(0), content)
+	}
+
+	lhsVars, rhsVars, isAssignmentNode := ExtractAssignmentVariables(language, node, content)
+	if !isAssignmentNode {
+		return nil, false
+	}
+
+	// The languages with their own extraction and the generic extraction give every target the whole value
+	captures, matched := languageService.MatchQuery(language, languageService.QueryAssignments, node, content)
+	if _, _, handled := languageService.ExtractAssignment(language, node, content); handled || !matched {
+		return pairWithWholeValue(lhsVars, rhsVars), true
+	}
+
+	var pairs []models.AssignmentPair
+	targets, values := captures["lhs"], captures["rhs"]
+	switch {
+	case len(targets) == len(values):
+		// Each target has its own value (a = x, b = y or a, b = b, a)
+		for i, target := range targets {
+			pairs = append(pairs, destructure(language, target, values[i], content)...)
+		}
+	case len(targets) == 1 && len(values) == 1:
+		pairs = destructure(language, targets[0], values[0], content)
+	case len(values) == 1:
+		// Several names share a list of values (Go var a, b = x, y) or destructure a single value (Kotlin val (a, b) = pair),
+		// every name receiving the result of a call (data and err in data, err := ioutil.ReadFile(p))
+		valueElements := getPositionalElements(language, values[0])
+		for i, target := range targets {
+			if len(valueElements) == len(targets) && languageService.IsSyntaxNode(language, values[0], languageService.SyntaxPositionalValue) {
+				if valueElements[i] != nil {
+					pairs = append(pairs, destructure(language, target, valueElements[i], content)...)
+				}
+				continue
+			}
+			pairs = append(pairs, destructurePath(language, target, "", getResultVariables(language, values[0], content), content)...)
+		}
+	default:
+		// Declarations whose targets have no value of their own (int a, b = 3)
+		return pairWithWholeValue(lhsVars, rhsVars), true
+	}
+	return pairs, true
+}
+
+// -----------------------------------------------------------------------------
+// getResultVariables - Extracts the variables a value is computed from.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - value (*sitter.Node): The value of an assignment.
+//   - content ([]byte): The content of the source code.
+//
+// Returns:
+//   - ([]string): The variables and access paths of the value, without the names of the functions and modules it
+//     calls (p for ioutil.ReadFile(p)).
+//
+// -----------------------------------------------------------------------------
+func getResultVariables(language string, value *sitter.Node, content []byte) []string {
+	if value == nil {
+		return nil
+	}
+	root := findRootNode(value)
+	var variables []string
+	for _, identifier := range extractIdentifiers(language, value, content) {
+		if !IsCalledName(language, root, value, identifier, content) {
+			variables = append(variables, identifier)
+		}
+	}
+	return variables
+}
+
+// -----------------------------------------------------------------------------
+// pairWithWholeValue - Pairs every target of an assignment with all the variables of its value.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - lhsVars ([]string): The assigned variables.
+//   - rhsVars ([]string): The variables of the value.
+//
+// Returns:
+//   - ([]models.AssignmentPair): One pair per assigned variable.
+//
+// -----------------------------------------------------------------------------
+func pairWithWholeValue(lhsVars, rhsVars []string) []models.AssignmentPair {
+	var pairs []models.AssignmentPair
+	for _, lhsVar := range lhsVars {
+		pairs = append(pairs, models.AssignmentPair{Target: lhsVar, Sources: rhsVars})
+	}
+	return pairs
+}
+
+// -----------------------------------------------------------------------------
+// destructure - Pairs the targets of an assignment pattern with the parts of a value node.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - target (*sitter.Node): The target or pattern of the assignment.
+//   - value (*sitter.Node): The value assigned to it.
+//   - content ([]byte): The content of the source code.
+//
+// Returns:
+//   - ([]models.AssignmentPair): The pairs of the targets of the pattern.
+//
+// -----------------------------------------------------------------------------
+func destructure(language string, target, value *sitter.Node, content []byte) []models.AssignmentPair {
+	// Values listed by position go to the targets at the same position
+	if languageService.IsSyntaxNode(language, target, languageService.SyntaxPositionalPattern) && value != nil && languageService.IsSyntaxNode(language, value, languageService.SyntaxPositionalValue) {
+		elements, valueElements := getPositionalElements(language, target), getPositionalElements(language, value)
+		if len(elements) == len(valueElements) || hasRestPattern(language, elements) {
+			var pairs []models.AssignmentPair
+			for i, element := range elements {
+				if element == nil || i >= len(valueElements) {
+					continue
+				}
+				if languageService.IsSyntaxNode(language, element, languageService.SyntaxRestPattern) {
+					// The rest receives the values left
+					var sources []string
+					for _, valueElement := range valueElements[i:] {
+						if valueElement != nil {
+							sources = append(sources, extractIdentifiers(language, valueElement, content)...)
+						}
+					}
+					pairs = append(pairs, destructurePath(language, element, "", sources, content)...)
+					continue
+				}
+				if valueElements[i] != nil {
+					pairs = append(pairs, destructure(language, element, valueElements[i], content)...)
+				}
+			}
+			return pairs
+		}
+	}
+
+	// Several targets receive together the results of a call, each one from the variables of the call (data and err
+	// from p in data, err := ioutil.ReadFile(p))
+	sources := extractIdentifiers(language, value, content)
+	if languageService.IsSyntaxNode(language, target, languageService.SyntaxPositionalPattern) {
+		sources = getResultVariables(language, value, content)
+	}
+	return destructurePath(language, target, GetAccessPath(language, value, content), sources, content)
+}
+
+// -----------------------------------------------------------------------------
+// destructurePath - Pairs the targets of an assignment pattern with the fields of a value read as a whole.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - target (*sitter.Node): The target or pattern of the assignment.
+//   - valuePath (string): The access path of the value, or an empty string if the value is another expression.
+//   - sources ([]string): The variables and access paths read by the value.
+//   - content ([]byte): The content of the source code.
+//
+// Returns:
+//   - ([]models.AssignmentPair): The pairs of the targets of the pattern, the destructured fields extending the
+//     access path of the value (opts.path for path in const {path} = opts).
+//
+// -----------------------------------------------------------------------------
+func destructurePath(language string, target *sitter.Node, valuePath string, sources []string, content []byte) []models.AssignmentPair {
+	var pairs []models.AssignmentPair
+
+	switch {
+	case languageService.IsSyntaxNode(language, target, languageService.SyntaxRestPattern) || (languageService.IsSyntaxNode(language, target, languageService.SyntaxAccessWrapper) && !languageService.IsSyntaxNode(language, target, languageService.SyntaxPositionalPattern)):
+		// Wrappers of a single target (...rest, C# tuple elements)
+		for _, child := range namedChildren(target) {
+			pairs = append(pairs, destructurePath(language, child, valuePath, sources, content)...)
+		}
+
+	case languageService.IsSyntaxNode(language, target, languageService.SyntaxDefaultPattern):
+		// A default value (b = 2) is another origin of the target
+		defaults := extractIdentifiers(language, target.ChildByFieldName("right"), content)
+		if left := target.ChildByFieldName("left"); left != nil {
+			pairs = append(pairs, destructurePath(language, left, valuePath, append(append([]string{}, sources...), defaults...), content)...)
+		}
+
+	case languageService.IsSyntaxNode(language, target, languageService.SyntaxObjectPattern) || languageService.IsSyntaxNode(language, target, languageService.SyntaxPositionalPattern) && isKeyedList(target):
+		// Each property receives the field of the same name
+		for _, property := range getDestructuredProperties(language, target, content) {
+			if property.field == "" || valuePath == "" {
+				pairs = append(pairs, destructurePath(language, property.target, valuePath, sources, content)...)
+				continue
+			}
+			fieldPath := valuePath + property.field
+			pairs = append(pairs, destructurePath(language, property.target, fieldPath, []string{fieldPath}, content)...)
+		}
+
+	case languageService.IsSyntaxNode(language, target, languageService.SyntaxPositionalPattern):
+		// Without a list of values, each target receives an element of the whole value
+		for _, element := range getPositionalElements(language, target) {
+			if element != nil {
+				pairs = append(pairs, destructurePath(language, element, "", sources, content)...)
+			}
+		}
+
+	default:
+		targetPaths := []string{GetAccessPath(language, target, content)}
+		if targetPaths[0] == "" {
+			targetPaths = extractIdentifiers(language, target, content)
+		}
+		for _, targetPath := range targetPaths {
+			// Go and Rust discard the values assigned to _
+			if targetPath == "_" {
+				continue
+			}
+			pairs = append(pairs, models.AssignmentPair{Target: targetPath, Sources: sources, Copied: valuePath})
+		}
+	}
+	return pairs
+}
+
+// destructuredProperty - A property of an object pattern: the segment of the field it reads and its target
+type destructuredProperty struct {
+	field  string
+	target *sitter.Node
+}
+
+// -----------------------------------------------------------------------------
+// getDestructuredProperties - Returns the properties of an object pattern or a keyed list.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - pattern (*sitter.Node): The object pattern ({path, mode: m, ...rest}) or the PHP keyed list (['path' => $p]).
+//   - content ([]byte): The content of the source code.
+//
+// Returns:
+//   - ([]destructuredProperty): The properties with the segment of their field (.path, ["path"]), empty for a rest.
+//
+// -----------------------------------------------------------------------------
+func getDestructuredProperties(language string, pattern *sitter.Node, content []byte) []destructuredProperty {
+	var properties []destructuredProperty
+
+	// PHP lists pair a key with the following target
+	if isKeyedList(pattern) {
+		var key *sitter.Node
+		for i := 0; i < int(pattern.ChildCount()); i++ {
+			child := pattern.Child(i)
+			switch {
+			case !child.IsNamed():
+				continue
+			case key == nil && i+1 < int(pattern.ChildCount()) && pattern.Child(i+1).Type() == "=>":
+				key = child
+			default:
+				field := ""
+				if name, ok := getStringKey(language, key, content); ok {
+					field = formatKeySegment(name)
+				}
+				properties = append(properties, destructuredProperty{field: field, target: child})
+				key = nil
+			}
+		}
+		return properties
+	}
+
+	for _, property := range namedChildren(pattern) {
+		switch {
+		case languageService.IsSyntaxNode(language, property, languageService.SyntaxShorthandProperty) || IsIdentifierNode(language, property):
+			properties = append(properties, destructuredProperty{field: "." + SafeContent(property, content), target: property})
+		case languageService.IsSyntaxNode(language, property, languageService.SyntaxDefaultPattern):
+			left := property.ChildByFieldName("left")
+			properties = append(properties, destructuredProperty{field: "." + SafeContent(left, content), target: property})
+		case languageService.IsSyntaxNode(language, property, languageService.SyntaxPropertyPattern):
+			key := property.ChildByFieldName("key")
+			if key == nil {
+				key = property.ChildByFieldName("name")
+			}
+			target := property.ChildByFieldName("value")
+			if target == nil {
+				target = property.ChildByFieldName("pattern")
+			}
+			if target == nil {
+				// Rust shorthand fields (Point { x }) name their target
+				target = key
+			}
+			field := ""
+			if name, ok := getStringKey(language, key, content); ok {
+				field = formatKeySegment(name)
+			} else if key != nil {
+				field = "." + SafeContent(key, content)
+			}
+			properties = append(properties, destructuredProperty{field: field, target: target})
+		case languageService.IsSyntaxNode(language, property, languageService.SyntaxRestPattern):
+			properties = append(properties, destructuredProperty{target: property})
+		}
+	}
+	return properties
+}
+
+// -----------------------------------------------------------------------------
+// getPositionalElements - Returns the elements of a list of targets or values by position.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): The list (a, b or [a, , b]).
+//
+// Returns:
+//   - ([]*sitter.Node): The elements, nil for the holes of the list.
+//
+// -----------------------------------------------------------------------------
+func getPositionalElements(language string, node *sitter.Node) []*sitter.Node {
+	var elements []*sitter.Node
+	var current *sitter.Node
+	separated := false
+	for i := 0; i < int(node.ChildCount()); i++ {
+		child := node.Child(i)
+		switch {
+		case child.Type() == ",":
+			elements = append(elements, current)
+			current = nil
+			separated = true
+		case child.IsNamed() && child.Type() != "comment":
+			current = child
+		}
+	}
+	if current != nil || !separated {
+		elements = append(elements, current)
+	}
+	if len(elements) == 1 && elements[0] == nil {
+		return nil
+	}
+
+	// PHP array elements wrap their value
+	for i, element := range elements {
+		if element != nil && languageService.IsSyntaxNode(language, element, languageService.SyntaxAccessWrapper) && element.NamedChildCount() == 1 {
+			elements[i] = element.NamedChild(0)
+		}
+	}
+	return elements
+}
+
+// -----------------------------------------------------------------------------
+// hasRestPattern - Checks if a list of targets ends with a rest receiving the values left.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - elements ([]*sitter.Node): The targets of a positional destructuring.
+//
+// Returns:
+//   - (bool): True if the last target is a rest (...rest, *rest).
+//
+// -----------------------------------------------------------------------------
+func hasRestPattern(language string, elements []*sitter.Node) bool {
+	if len(elements) == 0 || elements[len(elements)-1] == nil {
+		return false
+	}
+	return languageService.IsSyntaxNode(language, elements[len(elements)-1], languageService.SyntaxRestPattern)
+}
+
+// -----------------------------------------------------------------------------
+// isKeyedList - Checks if a PHP list destructures an array by key.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - node (*sitter.Node): The positional pattern.
+//
+// Returns:
+//   - (bool): True if the list pairs keys with targets (['path' => $p] = $opts).
+//
+// -----------------------------------------------------------------------------
+func isKeyedList(node *sitter.Node) bool {
+	for i := 0; i < int(node.ChildCount()); i++ {
+		if node.Child(i).Type() == "=>" {
+			return true
+		}
+	}
+	return false
+}
+
+/**** Return Value Functions ****/
+
+// -----------------------------------------------------------------------------
+// FindReturnStatements - Finds the statements returning a value from a function.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - functionNode (*sitter.Node): The function declaration node.
+//   - content ([]byte): The content of the source code.
+//
+// Returns:
+//   - ([]*sitter.Node): The return statements with a value, in the order of the source, without those of the nested functions.
+//
+// -----------------------------------------------------------------------------
+func FindReturnStatements(language string, functionNode *sitter.Node, content []byte) []*sitter.Node {
+	var returns []*sitter.Node
+
+	var traverse func(node *sitter.Node)
+	traverse = func(node *sitter.Node) {
+		for _, child := range namedChildren(node) {
+			// The return statements of a nested function or closure leave that function
+			if languageService.IsFunctionNode(language, child) || languageService.IsSyntaxNode(language, child, languageService.SyntaxClosure) {
+				continue
+			}
+			// Kotlin jump expressions also break, continue and throw
+			if languageService.GetControlKind(language, child) == languageService.ControlReturn {
+				if strings.HasPrefix(SafeContent(child, content), "return") && len(GetReturnedValues(language, child)) > 0 {
+					returns = append(returns, child)
+				}
+				continue
+			}
+			traverse(child)
+		}
+	}
+
+	if body := functionNode.ChildByFieldName("body"); body != nil {
+		traverse(body)
+	} else {
+		traverse(functionNode)
+	}
+	return returns
+}
+
+// -----------------------------------------------------------------------------
+// GetReturnedValues - Returns the values of a return statement.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - returnNode (*sitter.Node): The return statement node.
+//
+// Returns:
+//   - ([]*sitter.Node): The returned expressions, one per value when several values are returned (return data, nil).
+//
+// -----------------------------------------------------------------------------
+func GetReturnedValues(language string, returnNode *sitter.Node) []*sitter.Node {
+	var values []*sitter.Node
+	for _, child := range namedChildren(returnNode) {
+		if child.Type() == "comment" {
+			continue
+		}
+		if languageService.IsSyntaxNode(language, child, languageService.SyntaxReturnedValues) {
+			values = append(values, namedChildren(child)...)
+			continue
+		}
+		values = append(values, child)
+	}
+	return values
+}
+
+// -----------------------------------------------------------------------------
+// GetReturnedVariables - Returns the variables building the value a return statement gives to an assigned variable.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - returnNode (*sitter.Node): The return statement node.
+//   - content ([]byte): The content of the source code.
+//   - index (int): The position of the variable among the variables assigned by the call.
+//   - count (int): The number of variables assigned by the call.
+//
+// Returns:
+//   - ([]string): The variables and access paths of the returned value (content for data in data, err := f() when
+//     f returns content, nil), without duplicates.
+//
+// -----------------------------------------------------------------------------
+func GetReturnedVariables(language string, returnNode *sitter.Node, content []byte, index, count int) []string {
+	var variables []string
+	for _, value := range getReturnedValuesAt(language, returnNode, index, count) {
+		for _, identifier := range extractIdentifiers(language, value, content) {
+			if !utilityService.ContainsString(variables, identifier) {
+				variables = append(variables, identifier)
+			}
+		}
+	}
+	return variables
+}
+
+// -----------------------------------------------------------------------------
+// GetReturnedLiterals - Returns the literal values a return statement gives to an assigned variable.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - returnNode (*sitter.Node): The return statement node.
+//   - index (int): The position of the variable among the variables assigned by the call.
+//   - count (int): The number of variables assigned by the call.
+//
+// Returns:
+//   - ([]*sitter.Node): The returned values that are literals ("cached" in return "cached").
+//
+// -----------------------------------------------------------------------------
+func GetReturnedLiterals(language string, returnNode *sitter.Node, index, count int) []*sitter.Node {
+	var literals []*sitter.Node
+	for _, value := range getReturnedValuesAt(language, returnNode, index, count) {
+		if IsLiteral(language, value) {
+			literals = append(literals, value)
+		}
+	}
+	return literals
+}
+
+// -----------------------------------------------------------------------------
+// getReturnedValuesAt - Returns the values a return statement gives to an assigned variable.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - returnNode (*sitter.Node): The return statement node.
+//   - index (int): The position of the variable among the variables assigned by the call.
+//   - count (int): The number of variables assigned by the call.
+//
+// Returns:
+//   - ([]*sitter.Node): The returned value at the position of the variable, or all the returned values.
+//
+// -----------------------------------------------------------------------------
+func getReturnedValuesAt(language string, returnNode *sitter.Node, index, count int) []*sitter.Node {
+	values := GetReturnedValues(language, returnNode)
+
+	// Each assigned variable receives the returned value at its position
+	if count > 1 && len(values) == count && index < count {
+		values = values[index : index+1]
+	}
+	return values
+}
+
+/**** Access Path Functions ****/
+
+// -----------------------------------------------------------------------------
+// GetAccessPath - Returns the access path read or written by a node: a variable followed by its fields and constant keys.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): The node to convert (identifier, member access, subscript with a string key).
+//   - content ([]byte): The content of the source code.
+//
+// Returns:
+//   - (string): The access path (e.g. req.Path, cfg["path"]), or an empty string if the node is not a variable or does not start from one.
+//
+// -----------------------------------------------------------------------------
+func GetAccessPath(language string, node *sitter.Node, content []byte) string {
+	base, segments := getAccessPathSegments(language, node, content)
+	if base == "" {
+		return ""
+	}
+	return base + strings.Join(segments, "")
+}
+
+// -----------------------------------------------------------------------------
+// getAccessPathSegments - Splits the access path of a node into its variable and its fields and keys.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): The node to convert.
+//   - content ([]byte): The content of the source code.
+//
+// Returns:
+//   - (string): The variable the path starts from, or an empty string if the node is not an access path.
+//   - ([]string): The segments of the path (.field or ["key"]), in order.
+//
+// -----------------------------------------------------------------------------
+func getAccessPathSegments(language string, node *sitter.Node, content []byte) (string, []string) {
+	if node == nil {
+		return "", nil
+	}
+
+	if IsIdentifierNode(language, node) || languageService.IsSyntaxNode(language, node, languageService.SyntaxAccessBase) {
+		return SafeContent(node, content), nil
+	}
+	if languageService.IsSyntaxNode(language, node, languageService.SyntaxAccessWrapper) {
+		if node.NamedChildCount() != 1 {
+			return "", nil
+		}
+		return getAccessPathSegments(language, node.NamedChild(0), content)
+	}
+
+	// Kotlin chains its members and keys as suffixes of the object, without fields
+	if languageService.IsSyntaxNode(language, node, languageService.SyntaxSuffixedAccess) {
+		if node.NamedChildCount() < 2 {
+			return getAccessPathSegments(language, node.NamedChild(0), content)
+		}
+		base, segments := getAccessPathSegments(language, node.NamedChild(0), content)
+		for i := 1; i < int(node.NamedChildCount()) && base != ""; i++ {
+			suffix := node.NamedChild(i)
+			switch {
+			case languageService.IsSyntaxNode(language, suffix, languageService.SyntaxMemberSuffix):
+				segments = append(segments, "."+SafeContent(suffix.NamedChild(int(suffix.NamedChildCount())-1), content))
+			case languageService.IsSyntaxNode(language, suffix, languageService.SyntaxKeySuffix):
+				key, ok := getStringKey(language, suffix, content)
+				if !ok {
+					return "", nil
+				}
+				segments = append(segments, formatKeySegment(key))
+			default:
+				return "", nil
+			}
+		}
+		return base, segments
+	}
+
+	var object *sitter.Node
+	for _, field := range languageService.GetFieldNames(language, languageService.FieldObject) {
+		if object = node.ChildByFieldName(field); object != nil {
+			break
+		}
+	}
+
+	if languageService.IsSyntaxNode(language, node, languageService.SyntaxMemberAccess) {
+		// A Ruby call with arguments or a block is a method call, not an attribute read
+		if languageService.IsCallNode(language, node) && (node.ChildByFieldName("arguments") != nil || node.ChildByFieldName("block") != nil) {
+			return "", nil
+		}
+
+		var member *sitter.Node
+		for _, field := range languageService.GetFieldNames(language, languageService.FieldMember) {
+			if member = node.ChildByFieldName(field); member != nil {
+				break
+			}
+		}
+		if member == nil || member.NamedChildCount() > 0 && !IsIdentifierNode(language, member) {
+			return "", nil
+		}
+
+		var base string
+		var segments []string
+		if object != nil {
+			base, segments = getAccessPathSegments(language, object, content)
+		} else if node.ChildCount() > 0 && languageService.IsSyntaxNode(language, node.Child(0), languageService.SyntaxAccessBase) {
+			// C# keeps this as an anonymous keyword of the member access
+			base = SafeContent(node.Child(0), content)
+		}
+		if base == "" {
+			return "", nil
+		}
+		return base, append(segments, "."+SafeContent(member, content))
+	}
+
+	if languageService.IsSyntaxNode(language, node, languageService.SyntaxSubscript) {
+		if object == nil {
+			object = node.NamedChild(0)
+		}
+		var keyNode *sitter.Node
+		for _, field := range languageService.GetFieldNames(language, languageService.FieldKey) {
+			if keyNode = node.ChildByFieldName(field); keyNode != nil {
+				break
+			}
+		}
+		if keyNode == nil && node.NamedChildCount() == 2 {
+			keyNode = node.NamedChild(1)
+		}
+
+		key, ok := getStringKey(language, keyNode, content)
+		if !ok {
+			return "", nil
+		}
+		base, segments := getAccessPathSegments(language, object, content)
+		if base == "" {
+			return "", nil
+		}
+		return base, append(segments, formatKeySegment(key))
+	}
+
+	return "", nil
+}
+
+// -----------------------------------------------------------------------------
+// getStringKey - Returns the value of a constant string key of a subscript.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): The key node, possibly wrapped in an argument list.
+//   - content ([]byte): The content of the source code.
+//
+// Returns:
+//   - (string): The key without its quotes.
+//   - (bool): True if the key is a string literal without interpolation.
+//
+// -----------------------------------------------------------------------------
+func getStringKey(language string, node *sitter.Node, content []byte) (string, bool) {
+	for node != nil && languageService.IsSyntaxNode(language, node, languageService.SyntaxAccessWrapper) && node.NamedChildCount() == 1 {
+		node = node.NamedChild(0)
+	}
+	if node == nil || !strings.Contains(node.Type(), "string") {
+		return "", false
+	}
+
+	// Interpolated strings (e.g. f"{name}", "$name") are not constant
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		if !languageService.IsSyntaxNode(language, node.NamedChild(i), languageService.SyntaxStringPart) {
+			return "", false
+		}
+	}
+
+	text := SafeContent(node, content)
+	start := strings.IndexAny(text, "\"'`")
+	if start < 0 || len(text)-start < 2 || text[len(text)-1] != text[start] {
+		return "", false
+	}
+	return text[start+1 : len(text)-1], true
+}
+
+// -----------------------------------------------------------------------------
+// formatKeySegment - Formats a constant key as a segment of an access path.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - key (string): The key without its quotes.
+//
+// Returns:
+//   - (string): The segment, the key being double-quoted (e.g. ["path"]).
+//
+// -----------------------------------------------------------------------------
+func formatKeySegment(key string) string {
+	return "[" + strconv.Quote(key) + "]"
+}
+
+// -----------------------------------------------------------------------------
+// parseAccessPath - Splits an access path written by hand or built from the syntax tree into its variable and its segments.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - path (string): The access path (e.g. req.Path, $req->path, cfg['path']).
+//
+// Returns:
+//   - (string): The variable the path starts from.
+//   - ([]string): The segments of the path (.field or ["key"]), in order.
+//
+// -----------------------------------------------------------------------------
+func parseAccessPath(path string) (string, []string) {
+	path = strings.TrimSpace(path)
+	end := nextAccessSeparator(path)
+	if end <= 0 {
+		return path, nil
+	}
+
+	base := path[:end]
+	var segments []string
+	rest := path[end:]
+	for rest != "" {
+		switch {
+		case strings.HasPrefix(rest, "->"), strings.HasPrefix(rest, "?."):
+			rest = "." + rest[2:]
+		case rest[0] == '.':
+			nameEnd := nextAccessSeparator(rest[1:])
+			if nameEnd < 0 {
+				nameEnd = len(rest) - 1
+			}
+			if nameEnd == 0 {
+				return path, nil
+			}
+			segments = append(segments, rest[:nameEnd+1])
+			rest = rest[nameEnd+1:]
+		case rest[0] == '[':
+			// The key is quoted, the closing quote being followed by the closing bracket
+			if len(rest) < 4 || !strings.ContainsRune("\"'`", rune(rest[1])) {
+				return path, nil
+			}
+			closing := strings.Index(rest[2:], string(rest[1])+"]")
+			if closing < 0 {
+				return path, nil
+			}
+			closing += 3
+			key := rest[1:closing]
+			if unquoted, err := strconv.Unquote(key); err == nil {
+				key = unquoted
+			} else {
+				key = key[1 : len(key)-1]
+			}
+			segments = append(segments, formatKeySegment(key))
+			rest = rest[closing+1:]
+		default:
+			return path, nil
+		}
+	}
+	return base, segments
+}
+
+// -----------------------------------------------------------------------------
+// nextAccessSeparator - Finds the start of the next segment of an access path.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - path (string): The rest of the access path.
+//
+// Returns:
+//   - (int): The index of the next ".", "[", "->" or "?.", -1 if there is none.
+//
+// -----------------------------------------------------------------------------
+func nextAccessSeparator(path string) int {
+	for i := 0; i < len(path); i++ {
+		switch {
+		case path[i] == '.' || path[i] == '[':
+			return i
+		case strings.HasPrefix(path[i:], "->"), strings.HasPrefix(path[i:], "?."):
+			return i
+		}
+	}
+	return -1
+}
+
+// -----------------------------------------------------------------------------
+// NormalizeAccessPath - Writes an access path the way the analysis builds them from the syntax tree.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - path (string): The access path (e.g. $req->path, cfg['path']).
+//
+// Returns:
+//   - (string): The path with dots between the fields and double-quoted keys (e.g. $req.path, cfg["path"]).
+//
+// -----------------------------------------------------------------------------
+func NormalizeAccessPath(path string) string {
+	base, segments := parseAccessPath(path)
+	return base + strings.Join(segments, "")
+}
+
+// -----------------------------------------------------------------------------
+// AccessPathBase - Returns the variable an access path starts from.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - path (string): The access path.
+//
+// Returns:
+//   - (string): The variable (e.g. req for req.Path).
+//
+// -----------------------------------------------------------------------------
+func AccessPathBase(path string) string {
+	base, _ := parseAccessPath(path)
+	return base
+}
+
+// -----------------------------------------------------------------------------
+// TruncateAccessPath - Keeps the first fields and keys of an access path.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - path (string): The access path.
+//   - depth (int): The number of fields and keys to keep, 0 to keep the variable only.
+//
+// Returns:
+//   - (string): The truncated path, which also holds the fields beyond the depth.
+//
+// -----------------------------------------------------------------------------
+func TruncateAccessPath(path string, depth int) string {
+	base, segments := parseAccessPath(path)
+	if depth < 0 {
+		depth = 0
+	}
+	if len(segments) > depth {
+		segments = segments[:depth]
+	}
+	return base + strings.Join(segments, "")
+}
+
+// -----------------------------------------------------------------------------
+// IsAccessPathPrefix - Checks if an access path holds another one (req holds req.Path, req.Path holds req.Path.Name).
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - prefix (string): The access path that may hold the other one.
+//   - path (string): The access path to check.
+//
+// Returns:
+//   - (bool): True if both paths are equal or if the path goes further than the prefix.
+//
+// -----------------------------------------------------------------------------
+func IsAccessPathPrefix(prefix, path string) bool {
+	if prefix == path {
+		return true
+	}
+	prefixBase, prefixSegments := parseAccessPath(prefix)
+	base, segments := parseAccessPath(path)
+	if prefixBase != base || len(prefixSegments) > len(segments) {
+		return false
+	}
+	for i, segment := range prefixSegments {
+		if segments[i] != segment {
+			return false
+		}
+	}
+	return true
 }
 
-/**** Variable Functions ****/
+// -----------------------------------------------------------------------------
+// AccessPathsOverlap - Checks if two access paths share a value: one of them holds the other.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - a (string): The first access path.
+//   - b (string): The second access path.
+//
+// Returns:
+//   - (bool): True if writing or reading one of the paths writes or reads the other (req and req.Path), false for sibling fields (req.Path and req.Name).
+//
+// -----------------------------------------------------------------------------
+func AccessPathsOverlap(a, b string) bool {
+	return IsAccessPathPrefix(a, b) || IsAccessPathPrefix(b, a)
+}
 
 // -----------------------------------------------------------------------------
-// IsAssignment - Checks if a variable is used in an assignment or declaration across multiple languages.
+// ContainsAccessPath - Checks if one of the access paths of a list overlaps a tracked path.
 // -----------------------------------------------------------------------------
 //
 // Parameters:
-//   - node (*sitter.Node): The node to check.
-//   - content ([]byte): The content of the source code.
-//   - variable (string): The variable to check for.
+//   - paths ([]string): The access paths, e.g. the right-hand side of an assignment.
+//   - path (string): The tracked access path.
 //
 // Returns:
-//   - (bool, string): True if the variable is found in the assignment, and the value assigned to it.
+//   - (bool): True if one of the paths reads or writes the tracked path.
 //
 // -----------------------------------------------------------------------------
-func IsAssignment(node *sitter.Node, content []byte, variable string) (bool, string) {
-	// Handle expression_statement nodes that may contain assignments
-	if node.Type() == "expression_statement" && node.NamedChildCount() > 0 {
-		return IsAssignment(node.NamedChild(0), content, variable)
+func ContainsAccessPath(paths []string, path string) bool {
+	for _, current := range paths {
+		if AccessPathsOverlap(current, path) {
+			return true
+		}
 	}
+	return false
+}
 
-	// Node types representing assignments and declarations in various languages
-	assignmentNodeTypes := map[string]bool{
-		// Assignments
-		"short_var_declaration":           true, // Go
-		"assignment_statement":            true, // Go
-		"assignment_expression":           true, // C, C++, Java, JavaScript, C#
-		"assignment":                      true, // Python, Ruby
-		"reference_assignment_expression": true, // PHP
-		// Declarations with initialization
-		"var_declaration":             true, // Go
-		"const_declaration":           true, // Go
-		"let_declaration":             true, // Rust
-		"static_item":                 true, // Rust
-		"declaration":                 true, // C, C++
-		"local_declaration_statement": true, // C#
-		"local_variable_declaration":  true, // Java
-		"lexical_declaration":         true, // JavaScript
-		"variable_declaration":        true, // JavaScript
+// -----------------------------------------------------------------------------
+// RebaseAccessPath - Moves the fields of a tracked path to the variable it is copied to or from.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - path (string): The tracked access path (e.g. req.Path).
+//   - from (string): The access path that is copied (e.g. req).
+//   - to (string): The access path receiving the copy (e.g. r).
+//
+// Returns:
+//   - (string): The tracked path in the copy (e.g. r.Path), or the receiving path if the tracked path does not go further than the copied one.
+//
+// -----------------------------------------------------------------------------
+func RebaseAccessPath(path, from, to string) string {
+	if to == "" || path == from || !IsAccessPathPrefix(from, path) {
+		return to
 	}
+	_, fromSegments := parseAccessPath(from)
+	_, segments := parseAccessPath(path)
+	return to + strings.Join(segments[len(fromSegments):], "")
+}
 
-	// Check if the node is an assignment or declaration
-	if !assignmentNodeTypes[node.Type()] {
-		return false, ""
+// -----------------------------------------------------------------------------
+// GetAssignmentValue - Returns the single value of an assignment or declaration.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): The assignment or declaration node.
+//   - content ([]byte): The content of the source code.
+//
+// Returns:
+//   - (*sitter.Node): The assigned expression (buildPath(base, name) in path := buildPath(base, name)), or nil if the
+//     assignment has no value or several values.
+//
+// -----------------------------------------------------------------------------
+func GetAssignmentValue(language string, node *sitter.Node, content []byte) *sitter.Node {
+	if languageService.IsSyntaxNode(language, node, languageService.SyntaxStatement) && node.NamedChildCount() > 0 {
+		return GetAssignmentValue(language, node.NamedChild(0), content)
 	}
 
-	// Variables to store left-hand side and right-hand side identifiers
-	var lhsVars []string
-	var rhsVars []string
-
-	// Extract variables based on node type
-	switch node.Type() {
-	case "short_var_declaration", "assignment_statement", "assignment_expression", "assignment", "reference_assignment_expression":
-		// Assignments with 'left' and 'right' fields
-		leftSide := node.ChildByFieldName("left")
-		rightSide := node.ChildByFieldName("right")
-
-		// Extract variables from left side
-		if leftSide != nil {
-			lhsVars = extractIdentifiers(leftSide, content)
-		}
-
-		// Extract variables from right side
-		if rightSide != nil {
-			rhsVars = extractIdentifiers(rightSide, content)
-		}
-	case "var_declaration", "const_declaration":
-		// Go var/const declarations
-		for i := 0; i < int(node.NamedChildCount()); i++ {
-			varSpec := node.NamedChild(i)
-			if varSpec.Type() == "var_spec" || varSpec.Type() == "const_spec" {
-				nameNode := varSpec.ChildByFieldName("name")
-				valueNode := varSpec.ChildByFieldName("value")
-				if nameNode != nil {
-					lhsVars = append(lhsVars, extractIdentifiers(nameNode, content)...)
-				}
-				if valueNode != nil {
-					rhsVars = append(rhsVars, extractIdentifiers(valueNode, content)...)
-				}
-			}
-		}
-	case "let_declaration":
-		// Rust let declarations
-		patternNode := node.ChildByFieldName("pattern")
-		valueNode := node.ChildByFieldName("value")
-		if patternNode != nil {
-			lhsVars = append(lhsVars, extractIdentifiers(patternNode, content)...)
-		}
-		if valueNode != nil {
-			rhsVars = append(rhsVars, extractIdentifiers(valueNode, content)...)
-		}
-	case "static_item":
-		// Rust static declarations
-		nameNode := node.ChildByFieldName("name")
-		valueNode := node.ChildByFieldName("value")
-		logger.PrintDebug("Static item name: %v", nameNode)
-		logger.PrintDebug("Static item value: %v", valueNode)
-
-		// Add the name to lhsVars (left-hand side)
-		if nameNode != nil {
-			lhsVars = append(lhsVars, extractIdentifiers(nameNode, content)...)
-		}
-
-		// Add identifiers from the value to rhsVars (right-hand side)
-		if valueNode != nil {
-			rhsVars = append(rhsVars, extractIdentifiers(valueNode, content)...)
-		}
-		logger.PrintDebug("Static item lhsVars: %v", lhsVars)
-		logger.PrintDebug("Static item rhsVars: %v", rhsVars)
-
-	case "declaration":
-		// C/C++ declarations
-		declarator := node.ChildByFieldName("declarator")
-		if declarator != nil {
-			var nameNode *sitter.Node
-			if declarator.Type() == "init_declarator" {
-				innerDeclarator := declarator.ChildByFieldName("declarator")
-				nameNode = findIdentifierInDeclarator(innerDeclarator)
-				valueNode := declarator.ChildByFieldName("value")
-				if valueNode != nil {
-					rhsVars = append(rhsVars, extractIdentifiers(valueNode, content)...)
-				}
-			} else {
-				// Handle cases where declarator is directly an identifier
-				nameNode = findIdentifierInDeclarator(declarator)
-			}
-			if nameNode != nil {
-				lhsVars = append(lhsVars, SafeContent(nameNode, content))
-			}
-		}
-
-	case "local_declaration_statement":
-		// C# local declarations
-		varDecl := node.NamedChild(0)
-		logger.PrintDebug("Local declaration statement: %v", varDecl)
-		if varDecl != nil && varDecl.Type() == "variable_declaration" {
-			for i := 0; i < int(varDecl.NamedChildCount()); i++ {
-				varDeclarator := varDecl.NamedChild(i)
-				if varDeclarator.Type() == "variable_declarator" {
-					nameNode := varDeclarator.ChildByFieldName("name")
-					valueNode := varDeclarator.ChildByFieldName("value")
-					// If valueNode is nil, check if there's an additional child
-					if valueNode == nil && varDeclarator.NamedChildCount() > 1 {
-						// Assume the second named child is the value node
-						valueNode = varDeclarator.NamedChild(1)
-					}
-					logger.PrintDebug("Name node: %v", nameNode)
-					logger.PrintDebug("Value node: %v", valueNode)
-					if nameNode != nil {
-						lhsVars = append(lhsVars, SafeContent(nameNode, content))
-						logger.PrintDebug("Added variable: %s", SafeContent(nameNode, content))
-					}
-					if valueNode != nil {
-						rhsVars = append(rhsVars, extractIdentifiers(valueNode, content)...)
-						logger.PrintDebug("Added value: %s", SafeContent(valueNode, content))
-					}
-				}
-			}
-		}
-
-	case "local_variable_declaration":
-		// Java local variable declarations
-		for i := 0; i < int(node.NamedChildCount()); i++ {
-			child := node.NamedChild(i)
-			if child.Type() == "variable_declarator" {
-				nameNode := child.ChildByFieldName("name")
-				valueNode := child.ChildByFieldName("value")
-				if nameNode != nil {
-					lhsVars = append(lhsVars, SafeContent(nameNode, content))
-				}
-				if valueNode != nil {
-					rhsVars = append(rhsVars, extractIdentifiers(valueNode, content)...)
-				}
-			}
-		}
-	case "lexical_declaration", "variable_declaration":
-		// JavaScript variable declarations
-		for i := 0; i < int(node.NamedChildCount()); i++ {
-			varDeclarator := node.NamedChild(i)
-			if varDeclarator.Type() == "variable_declarator" {
-				nameNode := varDeclarator.ChildByFieldName("name")
-				valueNode := varDeclarator.ChildByFieldName("value")
-				if nameNode != nil {
-					lhsVars = append(lhsVars, SafeContent(nameNode, content))
-				}
-				if valueNode != nil {
-					rhsVars = append(rhsVars, extractIdentifiers(valueNode, content)...)
-				}
-			}
+	if captures, ok := languageService.MatchQuery(language, languageService.QueryAssignments, node, content); ok {
+		if len(captures["rhs"]) != 1 {
+			return nil
 		}
+		return captures["rhs"][0]
 	}
-
-	// Check if the variable is on the left-hand side (assigned)
-	for _, lhsVar := range lhsVars {
-		if lhsVar == variable || (node.Type() == "static_item" && strings.EqualFold(lhsVar, variable)) {
-			assignedValue := ""
-			if len(rhsVars) > 0 {
-				assignedValue = rhsVars[0]
-			}
-			return true, assignedValue
+	for _, field := range []string{"right", "value"} {
+		if value := node.ChildByFieldName(field); value != nil {
+			return value
 		}
 	}
+	return nil
+}
 
-	// Check if the variable is on the right-hand side (used)
-	for _, rhsVar := range rhsVars {
-		if rhsVar == variable {
-			assignedVariable := ""
-			if len(lhsVars) > 0 {
-				assignedVariable = lhsVars[0]
-			}
-			return true, assignedVariable
-		}
+// -----------------------------------------------------------------------------
+// ArgumentRefersToVariable - Checks if a call argument passes a tracked variable or access path.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - argNode (*sitter.Node): The argument node.
+//   - content ([]byte): The content of the source code.
+//   - variable (string): The tracked variable or access path.
+//
+// Returns:
+//   - (bool): True if the argument is the tracked path, an object holding it or one of its fields.
+//
+// -----------------------------------------------------------------------------
+func ArgumentRefersToVariable(language string, argNode *sitter.Node, content []byte, variable string) bool {
+	// The value of keyword and spread arguments is passed, not their keyword (p in path=p or **p)
+	argNode = describeArgument(language, argNode, content).value
+	if path := GetAccessPath(language, argNode, content); path != "" {
+		return AccessPathsOverlap(path, variable)
 	}
-
-	return false, ""
+	return SafeContent(argNode, content) == variable
 }
 
 // -----------------------------------------------------------------------------
-// extractIdentifiers - Extracts identifier names from a node.
+// getEnclosingAccessPath - Returns the longest access path a variable is the start of.
 // -----------------------------------------------------------------------------
 //
 // Parameters:
-//   - node (*sitter.Node): The node to extract identifiers from.
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): The identifier of the variable.
 //   - content ([]byte): The content of the source code.
 //
 // Returns:
-//   - ([]string): A slice of identifier names.
+//   - (string): The access path of the member accesses and subscripts around the variable (req.Path for req in req.Path),
+//     the variable itself if it is used as a whole or as the object of a called method.
 //
 // -----------------------------------------------------------------------------
-func extractIdentifiers(node *sitter.Node, content []byte) []string {
-	var identifiers []string
-
-	if node == nil {
-		return identifiers
+func getEnclosingAccessPath(language string, node *sitter.Node, content []byte) string {
+	path := SafeContent(node, content)
+	for parent := node.Parent(); parent != nil && !isCalleeNode(language, parent); parent = parent.Parent() {
+		parentPath := GetAccessPath(language, parent, content)
+		if parentPath == "" || !IsAccessPathPrefix(path, parentPath) {
+			break
+		}
+		path = parentPath
 	}
+	return path
+}
 
-	nodeType := node.Type()
-
-	if nodeType == "identifier" || nodeType == "variable_name" || nodeType == "name" || nodeType == "constant" {
-		identifiers = append(identifiers, SafeContent(node, content))
-		return identifiers
+// -----------------------------------------------------------------------------
+// isAccessMemberNode - Checks if a node is the member name of a member access.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): The node to check.
+//
+// Returns:
+//   - (bool): True if the node names the member accessed by its parent (login in libuser.login), not a variable.
+//
+// -----------------------------------------------------------------------------
+func isAccessMemberNode(language string, node *sitter.Node) bool {
+	parent := node.Parent()
+	if parent == nil {
+		return false
 	}
-
-	// Handle pointers, references, and declarators in C/C++
-	if nodeType == "pointer_declarator" || nodeType == "reference_declarator" || nodeType == "array_declarator" {
-		if node.ChildCount() > 0 {
-			child := node.Child(0)
-			identifiers = append(identifiers, extractIdentifiers(child, content)...)
-		}
-		return identifiers
+	if languageService.IsSyntaxNode(language, parent, languageService.SyntaxMemberSuffix) {
+		return true
 	}
-
-	// Handle Rust static items
-	if nodeType == "static_item" {
-		// Extract the name of the static variable
-		nameNode := node.ChildByFieldName("name")
-		if nameNode != nil {
-			identifiers = append(identifiers, SafeContent(nameNode, content))
-		}
-		// Extract identifiers from the value if necessary (e.g., complex expressions)
-		valueNode := node.ChildByFieldName("value")
-		if valueNode != nil {
-			identifiers = append(identifiers, extractIdentifiers(valueNode, content)...)
-		}
-		return identifiers
+	if !languageService.IsSyntaxNode(language, parent, languageService.SyntaxMemberAccess) {
+		return false
 	}
-
-	// Handle Rust patterns
-	if nodeType == "pattern" || nodeType == "mutable_specifier" {
-		for i := 0; i < int(node.NamedChildCount()); i++ {
-			child := node.NamedChild(i)
-			identifiers = append(identifiers, extractIdentifiers(child, content)...)
+	for _, field := range languageService.GetFieldNames(language, languageService.FieldMember) {
+		if member := parent.ChildByFieldName(field); member != nil {
+			return member.Equal(node)
 		}
-		return identifiers
-	}
-
-	// Recursively extract identifiers from children
-	for i := 0; i < int(node.NamedChildCount()); i++ {
-		child := node.NamedChild(i)
-		identifiers = append(identifiers, extractIdentifiers(child, content)...)
 	}
-
-	return identifiers
+	return false
 }
 
 // -----------------------------------------------------------------------------
-// IsVariableUsedInExpression - Checks if a variable is used in an expression within a node.
+// isCalleeNode - Checks if a node is the function called by its parent.
 // -----------------------------------------------------------------------------
 //
 // Parameters:
+//   - language (string): The language of the syntax tree.
 //   - node (*sitter.Node): The node to check.
-//   - variable (string): The variable to check for.
-//   - content ([]byte): The content of the source code.
 //
 // Returns:
-//   - (bool): True if the variable is used in the expression, false otherwise.
+//   - (bool): True if the parent is a call and the node is its function (e.g. r.Read in r.Read(buf)).
 //
 // -----------------------------------------------------------------------------
-func IsVariableUsedInExpression(node *sitter.Node, variable string, content []byte) bool {
-	if node == nil {
+func isCalleeNode(language string, node *sitter.Node) bool {
+	parent := node.Parent()
+	if parent == nil || !languageService.IsCallNode(language, parent) {
 		return false
 	}
+	callee := parent.ChildByFieldName("function")
+	if callee == nil {
+		callee = parent.NamedChild(0)
+	}
+	return callee != nil && callee.Equal(node)
+}
 
-	switch node.Type() {
-	case "identifier", "variable_name", "name":
-		// Extraire le texte du nœud et comparer avec la variable
-		identifier := string(content[node.StartByte():node.EndByte()])
-		return identifier == variable
-	default:
-		// Parcourir récursivement tous les enfants du nœud
-		for i := 0; i < int(node.ChildCount()); i++ {
-			child := node.Child(i)
-			if IsVariableUsedInExpression(child, variable, content) {
-				return true
-			}
-		}
+// -----------------------------------------------------------------------------
+// isCalledScopedName - Checks if a node is a part of the qualified name of a called function.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): The node to check.
+//
+// Returns:
+//   - (bool): True if the node is the module, the type or the name of a called qualified name (fs and read_to_string
+//     in fs::read_to_string(path)).
+//
+// -----------------------------------------------------------------------------
+func isCalledScopedName(language string, node *sitter.Node) bool {
+	scoped := node
+	for scoped.Parent() != nil && languageService.IsSyntaxNode(language, scoped.Parent(), languageService.SyntaxScopedName) {
+		scoped = scoped.Parent()
 	}
-	return false
+	return !scoped.Equal(node) && isCalleeNode(language, scoped)
 }
 
 // -----------------------------------------------------------------------------
-// IsVariableInScope - Checks if the given variable is in scope within the provided function node.
+// isInstantiatedType - Checks if a node is the type an instantiation creates.
 // -----------------------------------------------------------------------------
 //
 // Parameters:
-//   - varName (string): The name of the variable to check.
-//   - functionNode (*sitter.Node): The function node to check within.
-//   - content ([]byte): The content of the source code.
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): The node to check.
 //
 // Returns:
-//   - (bool): True if the variable is in scope, false otherwise.
+//   - (bool): True if the parent is an instantiation and the node its type (URLSearchParams in new URLSearchParams(query)).
 //
 // -----------------------------------------------------------------------------
-func IsVariableInScope(varName string, functionNode *sitter.Node, content []byte) bool {
-	if functionNode == nil {
+func isInstantiatedType(language string, node *sitter.Node) bool {
+	parent := node.Parent()
+	if parent == nil || !languageService.IsSyntaxNode(language, parent, languageService.SyntaxInstantiation) {
 		return false
 	}
-
-	// Vérifier si la variable est définie dans les paramètres de la fonction
-	parameters := functionNode.ChildByFieldName("parameters")
-	if parameters != nil {
-		for i := 0; i < int(parameters.NamedChildCount()); i++ {
-			param := parameters.NamedChild(i)
-			paramName := extractParameterName(param, content)
-			if paramName == varName {
-				return true // La variable est un paramètre de la fonction
-			}
+	for _, field := range []string{"type", "constructor", "name"} {
+		if typeNode := parent.ChildByFieldName(field); typeNode != nil {
+			return typeNode.Equal(node)
 		}
 	}
+	return false
+}
 
-	// Vérifier si la variable est définie dans les déclarations locales
-	var traverse func(*sitter.Node) bool
-	traverse = func(n *sitter.Node) bool {
-		if n == nil {
-			return false
-		}
-
-		// Vérifier les déclarations locales (e.g. short_var_declaration, local_variable_declaration, etc.)
-		switch n.Type() {
-		case "short_var_declaration", "local_variable_declaration", "assignment_statement":
-			leftSide := n.ChildByFieldName("left")
-			if leftSide != nil {
-				for i := 0; i < int(leftSide.NamedChildCount()); i++ {
-					child := leftSide.NamedChild(i)
-					if child.Type() == "identifier" || child.Type() == "variable_name" {
-						varNameInNode := SafeContent(child, content)
-						if varNameInNode == varName {
-							return true
-						}
-					}
-				}
-			}
-		}
-
-		// Parcourir récursivement les enfants
-		for i := 0; i < int(n.NamedChildCount()); i++ {
-			if traverse(n.NamedChild(i)) {
-				return true
-			}
+// -----------------------------------------------------------------------------
+// getAccessObject - Returns the object of a member access.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): The member access node.
+//
+// Returns:
+//   - (*sitter.Node): The object whose member is accessed, or nil if the node is not a member access.
+//
+// -----------------------------------------------------------------------------
+func getAccessObject(language string, node *sitter.Node) *sitter.Node {
+	if !languageService.IsSyntaxNode(language, node, languageService.SyntaxMemberAccess) {
+		return nil
+	}
+	for _, field := range languageService.GetFieldNames(language, languageService.FieldObject) {
+		if object := node.ChildByFieldName(field); object != nil {
+			return object
 		}
-		return false
 	}
-
-	// Vérifier dans le corps de la fonction
-	body := functionNode.ChildByFieldName("body")
-	if body != nil {
-		return traverse(body)
+	// Kotlin puts the object before the suffix naming the member
+	if getMemberSuffix(language, node) != nil {
+		return node.NamedChild(0)
 	}
-
-	return false
+	return nil
 }
 
 /**** Variable Global Functions ****/
@@ -1657,17 +4393,20 @@ func IsVariableInScope(varName string, functionNode *sitter.Node, content []byte
 // -----------------------------------------------------------------------------
 //
 // Parameters:
+//   - language (string): The language of the syntax tree.
 //   - dataFlow ([]models.DataFlowStep): The data flow steps to process.
 //   - root (*sitter.Node): The root node of the syntax tree.
 //   - content ([]byte): The content of the source code.
 //   - lineNumber (uint32): The line number to use for generic usage steps.
+//   - log (*logger.Logger): The logger of the analysis.
 //
 // Returns:
-//   - ([]models.DataFlowStep): The updated data flow steps with global variable declaration steps.
+//   - ([]models.DataFlowStep): The updated data flow steps with global variable declaration steps, one per declaration
+//     line.
 //
 // -----------------------------------------------------------------------------
-func AddGlobalVariableSteps(dataFlow []models.DataFlowStep, root *sitter.Node, content []byte, lineNumber uint32) []models.DataFlowStep {
-	logger.PrintDebug("Extracting unique variables from data flow steps.")
+func AddGlobalVariableSteps(language string, dataFlow []models.DataFlowStep, root *sitter.Node, content []byte, lineNumber uint32, log *logger.Logger) []models.DataFlowStep {
+	log.PrintDebug("Extracting unique variables from data flow steps.")
 	processedVariables := make(map[string]string) // Tracks processed variables (lowercase -> original case)
 
 	// Collect all unique variables from the data flow steps
@@ -1675,40 +4414,53 @@ func AddGlobalVariableSteps(dataFlow []models.DataFlowStep, root *sitter.Node, c
 		lowercaseVariable := strings.ToLower(step.Variable)
 		if original, exists := processedVariables[lowercaseVariable]; !exists {
 			processedVariables[lowercaseVariable] = step.Variable
-			logger.PrintDebug("Added unique variable '%s' (original case: '%s') for processing.", lowercaseVariable, step.Variable)
+			log.PrintDebug("Added unique variable '%s' (original case: '%s') for processing.", lowercaseVariable, step.Variable)
 		} else if original != step.Variable {
-			logger.PrintWarning("Detected case-insensitive duplicate variables: '%s' and '%s'.", original, step.Variable)
+			log.PrintWarning("Detected case-insensitive duplicate variables: '%s' and '%s'.", original, step.Variable)
 		}
 	}
 
 	// Variables to add to the final dataFlow
 	var finalSteps []models.DataFlowStep
+	declaredLines := make(map[uint32]bool)
 
-	// Process each unique lowercase variable
-	for lowercaseVariable, originalCaseVariable := range processedVariables {
-		logger.PrintDebug("Checking if global variable step is needed for '%s' (original case: '%s').", lowercaseVariable, originalCaseVariable)
-		isOriginalGlobal := IsVariableGlobal(root, originalCaseVariable, content)
-		isLowercaseGlobal := IsVariableGlobal(root, lowercaseVariable, content)
+	// Process each unique lowercase variable, in order
+	lowercaseVariables := make([]string, 0, len(processedVariables))
+	for lowercaseVariable := range processedVariables {
+		lowercaseVariables = append(lowercaseVariables, lowercaseVariable)
+	}
+	sort.Strings(lowercaseVariables)
+	for _, lowercaseVariable := range lowercaseVariables {
+		originalCaseVariable := processedVariables[lowercaseVariable]
+		log.PrintDebug("Checking if global variable step is needed for '%s' (original case: '%s').", lowercaseVariable, originalCaseVariable)
+		isOriginalGlobal := IsVariableGlobal(language, root, originalCaseVariable, content, log)
+		isLowercaseGlobal := IsVariableGlobal(language, root, lowercaseVariable, content, log)
 
 		// Determine which variable to add based on global detection
 		if isOriginalGlobal && isLowercaseGlobal {
-			logger.PrintDebug("Both '%s' and '%s' are global. Keeping lowercase version: '%s'.", originalCaseVariable, lowercaseVariable, lowercaseVariable)
+			log.PrintDebug("Both '%s' and '%s' are global. Keeping lowercase version: '%s'.", originalCaseVariable, lowercaseVariable, lowercaseVariable)
 			originalCaseVariable = lowercaseVariable // Prefer lowercase if both are global
 		} else if isLowercaseGlobal {
-			logger.PrintDebug("Only lowercase '%s' is global. Adding this version.", lowercaseVariable)
+			log.PrintDebug("Only lowercase '%s' is global. Adding this version.", lowercaseVariable)
 			originalCaseVariable = lowercaseVariable
 		} else if isOriginalGlobal {
-			logger.PrintDebug("Only original case '%s' is global. Adding this version.", originalCaseVariable)
+			log.PrintDebug("Only original case '%s' is global. Adding this version.", originalCaseVariable)
 		} else {
-			logger.PrintDebug("Neither '%s' nor '%s' is global. Skipping.", lowercaseVariable, originalCaseVariable)
+			log.PrintDebug("Neither '%s' nor '%s' is global. Skipping.", lowercaseVariable, originalCaseVariable)
 			continue // Skip if neither is global
 		}
 
 		// Add the detected global variable to the data flow
-		globalVariableNode := FindGlobalVariableDeclaration(root, originalCaseVariable, content)
+		globalVariableNode := FindGlobalVariableDeclaration(language, root, originalCaseVariable, content, log)
 		if globalVariableNode != nil {
 			line := globalVariableNode.StartPoint().Row + 1
-			logger.PrintInfo("Adding global variable declaration step for '%s' at line %d in node type '%s'.", originalCaseVariable, line, globalVariableNode.Type())
+			if declaredLines[line] {
+				// The tracked variables declared together (x and x["key"]) share the step of their declaration
+				log.PrintDebug("Global variable declaration at line %d already added. Skipping '%s'.", line, originalCaseVariable)
+				continue
+			}
+			declaredLines[line] = true
+			log.PrintInfo("Adding global variable declaration step for '%s' at line %d in node type '%s'.", originalCaseVariable, line, globalVariableNode.Type())
 			finalSteps = append(finalSteps, models.DataFlowStep{
 				Line:     line,
 				Type:     "Global Variable Declaration",
@@ -1717,7 +4469,7 @@ func AddGlobalVariableSteps(dataFlow []models.DataFlowStep, root *sitter.Node, c
 				Variable: originalCaseVariable,
 			})
 		} else {
-			logger.PrintWarning("Global variable node not found for '%s'. Adding generic usage step at line %d.", originalCaseVariable, lineNumber)
+			log.PrintWarning("Global variable node not found for '%s'. Adding generic usage step at line %d.", originalCaseVariable, lineNumber)
 			finalSteps = append(finalSteps, models.DataFlowStep{
 				Line:     lineNumber,
 				Type:     "Global Variable Usage",
@@ -1737,17 +4489,19 @@ func AddGlobalVariableSteps(dataFlow []models.DataFlowStep, root *sitter.Node, c
 // -----------------------------------------------------------------------------
 //
 // Parameters:
+//   - language (string): The language of the syntax tree.
 //   - root (*sitter.Node): The root node of the syntax tree.
 //   - variable (string): The name of the variable to search for.
 //   - content ([]byte): The content of the source code.
+//   - log (*logger.Logger): The logger of the analysis.
 //
 // Returns:
 //   - (*sitter.Node): The node representing the global variable declaration if found, otherwise nil.
 //
 // -----------------------------------------------------------------------------
-func FindGlobalVariableDeclaration(root *sitter.Node, variable string, content []byte) *sitter.Node {
+func FindGlobalVariableDeclaration(language string, root *sitter.Node, variable string, content []byte, log *logger.Logger) *sitter.Node {
 	if root == nil {
-		logger.PrintDebug("Root node is nil, skipping global variable search for '%s'.", variable)
+		log.PrintDebug("Root node is nil, skipping global variable search for '%s'.", variable)
 		return nil
 	}
 
@@ -1761,40 +4515,36 @@ func FindGlobalVariableDeclaration(root *sitter.Node, variable string, content [
 		nodeType := node.Type()
 
 		// Determine if we're entering a new scope
-		switch nodeType {
-		case "function_definition", "method_declaration", "method_declaration_header",
-			"function_declaration", "anonymous_function_creation_expression", "method", "function_item":
+		if languageService.IsFunctionScopeNode(language, node) {
 			inFunctionScope = true
 			return
-		case "class_declaration", "namespace_name", "interface_declaration", "trait_declaration":
-			logger.PrintDebug("Entering class or namespace scope: '%s'.", nodeType)
+		}
+		if languageService.IsClassScopeNode(language, node) {
+			log.PrintDebug("Entering class or namespace scope: '%s'.", nodeType)
 			inClassScope = true
 		}
 
 		if !inFunctionScope && !inClassScope {
-			// Handle PHP assignments
-			if models.GlobalLanguage == "php" && nodeType == "assignment_expression" {
-				leftNode := node.ChildByFieldName("left")
-				if leftNode != nil && leftNode.Type() == "variable_name" {
-					// Instead of using ChildByFieldName("name"), access the first named child
-					varNameNode := leftNode.NamedChild(0)
-					if varNameNode != nil && varNameNode.Type() == "name" {
-						varName := SafeContent(varNameNode, content)
-						logger.PrintDebug("Checking PHP assignment for global variable '%s'.", varName)
-						if strings.EqualFold(varName, variable) {
-							logger.PrintDebug("Global variable '%s' assigned at line %d in node type '%s'.",
-								variable, node.StartPoint().Row+1, nodeType)
-							result = node
-							return
-						}
+			// Handle PHP assignments, whose variables are written with a sigil
+			leftNode := node.ChildByFieldName("left")
+			if languageService.GetControlKind(language, node) == languageService.ControlAssignment && leftNode != nil && languageService.IsSyntaxNode(language, leftNode, languageService.SyntaxSigilVariable) {
+				// Instead of using ChildByFieldName("name"), access the first named child
+				varNameNode := leftNode.NamedChild(0)
+				if varNameNode != nil && languageService.IsSyntaxNode(language, varNameNode, languageService.SyntaxIdentifier) {
+					varName := SafeContent(varNameNode, content)
+					log.PrintDebug("Checking PHP assignment for global variable '%s'.", varName)
+					if strings.EqualFold(varName, variable) {
+						log.PrintDebug("Global variable '%s' assigned at line %d in node type '%s'.",
+							variable, node.StartPoint().Row+1, nodeType)
+						result = node
+						return
 					}
 				}
-
 			} else {
 				// Use IsAssignment for other languages
-				isAssign, _ := IsAssignment(node, content, variable)
+				isAssign, _ := IsAssignment(language, node, content, variable)
 				if isAssign {
-					logger.PrintDebug("Global variable '%s' declared at line %d in node type '%s'.",
+					log.PrintDebug("Global variable '%s' declared at line %d in node type '%s'.",
 						variable, node.StartPoint().Row+1, nodeType)
 					result = node
 					return
@@ -1802,10 +4552,10 @@ func FindGlobalVariableDeclaration(root *sitter.Node, variable string, content [
 			}
 		} else if inClassScope && !inFunctionScope {
 			// For class field declarations
-			if isClassStaticVariableDeclarationNode(node) {
-				isAssign := IsClassFieldAssignment(node, content, variable)
+			if isClassStaticVariableDeclarationNode(language, node) {
+				isAssign := IsClassFieldAssignment(language, node, content, variable, log)
 				if isAssign {
-					logger.PrintDebug("Global class variable '%s' declared at line %d in node type '%s'.",
+					log.PrintDebug("Global class variable '%s' declared at line %d in node type '%s'.",
 						variable, node.StartPoint().Row+1, nodeType)
 					result = node
 					return
@@ -1823,10 +4573,10 @@ func FindGlobalVariableDeclaration(root *sitter.Node, variable string, content [
 		}
 	}
 
-	logger.PrintDebug("Starting traversal to find global variable '%s'.", variable)
+	log.PrintDebug("Starting traversal to find global variable '%s'.", variable)
 	traverse(root, false, false)
 	if result == nil {
-		logger.PrintDebug("Global variable '%s' not found at the global scope.", variable)
+		log.PrintDebug("Global variable '%s' not found at the global scope.", variable)
 	}
 	return result
 }
@@ -1836,25 +4586,15 @@ func FindGlobalVariableDeclaration(root *sitter.Node, variable string, content [
 // -----------------------------------------------------------------------------
 //
 // Parameters:
+//   - language (string): The language of the syntax tree.
 //   - node (*sitter.Node): The node to check.
 //
 // Returns:
 //   - (bool): True if the node represents a class field declaration, false otherwise.
 //
 // -----------------------------------------------------------------------------
-func isClassStaticVariableDeclarationNode(node *sitter.Node) bool {
-	nodeType := node.Type()
-
-	switch nodeType {
-	case
-		"field_declaration",    // Java, C#
-		"field_declarator",     // Java, C#
-		"constant_declaration", // C#
-		"property_declaration": // C#
-		return true
-	default:
-		return false
-	}
+func isClassStaticVariableDeclarationNode(language string, node *sitter.Node) bool {
+	return languageService.IsSyntaxNode(language, node, languageService.SyntaxFieldDeclaration)
 }
 
 // -----------------------------------------------------------------------------
@@ -1862,41 +4602,41 @@ func isClassStaticVariableDeclarationNode(node *sitter.Node) bool {
 // -----------------------------------------------------------------------------
 //
 // Parameters:
+//   - language (string): The language of the syntax tree.
 //   - node (*sitter.Node): The node to check.
 //   - content ([]byte): The content of the source code.
 //   - variable (string): The variable to check for.
+//   - log (*logger.Logger): The logger of the analysis.
 //
 // Returns:
 //   - (bool): True if the node represents an assignment to the class field, false otherwise.
 //
 // -----------------------------------------------------------------------------
-func IsClassFieldAssignment(node *sitter.Node, content []byte, variable string) bool {
+func IsClassFieldAssignment(language string, node *sitter.Node, content []byte, variable string, log *logger.Logger) bool {
 	nodeType := node.Type()
-	logger.PrintDebug("IsClassFieldAssignment: nodeType='%s'", nodeType)
-	if nodeType == "field_declaration" || nodeType == "property_declaration" || nodeType == "constant_declaration" {
+	log.PrintDebug("IsClassFieldAssignment: nodeType='%s'", nodeType)
+	if isClassStaticVariableDeclarationNode(language, node) {
 		var nameNode *sitter.Node
 
 		// Handle Java syntax
-		if models.GlobalLanguage == "java" {
-			declaratorNode := node.ChildByFieldName("declarator")
-			if declaratorNode != nil && declaratorNode.Type() == "variable_declarator" {
-				nameNode = declaratorNode.ChildByFieldName("name")
-			}
+		declaratorNode := node.ChildByFieldName("declarator")
+		if declaratorNode != nil && languageService.IsSyntaxNode(language, declaratorNode, languageService.SyntaxDeclarator) {
+			nameNode = declaratorNode.ChildByFieldName("name")
 		}
 
 		// Handle C# syntax
-		if models.GlobalLanguage == "csharp" {
+		if nameNode == nil {
 			// Navigate through the children to locate `variable_declaration`
 			for i := 0; i < int(node.NamedChildCount()); i++ {
 				child := node.NamedChild(i)
-				if child.Type() == "variable_declaration" {
+				if languageService.IsAssignmentNode(language, child) {
 					for j := 0; j < int(child.NamedChildCount()); j++ {
 						variableDeclarator := child.NamedChild(j)
-						if variableDeclarator.Type() == "variable_declarator" {
+						if languageService.IsSyntaxNode(language, variableDeclarator, languageService.SyntaxDeclarator) {
 							nameNode = variableDeclarator.ChildByFieldName("name")
 							if nameNode != nil {
 								varName := SafeContent(nameNode, content)
-								logger.PrintDebug("Found class field variable '%s'.", varName)
+								log.PrintDebug("Found class field variable '%s'.", varName)
 								if strings.EqualFold(varName, variable) {
 									return true
 								}
@@ -1909,7 +4649,7 @@ func IsClassFieldAssignment(node *sitter.Node, content []byte, variable string)
 
 		if nameNode != nil {
 			varName := SafeContent(nameNode, content)
-			logger.PrintDebug("Found class field variable '%s'.", varName)
+			log.PrintDebug("Found class field variable '%s'.", varName)
 			if strings.EqualFold(varName, variable) {
 				return true
 			}
@@ -1923,21 +4663,23 @@ func IsClassFieldAssignment(node *sitter.Node, content []byte, variable string)
 // -----------------------------------------------------------------------------
 //
 // Parameters:
+//   - language (string): The language of the syntax tree.
 //   - root (*sitter.Node): The root node of the syntax tree.
 //   - variable (string): The name of the variable to check.
 //   - content ([]byte): The content of the source code.
+//   - log (*logger.Logger): The logger of the analysis.
 //
 // Returns:
 //   - (bool): True if the variable is global, false otherwise.
 //
 // -----------------------------------------------------------------------------
-func IsVariableGlobal(root *sitter.Node, variable string, content []byte) bool {
-	logger.PrintDebug("Checking if variable '%s' is global.", variable)
-	globalDecl := FindGlobalVariableDeclaration(root, variable, content)
+func IsVariableGlobal(language string, root *sitter.Node, variable string, content []byte, log *logger.Logger) bool {
+	log.PrintDebug("Checking if variable '%s' is global.", variable)
+	globalDecl := FindGlobalVariableDeclaration(language, root, variable, content, log)
 	if globalDecl != nil {
-		logger.PrintInfo("Variable '%s' is global. Found at line %d in node type '%s'.", variable, globalDecl.StartPoint().Row+1, globalDecl.Type())
+		log.PrintDebug("Variable '%s' is global. Found at line %d in node type '%s'.", variable, globalDecl.StartPoint().Row+1, globalDecl.Type())
 		return true
 	}
-	logger.PrintInfo("Variable '%s' is not global.", variable)
+	log.PrintDebug("Variable '%s' is not global.", variable)
 	return false
 }
@@ -0,0 +1,362 @@
+// Functions that write the result of an analysis in the output formats of the command line (JSON, NDJSON, text, table).
+
+package outputService
+
+import (
+	"dataflow/models"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// -----------------------------------------------------------------------------
+// IsSupportedFormat - Checks if an output format is supported.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - format (string): The output format to check.
+//
+// Returns:
+//   - (bool): True if the format is json, ndjson, text or table.
+//
+// -----------------------------------------------------------------------------
+func IsSupportedFormat(format string) bool {
+	switch format {
+	case models.OutputFormatJSON, models.OutputFormatNDJSON, models.OutputFormatText, models.OutputFormatTable:
+		return true
+	}
+	return false
+}
+
+// -----------------------------------------------------------------------------
+// WriteOutput - Writes the result of an analysis in the requested format.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - writer (io.Writer): The destination of the output (standard output or a file).
+//   - format (string): The output format (json, ndjson, text or table).
+//   - output (models.AnalysisOutput): The result of the analysis.
+//
+// Returns:
+//   - (error): An error object if the format is not supported or the output could not be written.
+//
+// -----------------------------------------------------------------------------
+func WriteOutput(writer io.Writer, format string, output models.AnalysisOutput) error {
+	switch format {
+	case models.OutputFormatJSON:
+		return writeJSON(writer, output)
+	case models.OutputFormatNDJSON:
+		return writeNDJSON(writer, output)
+	case models.OutputFormatText:
+		return writeText(writer, output)
+	case models.OutputFormatTable:
+		return writeTable(writer, output)
+	}
+	return fmt.Errorf("unsupported output format: %s", format)
+}
+
+// -----------------------------------------------------------------------------
+// WriteVariables - Writes the candidates detected on a line in the requested format.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - writer (io.Writer): The destination of the output (standard output or a file).
+//   - format (string): The output format (json, ndjson, text or table).
+//   - candidates ([]models.IdentifyVariableResponse): The identifiers and literals of the line.
+//
+// Returns:
+//   - (error): An error object if the format is not supported or the output could not be written.
+//
+// -----------------------------------------------------------------------------
+func WriteVariables(writer io.Writer, format string, candidates []models.IdentifyVariableResponse) error {
+	switch format {
+	case models.OutputFormatJSON:
+		if candidates == nil {
+			candidates = []models.IdentifyVariableResponse{}
+		}
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(candidates)
+	case models.OutputFormatNDJSON:
+		encoder := json.NewEncoder(writer)
+		for _, candidate := range candidates {
+			if err := encoder.Encode(candidate); err != nil {
+				return err
+			}
+		}
+		return nil
+	case models.OutputFormatText:
+		for _, candidate := range candidates {
+			if _, err := fmt.Fprintf(writer, "%s (%s)\n", candidate.VariableOrValue, candidate.Kind); err != nil {
+				return err
+			}
+		}
+		return nil
+	case models.OutputFormatTable:
+		table := tabwriter.NewWriter(writer, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(table, "NAME\tKIND\tVARIABLE")
+		for _, candidate := range candidates {
+			fmt.Fprintf(table, "%s\t%s\t%t\n", candidate.VariableOrValue, candidate.Kind, candidate.IsVariable)
+		}
+		return table.Flush()
+	}
+	return fmt.Errorf("unsupported output format: %s", format)
+}
+
+// -----------------------------------------------------------------------------
+// writeJSON - Writes the result of an analysis as a single indented JSON document.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - writer (io.Writer): The destination of the output.
+//   - output (models.AnalysisOutput): The result of the analysis.
+//
+// Returns:
+//   - (error): An error object if the output could not be encoded.
+//
+// -----------------------------------------------------------------------------
+func writeJSON(writer io.Writer, output models.AnalysisOutput) error {
+	if output.Dataflow == nil {
+		output.Dataflow = []models.DataFlow{}
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(output); err != nil {
+		return fmt.Errorf("error encoding JSON output: %v", err)
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// writeNDJSON - Writes one JSON record per line: the data flow steps, then the raw steps if any.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - writer (io.Writer): The destination of the output.
+//   - output (models.AnalysisOutput): The result of the analysis.
+//
+// Returns:
+//   - (error): An error object if a record could not be encoded.
+//
+// -----------------------------------------------------------------------------
+func writeNDJSON(writer io.Writer, output models.AnalysisOutput) error {
+	encoder := json.NewEncoder(writer)
+
+	// The "kind" field tells the data flow records from the raw step and parse diagnostic records
+	for _, step := range output.Dataflow {
+		record := struct {
+			Kind string `json:"kind"`
+			models.DataFlow
+		}{Kind: "dataflow", DataFlow: step}
+		if err := encoder.Encode(record); err != nil {
+			return fmt.Errorf("error encoding NDJSON output: %v", err)
+		}
+	}
+
+	for _, step := range output.Steps {
+		record := struct {
+			Kind string `json:"kind"`
+			models.DataFlowStep
+		}{Kind: "step", DataFlowStep: step}
+		if err := encoder.Encode(record); err != nil {
+			return fmt.Errorf("error encoding NDJSON output: %v", err)
+		}
+	}
+
+	for _, diagnostic := range output.Diagnostics {
+		// The diagnostic has its own kind (error or missing)
+		record := struct {
+			Kind       string                 `json:"kind"`
+			Diagnostic models.ParseDiagnostic `json:"diagnostic"`
+		}{Kind: "diagnostic", Diagnostic: diagnostic}
+		if err := encoder.Encode(record); err != nil {
+			return fmt.Errorf("error encoding NDJSON output: %v", err)
+		}
+	}
+
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// writeText - Writes the result of an analysis as a readable list of steps.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - writer (io.Writer): The destination of the output.
+//   - output (models.AnalysisOutput): The result of the analysis.
+//
+// Returns:
+//   - (error): An error object if the output could not be written.
+//
+// -----------------------------------------------------------------------------
+func writeText(writer io.Writer, output models.AnalysisOutput) error {
+	var builder strings.Builder
+
+	fmt.Fprintf(&builder, "Data flow of '%s' (%s, %s)\n", output.Variable, output.Language, output.Direction)
+	if output.TaintStatus != "" {
+		fmt.Fprintf(&builder, "Taint status: %s\n", output.TaintStatus)
+	}
+
+	for _, step := range output.Dataflow {
+		fmt.Fprintf(&builder, "\n#%d %s:%d %s", step.Order, stepPath(step.Path, step.Cell), step.Line, step.Type)
+		if step.TaintRole != "" {
+			fmt.Fprintf(&builder, " [%s]", step.TaintRole)
+		}
+		if step.ParseError {
+			builder.WriteString(" (inside a parse error)")
+		}
+		if len(step.Candidates) > 0 {
+			fmt.Fprintf(&builder, " (ambiguous call: %s)", strings.Join(step.Candidates, ", "))
+		}
+		builder.WriteString("\n")
+		if code := stepCode(step); code != "" {
+			fmt.Fprintf(&builder, "    %s\n", strings.TrimSpace(code))
+		}
+	}
+
+	if len(output.Steps) > 0 {
+		builder.WriteString("\nRaw steps:\n")
+		for i, step := range output.Steps {
+			location := fmt.Sprintf("line %d", step.Line)
+			if step.Cell != nil {
+				location = fmt.Sprintf("cell %d line %d", *step.Cell, step.Line)
+			}
+			fmt.Fprintf(&builder, "  %d. %s %s (variable: %s, function: %s)\n", i+1, location, step.Type, step.Variable, step.Function)
+		}
+	}
+
+	if len(output.Diagnostics) > 0 {
+		builder.WriteString("\nParse errors:\n")
+		for _, diagnostic := range output.Diagnostics {
+			fmt.Fprintf(&builder, "  %s %s\n", diagnosticLocation(diagnostic), diagnosticMessage(diagnostic))
+		}
+	}
+
+	_, err := io.WriteString(writer, builder.String())
+	return err
+}
+
+// -----------------------------------------------------------------------------
+// writeTable - Writes the data flow steps as an aligned table.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - writer (io.Writer): The destination of the output.
+//   - output (models.AnalysisOutput): The result of the analysis.
+//
+// Returns:
+//   - (error): An error object if the table could not be written.
+//
+// -----------------------------------------------------------------------------
+func writeTable(writer io.Writer, output models.AnalysisOutput) error {
+	table := tabwriter.NewWriter(writer, 0, 4, 2, ' ', 0)
+
+	fmt.Fprintln(table, "ORDER\tFILE\tLINE\tTYPE\tROLE\tCODE")
+	for _, step := range output.Dataflow {
+		role := step.TaintRole
+		if role == "" {
+			role = "-"
+		}
+		fmt.Fprintf(table, "%d\t%s\t%d\t%s\t%s\t%s\n", step.Order, stepPath(step.Path, step.Cell), step.Line, step.Type, role, strings.TrimSpace(stepCode(step)))
+	}
+
+	if len(output.Steps) > 0 {
+		fmt.Fprintln(table)
+		fmt.Fprintln(table, "STEP\tFILE\tLINE\tTYPE\tVARIABLE\tFUNCTION")
+		for i, step := range output.Steps {
+			fmt.Fprintf(table, "%d\t%s\t%d\t%s\t%s\t%s\n", i+1, stepPath(step.FilePath, step.Cell), step.Line, step.Type, step.Variable, step.Function)
+		}
+	}
+
+	if len(output.Diagnostics) > 0 {
+		fmt.Fprintln(table)
+		fmt.Fprintln(table, "PARSE ERROR\tLOCATION\tMESSAGE")
+		for i, diagnostic := range output.Diagnostics {
+			fmt.Fprintf(table, "%d\t%s\t%s\n", i+1, diagnosticLocation(diagnostic), diagnosticMessage(diagnostic))
+		}
+	}
+
+	return table.Flush()
+}
+
+// -----------------------------------------------------------------------------
+// stepPath - Returns the file of a data flow step, with its cell in a notebook.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - path (string): The path of the file of the step.
+//   - cell (*int): The cell of the step in a notebook, or nil.
+//
+// Returns:
+//   - (string): The path, followed by the cell (e.g. "analysis.ipynb[cell 3]") in a notebook.
+//
+// -----------------------------------------------------------------------------
+func stepPath(path string, cell *int) string {
+	if cell == nil {
+		return path
+	}
+	return fmt.Sprintf("%s[cell %d]", path, *cell)
+}
+
+// -----------------------------------------------------------------------------
+// diagnosticLocation - Returns the location of a parse diagnostic.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - diagnostic (models.ParseDiagnostic): The parse diagnostic.
+//
+// Returns:
+//   - (string): The line and column of the diagnostic (e.g. "12:5"), preceded by its cell in a notebook.
+//
+// -----------------------------------------------------------------------------
+func diagnosticLocation(diagnostic models.ParseDiagnostic) string {
+	location := fmt.Sprintf("%d:%d", diagnostic.Line, diagnostic.Column)
+	if diagnostic.EndLine != diagnostic.Line {
+		location += fmt.Sprintf("-%d:%d", diagnostic.EndLine, diagnostic.EndColumn)
+	}
+	if diagnostic.Cell != nil {
+		location = fmt.Sprintf("cell %d %s", *diagnostic.Cell, location)
+	}
+	return location
+}
+
+// -----------------------------------------------------------------------------
+// diagnosticMessage - Returns the message of a parse diagnostic.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - diagnostic (models.ParseDiagnostic): The parse diagnostic.
+//
+// Returns:
+//   - (string): The missing element, or the code the parser could not recognize.
+//
+// -----------------------------------------------------------------------------
+func diagnosticMessage(diagnostic models.ParseDiagnostic) string {
+	if diagnostic.Kind == models.DiagnosticKindMissing {
+		return fmt.Sprintf("missing '%s'", diagnostic.Text)
+	}
+	return "unexpected code: " + diagnostic.Text
+}
+
+// -----------------------------------------------------------------------------
+// stepCode - Returns the code of the line of a data flow step.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - step (models.DataFlow): The data flow step.
+//
+// Returns:
+//   - (string): The code of the step line, or an empty string if not available.
+//
+// -----------------------------------------------------------------------------
+func stepCode(step models.DataFlow) string {
+	for _, codeLine := range step.Code {
+		if codeLine.Line == step.Line {
+			return codeLine.Content
+		}
+	}
+	return ""
+}
@@ -0,0 +1,102 @@
+package outputService
+
+import (
+	"bufio"
+	"bytes"
+	"dataflow/core"
+	"dataflow/models"
+	"dataflow/tests/golden"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// -----------------------------------------------------------------------------
+// TestWriteOutput - Checks the output of an analysis in each format against its golden file.
+// -----------------------------------------------------------------------------
+func TestWriteOutput(t *testing.T) {
+	output := models.AnalysisOutput{
+		Variable:    "newPath",
+		Language:    "python",
+		Direction:   models.DirectionBackward,
+		TaintStatus: models.TaintStatusTainted,
+		Dataflow: []models.DataFlow{
+			{NameHighlight: "newPath", Line: 6, Code: []models.CodeLine{{Line: 6, Content: "    newPath = path"}}, Language: "python", Path: "tests/py/example.py", Type: "Assignment of value", Order: 1, TaintRole: models.TaintRoleSink},
+			{NameHighlight: "path", Line: 4, Code: []models.CodeLine{{Line: 4, Content: "def handler(path):"}}, Language: "python", Path: "tests/py/example.py", Type: "Function parameters", Order: 2, TaintRole: models.TaintRoleSource},
+		},
+		Diagnostics: []models.ParseDiagnostic{{Kind: models.DiagnosticKindMissing, Line: 9, Column: 12, EndLine: 9, EndColumn: 12, Text: ")"}},
+	}
+
+	for _, format := range []string{models.OutputFormatJSON, models.OutputFormatNDJSON, models.OutputFormatText, models.OutputFormatTable} {
+		format := format
+		t.Run(format, func(t *testing.T) {
+			var got bytes.Buffer
+			if err := WriteOutput(&got, format, output); err != nil {
+				t.Fatal(err)
+			}
+			golden.Check(t, filepath.Join("testdata", "output."+format), got.Bytes())
+		})
+	}
+
+	if err := WriteOutput(&bytes.Buffer{}, "xml", output); err == nil {
+		t.Error("no error for an unsupported format")
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TestWriteOutputOfTrace - Checks the NDJSON records exported for a real trace, from the start line of newPath to its source.
+// -----------------------------------------------------------------------------
+func TestWriteOutputOfTrace(t *testing.T) {
+	dataflow, steps, err := core.RunDataflowAnalysisWithSteps(models.Config{
+		FilePath:  filepath.Join("..", "..", "tests", "go", "example1.go"),
+		StartLine: 12,
+		Language:  "go",
+		Variable:  "newPath",
+	})
+	if err != nil {
+		t.Fatalf("analysis failed: %v", err)
+	}
+
+	output := models.AnalysisOutput{Variable: "newPath", Language: "go", Direction: models.DirectionBackward, Dataflow: dataflow, Steps: steps}
+	var got bytes.Buffer
+	if err := WriteOutput(&got, models.OutputFormatNDJSON, output); err != nil {
+		t.Fatal(err)
+	}
+	golden.Check(t, filepath.Join("testdata", "example1.ndjson"), got.Bytes())
+
+	// The data flow records keep the order of the analysis, from the start line back to the sources
+	var records []string
+	scanner := bufio.NewScanner(&got)
+	for scanner.Scan() {
+		var record struct {
+			Kind  string `json:"kind"`
+			Line  int    `json:"line"`
+			Order int    `json:"order"`
+			Type  string `json:"type"`
+		}
+		if err := json.Unmarshal(scanner.Bytes(), &record); err != nil {
+			t.Fatal(err)
+		}
+		if record.Kind == "dataflow" {
+			records = append(records, fmt.Sprintf("%d: %d %s", record.Order, record.Line, record.Type))
+		}
+	}
+	want := []string{
+		"1: 12 Variable used in assignment",
+		"2: 11 Assignment of value",
+		"3: 11 Assignment of value",
+		"4: 10 Assignment of value",
+		"5: 45 Function parameters",
+		"6: 9 Function parameters",
+		"7: 43 Assignment of value",
+		"8: 41 Function parameters",
+		"9: 56 Variable used in return statement",
+		"10: 55 Assignment of value",
+		"11: 36 Assignment of value",
+	}
+	if !reflect.DeepEqual(records, want) {
+		t.Errorf("data flow records of newPath:\n%v\nwant:\n%v", records, want)
+	}
+}
@@ -0,0 +1,319 @@
+// Functions that load a whole project, parse every supported source file and index its symbols across files.
+
+package projectService
+
+import (
+	"dataflow/logger"
+	"dataflow/models"
+	"dataflow/services/languageService"
+	"dataflow/services/nodeService"
+	"dataflow/services/utilityService"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// Directories that never contain project sources worth analyzing
+var ignoredDirectories = []string{".git", ".svn", ".hg", "node_modules", "vendor", "target", "bin", "obj", "__pycache__", ".venv", "venv"}
+
+// -----------------------------------------------------------------------------
+// LoadProject - Parses every supported source file under a root directory, in the language of its extension.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - rootDir (string): The root directory of the project.
+//   - log (*logger.Logger): The logger of the analysis.
+//
+// Returns:
+//   - (*models.Project): The loaded project with its cross-file function index.
+//   - (error): An error object if the directory could not be walked.
+//
+// -----------------------------------------------------------------------------
+func LoadProject(rootDir string, log *logger.Logger) (*models.Project, error) {
+	project := &models.Project{
+		RootDir:   rootDir,
+		Functions: make(map[string][]models.FunctionLocation),
+	}
+
+	err := filepath.WalkDir(rootDir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			log.PrintWarning("Unable to access '%s': %v", path, err)
+			return nil
+		}
+
+		if entry.IsDir() {
+			if path != rootDir && utilityService.ContainsString(ignoredDirectories, entry.Name()) {
+				log.PrintDebug("Skipping directory '%s'", path)
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		language := languageService.GetLanguageFromExtension(path)
+		if language == "" {
+			return nil
+		}
+
+		file, err := loadSourceFile(path, language)
+		if err != nil {
+			log.PrintWarning("Skipping file '%s': %v", path, err)
+			return nil
+		}
+
+		project.Files = append(project.Files, file)
+		indexFunctions(project, file, log)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error walking project directory: %v", err)
+	}
+
+	log.PrintInfo("Project '%s' loaded: %d files, %d function names indexed", rootDir, len(project.Files), len(project.Functions))
+	return project, nil
+}
+
+// -----------------------------------------------------------------------------
+// loadSourceFile - Reads and parses a single source file.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - path (string): The path of the file.
+//   - language (string): The programming language of the file.
+//
+// Returns:
+//   - (*models.SourceFile): The parsed source file.
+//   - (error): An error object if the file could not be read or parsed.
+//
+// -----------------------------------------------------------------------------
+func loadSourceFile(path, language string) (*models.SourceFile, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("error reading file: %v", err)
+	}
+
+	tree := languageService.ParseContent(content, language)
+	if tree == nil {
+		return nil, fmt.Errorf("failed to parse the file into a syntax tree")
+	}
+
+	return &models.SourceFile{
+		Path:     path,
+		Language: language,
+		Content:  content,
+		Tree:     tree,
+		Root:     tree.RootNode(),
+	}, nil
+}
+
+// -----------------------------------------------------------------------------
+// indexFunctions - Adds every function declared in a file to the project index.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - project (*models.Project): The project whose index is updated.
+//   - file (*models.SourceFile): The file to index.
+//   - log (*logger.Logger): The logger of the analysis.
+//
+// Returns:
+//   - None
+//
+// -----------------------------------------------------------------------------
+func indexFunctions(project *models.Project, file *models.SourceFile, log *logger.Logger) {
+	var traverse func(node *sitter.Node)
+	traverse = func(node *sitter.Node) {
+		if node == nil {
+			return
+		}
+
+		if funcName := nodeService.IsFunctionDeclaration(file.Language, file.Root, node, file.Content); funcName != "" {
+			project.Functions[funcName] = append(project.Functions[funcName], models.FunctionLocation{
+				Name: funcName,
+				File: file,
+				Node: node,
+			})
+			log.PrintDebug("Indexed function '%s' from '%s' at line %d", funcName, file.Path, node.StartPoint().Row+1)
+		}
+
+		for i := 0; i < int(node.NamedChildCount()); i++ {
+			traverse(node.NamedChild(i))
+		}
+	}
+	traverse(file.Root)
+}
+
+// -----------------------------------------------------------------------------
+// SetFileContent - Replaces the content of a project file (e.g. the unsaved buffer of an editor) and indexes its functions again.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - project (*models.Project): The project of the file.
+//   - file (*models.SourceFile): The file to update.
+//   - content ([]byte): The content analyzed instead of the file on disk.
+//   - log (*logger.Logger): The logger of the analysis.
+//
+// Returns:
+//   - (error): An error object if the content could not be parsed.
+//
+// -----------------------------------------------------------------------------
+func SetFileContent(project *models.Project, file *models.SourceFile, content []byte, log *logger.Logger) error {
+	tree := languageService.ParseContent(content, file.Language)
+	if tree == nil {
+		return fmt.Errorf("failed to parse the file into a syntax tree")
+	}
+
+	file.Content = content
+	file.Tree = tree
+	file.Root = tree.RootNode()
+
+	// The functions are indexed again in the order of the files, those of the file at the nodes of its new tree
+	project.Functions = make(map[string][]models.FunctionLocation)
+	for _, projectFile := range project.Files {
+		indexFunctions(project, projectFile, log)
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// FindFile - Finds a project file by its path.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - project (*models.Project): The project to search.
+//   - path (string): The path of the file, relative or absolute.
+//
+// Returns:
+//   - (*models.SourceFile): The matching file if found, otherwise nil.
+//
+// -----------------------------------------------------------------------------
+func FindFile(project *models.Project, path string) *models.SourceFile {
+	if project == nil {
+		return nil
+	}
+
+	target, err := filepath.Abs(path)
+	if err != nil {
+		target = filepath.Clean(path)
+	}
+
+	for _, file := range project.Files {
+		filePath, err := filepath.Abs(file.Path)
+		if err != nil {
+			filePath = filepath.Clean(file.Path)
+		}
+		if filePath == target {
+			return file
+		}
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// FindFunction - Finds the declaration of a function in the other files of the project.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - project (*models.Project): The project to search.
+//   - functionName (string): The name of the function to find.
+//   - currentFile (*models.SourceFile): The file being analyzed, which is excluded from the search with the files of the other languages.
+//   - log (*logger.Logger): The logger of the analysis.
+//
+// Returns:
+//   - (*models.FunctionLocation): The first matching declaration if found, otherwise nil.
+//
+// -----------------------------------------------------------------------------
+func FindFunction(project *models.Project, functionName string, currentFile *models.SourceFile, log *logger.Logger) *models.FunctionLocation {
+	if project == nil {
+		return nil
+	}
+
+	for _, location := range project.Functions[functionName] {
+		if location.File == currentFile || !sameLanguage(location.File, currentFile) {
+			continue
+		}
+		log.PrintDebug("Function '%s' found in '%s' at line %d", functionName, location.File.Path, location.Node.StartPoint().Row+1)
+		return &location
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// FindFunctions - Finds all the declarations of a function or a method in the other files of the project.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - project (*models.Project): The project to search.
+//   - functionName (string): The name of the function to find, without its receiver.
+//   - currentFile (*models.SourceFile): The file being analyzed, which is excluded from the search with the files of the other languages.
+//
+// Returns:
+//   - ([]models.FunctionLocation): The matching declarations, in the order of the project files.
+//
+// -----------------------------------------------------------------------------
+func FindFunctions(project *models.Project, functionName string, currentFile *models.SourceFile) []models.FunctionLocation {
+	var locations []models.FunctionLocation
+	if project == nil {
+		return locations
+	}
+
+	for _, location := range project.Functions[functionName] {
+		if location.File != currentFile && sameLanguage(location.File, currentFile) {
+			locations = append(locations, location)
+		}
+	}
+	return locations
+}
+
+// -----------------------------------------------------------------------------
+// FindCallSites - Searches the other files of the project for calls to a function.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - project (*models.Project): The project to search.
+//   - functionName (string): The name of the called function.
+//   - currentFile (*models.SourceFile): The file being analyzed, which is excluded from the search with the files of the other languages.
+//   - log (*logger.Logger): The logger of the analysis.
+//
+// Returns:
+//   - ([]models.ProjectCallSite): The call sites found in the other files.
+//
+// -----------------------------------------------------------------------------
+func FindCallSites(project *models.Project, functionName string, currentFile *models.SourceFile, log *logger.Logger) []models.ProjectCallSite {
+	var callSites []models.ProjectCallSite
+	if project == nil {
+		return callSites
+	}
+
+	for _, file := range project.Files {
+		if file == currentFile || !sameLanguage(file, currentFile) {
+			continue
+		}
+		for _, callSite := range nodeService.FindFunctionCallSites(file.Language, file.Root, functionName, file.Content) {
+			callSites = append(callSites, models.ProjectCallSite{
+				File:     file,
+				CallSite: callSite,
+			})
+		}
+	}
+
+	log.PrintDebug("Found %d call sites for '%s' in other project files", len(callSites), functionName)
+	return callSites
+}
+
+// -----------------------------------------------------------------------------
+// sameLanguage - Checks if a project file is written in the language of the file being analyzed.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - file (*models.SourceFile): The project file.
+//   - currentFile (*models.SourceFile): The file being analyzed, nil outside of the project files.
+//
+// Returns:
+//   - (bool): True if the calls of the file being analyzed can refer to the functions of the file, and the reverse.
+//
+// -----------------------------------------------------------------------------
+func sameLanguage(file, currentFile *models.SourceFile) bool {
+	return currentFile == nil || file.Language == currentFile.Language
+}
@@ -0,0 +1,212 @@
+// Functions that export data flows as SARIF 2.1.0 logs, with one code flow per traced variable.
+
+package sarifService
+
+import (
+	"dataflow/logger"
+	"dataflow/models"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+const (
+	sarifVersion   = "2.1.0"
+	sarifSchema    = "https://json.schemastore.org/sarif-2.1.0.json"
+	toolName       = "variable-dataflow-tracer"
+	dataflowRuleID = "dataflow/variable-trace"
+	taintRuleID    = "dataflow/tainted-variable"
+)
+
+// -----------------------------------------------------------------------------
+// CreateSarifResult - Converts a data flow into a SARIF result with a single code flow.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - dataflow ([]models.DataFlow): The data flow to convert, ordered from the start line.
+//   - variable (string): The analyzed variable.
+//   - direction (string): The direction of the analysis, used to put the steps in execution order.
+//
+// Returns:
+//   - (models.SarifResult): The SARIF result, located on the start line of the analysis.
+//
+// -----------------------------------------------------------------------------
+func CreateSarifResult(dataflow []models.DataFlow, variable, direction string) models.SarifResult {
+	result := models.SarifResult{
+		RuleID:  dataflowRuleID,
+		Level:   "note",
+		Message: models.SarifMessage{Text: fmt.Sprintf("Data flow of variable '%s' (%d steps)", variable, len(dataflow))},
+	}
+
+	if len(dataflow) == 0 {
+		return result
+	}
+
+	// The result points at the line where the analysis started
+	result.Locations = []models.SarifLocation{createLocation(dataflow[0], "")}
+
+	// A backward flow goes from the sink to the source: reverse it to follow the execution
+	steps := make([]models.DataFlow, len(dataflow))
+	copy(steps, dataflow)
+	if direction != models.DirectionForward {
+		for i, j := 0, len(steps)-1; i < j; i, j = i+1, j-1 {
+			steps[i], steps[j] = steps[j], steps[i]
+		}
+	}
+
+	threadFlow := models.SarifThreadFlow{}
+	for i, step := range steps {
+		message := step.Type
+		if step.TaintRole != "" {
+			message += " (" + step.TaintRole + ")"
+		}
+		threadFlow.Locations = append(threadFlow.Locations, models.SarifThreadFlowLocation{
+			Location:       createLocation(step, message),
+			ExecutionOrder: i + 1,
+		})
+	}
+
+	result.CodeFlows = []models.SarifCodeFlow{{
+		Message:     &models.SarifMessage{Text: fmt.Sprintf("Flow of '%s'", variable)},
+		ThreadFlows: []models.SarifThreadFlow{threadFlow},
+	}}
+
+	return result
+}
+
+// -----------------------------------------------------------------------------
+// CreateTaintSarifResult - Converts a taint report into a SARIF result.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - report (models.TaintReport): The taint report to convert.
+//   - direction (string): The direction of the analysis, used to put the steps in execution order.
+//
+// Returns:
+//   - (models.SarifResult): The SARIF result, reported as an error when the variable is tainted.
+//
+// -----------------------------------------------------------------------------
+func CreateTaintSarifResult(report models.TaintReport, direction string) models.SarifResult {
+	result := CreateSarifResult(report.Dataflow, report.Variable, direction)
+	result.RuleID = taintRuleID
+
+	switch report.Status {
+	case models.TaintStatusTainted:
+		result.Level = "error"
+		result.Message.Text = fmt.Sprintf("Variable '%s' reaches this line from the source at line %d without sanitization", report.Variable, report.SourceStep.Line)
+	case models.TaintStatusSanitized:
+		result.Level = "note"
+		result.Message.Text = fmt.Sprintf("Variable '%s' is sanitized at line %d", report.Variable, report.SanitizerStep.Line)
+	default:
+		result.Level = "none"
+		result.Message.Text = fmt.Sprintf("Variable '%s' does not come from a known source", report.Variable)
+	}
+
+	return result
+}
+
+// -----------------------------------------------------------------------------
+// CreateSarifLog - Wraps SARIF results into a SARIF 2.1.0 log.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - results ([]models.SarifResult): The results to include in the log.
+//
+// Returns:
+//   - (models.SarifLog): The SARIF log with a single run of the tracer.
+//
+// -----------------------------------------------------------------------------
+func CreateSarifLog(results []models.SarifResult) models.SarifLog {
+	if results == nil {
+		results = []models.SarifResult{}
+	}
+
+	return models.SarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs: []models.SarifRun{{
+			Tool: models.SarifTool{
+				Driver: models.SarifDriver{
+					Name: toolName,
+					Rules: []models.SarifRule{
+						{ID: dataflowRuleID, Name: "VariableDataflow", ShortDescription: models.SarifMessage{Text: "Data flow of a variable"}},
+						{ID: taintRuleID, Name: "TaintedVariable", ShortDescription: models.SarifMessage{Text: "Variable reaching a sink from an unsanitized source"}},
+					},
+				},
+			},
+			Results: results,
+		}},
+	}
+}
+
+// -----------------------------------------------------------------------------
+// WriteSarifFile - Writes a SARIF log to a file.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - sarifLog (models.SarifLog): The SARIF log to write.
+//   - outputPath (string): The path of the output file.
+//
+// Returns:
+//   - (error): An error object if the log could not be encoded or written.
+//
+// -----------------------------------------------------------------------------
+func WriteSarifFile(sarifLog models.SarifLog, outputPath string) error {
+	data, err := json.MarshalIndent(sarifLog, "", "  ")
+	if err != nil {
+		return fmt.Errorf("error encoding SARIF log: %v", err)
+	}
+
+	if err := os.WriteFile(outputPath, data, 0644); err != nil {
+		return fmt.Errorf("error writing SARIF file: %v", err)
+	}
+
+	logger.PrintInfo("SARIF log written to '%s'", outputPath)
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// createLocation - Converts a data flow step into a SARIF location.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - step (models.DataFlow): The data flow step.
+//   - message (string): The message of the location, or an empty string for none.
+//
+// Returns:
+//   - (models.SarifLocation): The location of the step, with its line snippet.
+//
+// -----------------------------------------------------------------------------
+func createLocation(step models.DataFlow, message string) models.SarifLocation {
+	region := models.SarifRegion{StartLine: step.Line}
+
+	for _, codeLine := range step.Code {
+		if codeLine.Line != step.Line {
+			continue
+		}
+		region.Snippet = &models.SarifSnippet{Text: codeLine.Content}
+
+		// Columns are 1-based and highlight the tracked name on the line
+		if step.NameHighlight != "" {
+			if index := strings.Index(codeLine.Content, step.NameHighlight); index >= 0 {
+				region.StartColumn = index + 1
+				region.EndColumn = index + len(step.NameHighlight) + 1
+			}
+		}
+		break
+	}
+
+	location := models.SarifLocation{
+		PhysicalLocation: models.SarifPhysicalLocation{
+			ArtifactLocation: models.SarifArtifactLocation{URI: filepath.ToSlash(step.Path)},
+			Region:           region,
+		},
+	}
+	if message != "" {
+		location.Message = &models.SarifMessage{Text: message}
+	}
+
+	return location
+}
@@ -0,0 +1,109 @@
+package sarifService
+
+import (
+	"dataflow/core"
+	"dataflow/models"
+	"dataflow/tests/golden"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// Backward data flow of newPath, from the start line to the parameter it comes from
+var testDataflow = []models.DataFlow{
+	{NameHighlight: "newPath", Line: 6, Code: []models.CodeLine{{Line: 6, Content: "    newPath = path"}}, Language: "python", Path: "tests/py/example.py", Type: "Assignment of value", Order: 1, TaintRole: models.TaintRoleSink},
+	{NameHighlight: "path", Line: 5, Code: []models.CodeLine{{Line: 5, Content: "    path = escape(name)"}}, Language: "python", Path: "tests/py/example.py", Type: "Assignment of value", Order: 2, TaintRole: models.TaintRoleSanitizer},
+	{NameHighlight: "name", Line: 4, Code: []models.CodeLine{{Line: 4, Content: "def handler(name):"}}, Language: "python", Path: "tests/py/example.py", Type: "Function parameters", Order: 3, TaintRole: models.TaintRoleSource},
+}
+
+// -----------------------------------------------------------------------------
+// TestWriteSarifFile - Checks the SARIF logs of a data flow and of its taint report against their golden files.
+// -----------------------------------------------------------------------------
+func TestWriteSarifFile(t *testing.T) {
+	report := models.TaintReport{
+		Variable:      "newPath",
+		Status:        models.TaintStatusSanitized,
+		Dataflow:      testDataflow,
+		SourceStep:    &testDataflow[2],
+		SanitizerStep: &testDataflow[1],
+		SinkStep:      &testDataflow[0],
+	}
+
+	tests := []struct {
+		golden string
+		result models.SarifResult
+	}{
+		{"dataflow.sarif", CreateSarifResult(testDataflow, "newPath", models.DirectionBackward)},
+		{"taint.sarif", CreateTaintSarifResult(report, models.DirectionBackward)},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.golden, func(t *testing.T) {
+			outputPath := filepath.Join(t.TempDir(), test.golden)
+			if err := WriteSarifFile(CreateSarifLog([]models.SarifResult{test.result}), outputPath); err != nil {
+				t.Fatal(err)
+			}
+			got, err := os.ReadFile(outputPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			golden.Check(t, filepath.Join("testdata", test.golden), got)
+		})
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TestWriteSarifFileOfTrace - Checks the code flow exported for a real trace, from the source of newPath to its start line.
+// -----------------------------------------------------------------------------
+func TestWriteSarifFileOfTrace(t *testing.T) {
+	dataflow, err := core.RunDataflowAnalysis(models.Config{
+		FilePath:  filepath.Join("..", "..", "tests", "go", "example1.go"),
+		StartLine: 12,
+		Language:  "go",
+		Variable:  "newPath",
+	})
+	if err != nil {
+		t.Fatalf("analysis failed: %v", err)
+	}
+
+	outputPath := filepath.Join(t.TempDir(), "example1.sarif")
+	if err := WriteSarifFile(CreateSarifLog([]models.SarifResult{CreateSarifResult(dataflow, "newPath", models.DirectionBackward)}), outputPath); err != nil {
+		t.Fatal(err)
+	}
+	got, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	golden.Check(t, filepath.Join("testdata", "example1.sarif"), got)
+
+	// The locations of the thread flow follow the execution, from the sources to the start line
+	var sarifLog models.SarifLog
+	if err := json.Unmarshal(got, &sarifLog); err != nil {
+		t.Fatal(err)
+	}
+	var edges []string
+	locations := sarifLog.Runs[0].Results[0].CodeFlows[0].ThreadFlows[0].Locations
+	for i := 1; i < len(locations); i++ {
+		from, to := locations[i-1].Location, locations[i].Location
+		edges = append(edges, fmt.Sprintf("%d %s -> %d %s", from.PhysicalLocation.Region.StartLine, from.Message.Text, to.PhysicalLocation.Region.StartLine, to.Message.Text))
+	}
+	want := []string{
+		"36 Assignment of value -> 55 Assignment of value",
+		"55 Assignment of value -> 56 Variable used in return statement",
+		"56 Variable used in return statement -> 41 Function parameters",
+		"41 Function parameters -> 43 Assignment of value",
+		"43 Assignment of value -> 9 Function parameters",
+		"9 Function parameters -> 45 Function parameters",
+		"45 Function parameters -> 10 Assignment of value",
+		"10 Assignment of value -> 11 Assignment of value",
+		"11 Assignment of value -> 11 Assignment of value",
+		"11 Assignment of value -> 12 Variable used in assignment",
+	}
+	if !reflect.DeepEqual(edges, want) {
+		t.Errorf("code flow of newPath:\n%v\nwant:\n%v", edges, want)
+	}
+}
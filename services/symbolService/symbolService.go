@@ -0,0 +1,453 @@
+// Functions that build the symbol table of a file from the scopes query of its language and resolve each occurrence of a variable to its declaration.
+
+package symbolService
+
+import (
+	"dataflow/logger"
+	"dataflow/models"
+	"dataflow/services/languageService"
+	"sort"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// Kinds of scope by capture of the scopes query
+var scopeCaptureKinds = map[string]string{
+	"scope":          models.ScopeKindBlock,
+	"scope.function": models.ScopeKindFunction,
+	"scope.class":    models.ScopeKindClass,
+}
+
+// Precedence of the kinds of scope when several captures open a scope on the same node
+var scopeKindPrecedence = map[string]int{
+	models.ScopeKindFunction: 3,
+	models.ScopeKindClass:    2,
+	models.ScopeKindBlock:    1,
+}
+
+// Captures of the scopes query declaring a variable, by precedence when several of them capture the same node
+var definitionCapturePrecedence = map[string]int{
+	"definition":         3,
+	"definition.new":     3,
+	"definition.hoisted": 2,
+	"definition.global":  1,
+}
+
+// -----------------------------------------------------------------------------
+// BuildSymbolTable - Builds the scopes of a file and the declarations of its variables.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the file.
+//   - root (*sitter.Node): The root node of the syntax tree.
+//   - content ([]byte): The content of the file.
+//   - log (*logger.Logger): The logger of the analysis.
+//
+// Returns:
+//   - (*models.SymbolTable): The symbol table, or nil if the language has no scopes query.
+//
+// -----------------------------------------------------------------------------
+func BuildSymbolTable(language string, root *sitter.Node, content []byte, log *logger.Logger) *models.SymbolTable {
+	captures, ok := languageService.CaptureQuery(language, languageService.QueryScopes, root, content)
+	if !ok {
+		log.PrintDebug("No scopes query for language '%s', the variables are matched by name.", language)
+		return nil
+	}
+
+	table := &models.SymbolTable{
+		Content:     content,
+		Scopes:      make(map[*sitter.Node]*models.Scope),
+		Definitions: make(map[*sitter.Node]*models.Declaration),
+	}
+	table.Root = newScope(table, models.ScopeKindModule, root, nil)
+
+	// A node captured several times opens the most specific scope and declares the most local variable
+	scopeKinds := make(map[*sitter.Node]string)
+	var scopeNodes []*sitter.Node
+	definitionKinds := make(map[*sitter.Node]string)
+	var definitionNodes []*sitter.Node
+	var globalNodes []*sitter.Node
+	for _, capture := range captures {
+		if kind, isScope := scopeCaptureKinds[capture.Name]; isScope {
+			if capture.Node == root {
+				continue
+			}
+			current, exists := scopeKinds[capture.Node]
+			if !exists {
+				scopeNodes = append(scopeNodes, capture.Node)
+			}
+			if !exists || scopeKindPrecedence[kind] > scopeKindPrecedence[current] {
+				scopeKinds[capture.Node] = kind
+			}
+		} else if precedence, isDefinition := definitionCapturePrecedence[capture.Name]; isDefinition {
+			current, exists := definitionKinds[capture.Node]
+			if !exists {
+				definitionNodes = append(definitionNodes, capture.Node)
+			}
+			if !exists || precedence > definitionCapturePrecedence[current] {
+				definitionKinds[capture.Node] = capture.Name
+			}
+		} else if capture.Name == "global" {
+			globalNodes = append(globalNodes, capture.Node)
+		}
+	}
+
+	// The scopes are nested by position: a scope belongs to the innermost scope containing it
+	sort.SliceStable(scopeNodes, func(i, j int) bool {
+		if scopeNodes[i].StartByte() != scopeNodes[j].StartByte() {
+			return scopeNodes[i].StartByte() < scopeNodes[j].StartByte()
+		}
+		return scopeNodes[i].EndByte() > scopeNodes[j].EndByte()
+	})
+	stack := []*models.Scope{table.Root}
+	for _, node := range scopeNodes {
+		for len(stack) > 1 && !containsNode(stack[len(stack)-1].Node, node) {
+			stack = stack[:len(stack)-1]
+		}
+		stack = append(stack, newScope(table, scopeKinds[node], node, stack[len(stack)-1]))
+	}
+
+	for _, node := range globalNodes {
+		scope := FunctionScope(FindScope(table, node))
+		scope.Externals[node.Content(content)] = true
+	}
+
+	for _, node := range definitionNodes {
+		declare(table, node, definitionKinds[node])
+	}
+	return table
+}
+
+// -----------------------------------------------------------------------------
+// FindScope - Finds the innermost scope containing a node.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - table (*models.SymbolTable): The symbol table of the file.
+//   - node (*sitter.Node): The node.
+//
+// Returns:
+//   - (*models.Scope): The scope opened by the node or by its closest ancestor, the module scope by default.
+//
+// -----------------------------------------------------------------------------
+func FindScope(table *models.SymbolTable, node *sitter.Node) *models.Scope {
+	for current := node; current != nil; current = current.Parent() {
+		if scope, exists := table.Scopes[current]; exists {
+			return scope
+		}
+	}
+	return table.Root
+}
+
+// -----------------------------------------------------------------------------
+// Resolve - Finds the declaration an occurrence of a variable refers to.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - table (*models.SymbolTable): The symbol table of the file.
+//   - occurrence (*sitter.Node): The identifier naming the variable.
+//
+// Returns:
+//   - (*models.Declaration): The declaration visible at the occurrence, or nil if the variable is not declared in the file.
+//
+// -----------------------------------------------------------------------------
+func Resolve(table *models.SymbolTable, occurrence *sitter.Node) *models.Declaration {
+	if declaration, exists := table.Definitions[occurrence]; exists {
+		return declaration
+	}
+
+	name := occurrence.Content(table.Content)
+	for scope := FindScope(table, occurrence); scope != nil; scope = scope.Parent {
+		var visible *models.Declaration
+		for _, declaration := range scope.Declarations[name] {
+			if isVisible(declaration, occurrence.StartByte()) {
+				visible = declaration
+			}
+		}
+		if visible != nil {
+			return visible
+		}
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// FindOccurrences - Lists the identifiers naming a variable in a node.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): The node to search.
+//   - content ([]byte): The content of the file.
+//   - name (string): The name of the variable.
+//
+// Returns:
+//   - ([]*sitter.Node): The identifiers, in source order.
+//
+// -----------------------------------------------------------------------------
+func FindOccurrences(language string, node *sitter.Node, content []byte, name string) []*sitter.Node {
+	var occurrences []*sitter.Node
+	var visit func(*sitter.Node)
+	visit = func(current *sitter.Node) {
+		if current == nil {
+			return
+		}
+		if isOccurrenceNode(language, current) && current.Content(content) == name {
+			occurrences = append(occurrences, current)
+			return
+		}
+		for i := 0; i < int(current.NamedChildCount()); i++ {
+			visit(current.NamedChild(i))
+		}
+	}
+	visit(node)
+	return occurrences
+}
+
+// -----------------------------------------------------------------------------
+// FindOccurrencesAtLine - Lists the identifiers naming a variable on a line of a file.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - root (*sitter.Node): The root node of the syntax tree.
+//   - content ([]byte): The content of the file.
+//   - name (string): The name of the variable.
+//   - line (uint32): The line (1-based).
+//
+// Returns:
+//   - ([]*sitter.Node): The identifiers starting on the line, in source order.
+//
+// -----------------------------------------------------------------------------
+func FindOccurrencesAtLine(language string, root *sitter.Node, content []byte, name string, line uint32) []*sitter.Node {
+	var occurrences []*sitter.Node
+	var visit func(*sitter.Node)
+	visit = func(current *sitter.Node) {
+		if current == nil || current.EndPoint().Row+1 < line || current.StartPoint().Row+1 > line {
+			return
+		}
+		if isOccurrenceNode(language, current) && current.Content(content) == name {
+			if current.StartPoint().Row+1 == line {
+				occurrences = append(occurrences, current)
+			}
+			return
+		}
+		for i := 0; i < int(current.NamedChildCount()); i++ {
+			visit(current.NamedChild(i))
+		}
+	}
+	visit(root)
+	return occurrences
+}
+
+// -----------------------------------------------------------------------------
+// FindDeclaration - Finds the declaration of a variable in the scope opened by a node.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - table (*models.SymbolTable): The symbol table of the file.
+//   - scopeNode (*sitter.Node): The node opening the scope (a function for its parameters).
+//   - name (string): The name of the variable.
+//
+// Returns:
+//   - (*models.Declaration): The first declaration of the variable in the scope, or nil if the scope does not declare it.
+//
+// -----------------------------------------------------------------------------
+func FindDeclaration(table *models.SymbolTable, scopeNode *sitter.Node, name string) *models.Declaration {
+	scope, exists := table.Scopes[scopeNode]
+	if !exists || len(scope.Declarations[name]) == 0 {
+		return nil
+	}
+	return scope.Declarations[name][0]
+}
+
+// -----------------------------------------------------------------------------
+// FunctionScope - Returns the function or module scope a scope belongs to.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - scope (*models.Scope): The scope.
+//
+// Returns:
+//   - (*models.Scope): The scope itself if it is a function or the module, otherwise its closest such ancestor.
+//
+// -----------------------------------------------------------------------------
+func FunctionScope(scope *models.Scope) *models.Scope {
+	for scope.Parent != nil && scope.Kind != models.ScopeKindFunction {
+		scope = scope.Parent
+	}
+	return scope
+}
+
+// -----------------------------------------------------------------------------
+// IsScopeWithin - Checks if a scope is nested in another one.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - scope (*models.Scope): The scope to check.
+//   - ancestor (*models.Scope): The enclosing scope.
+//
+// Returns:
+//   - (bool): True if the scope is the enclosing scope or one of its descendants.
+//
+// -----------------------------------------------------------------------------
+func IsScopeWithin(scope, ancestor *models.Scope) bool {
+	for current := scope; current != nil; current = current.Parent {
+		if current == ancestor {
+			return true
+		}
+	}
+	return false
+}
+
+// -----------------------------------------------------------------------------
+// newScope - Creates a scope and registers it in the symbol table.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - table (*models.SymbolTable): The symbol table of the file.
+//   - kind (string): The kind of the scope (module, function, class or block).
+//   - node (*sitter.Node): The node opening the scope.
+//   - parent (*models.Scope): The enclosing scope, or nil for the module scope.
+//
+// Returns:
+//   - (*models.Scope): The new scope.
+//
+// -----------------------------------------------------------------------------
+func newScope(table *models.SymbolTable, kind string, node *sitter.Node, parent *models.Scope) *models.Scope {
+	scope := &models.Scope{
+		Kind:         kind,
+		Node:         node,
+		Parent:       parent,
+		Declarations: make(map[string][]*models.Declaration),
+		Externals:    make(map[string]bool),
+	}
+	if parent != nil {
+		parent.Children = append(parent.Children, scope)
+	}
+	table.Scopes[node] = scope
+	return scope
+}
+
+// -----------------------------------------------------------------------------
+// declare - Adds the variable defined by a node to its scope.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - table (*models.SymbolTable): The symbol table of the file.
+//   - node (*sitter.Node): The node naming the variable.
+//   - capture (string): The capture of the definition (definition, definition.new, definition.hoisted or definition.global).
+//
+// Returns:
+//   - None
+//
+// -----------------------------------------------------------------------------
+func declare(table *models.SymbolTable, node *sitter.Node, capture string) {
+	name := node.Content(table.Content)
+	scope := FindScope(table, node)
+	switch capture {
+	case "definition.hoisted":
+		scope = FunctionScope(scope)
+	case "definition.global":
+		scope = table.Root
+	}
+
+	// A name declared global or nonlocal belongs to an enclosing scope
+	if FunctionScope(scope).Externals[name] {
+		return
+	}
+
+	// A new binding shadows the previous one of the scope after its statement, the other definitions add to the existing variable
+	if capture != "definition.new" {
+		if existing := scope.Declarations[name]; len(existing) > 0 {
+			declaration := existing[len(existing)-1]
+			declaration.Hoisted = declaration.Hoisted || capture != "definition"
+			table.Definitions[node] = declaration
+			return
+		}
+	}
+
+	declaration := &models.Declaration{
+		Name:    name,
+		Scope:   scope,
+		Line:    node.StartPoint().Row + 1,
+		Visible: node.StartByte(),
+		Hoisted: capture == "definition.hoisted" || capture == "definition.global",
+	}
+	if capture == "definition.new" {
+		declaration.Visible = statementEnd(scope, node)
+	}
+	scope.Declarations[name] = append(scope.Declarations[name], declaration)
+	table.Definitions[node] = declaration
+}
+
+// -----------------------------------------------------------------------------
+// isVisible - Checks if a declaration is visible at a position of its scope.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - declaration (*models.Declaration): The declaration.
+//   - position (uint32): The byte of the occurrence.
+//
+// Returns:
+//   - (bool): True if the variable is hoisted, declared in a module or class scope, or declared before the position.
+//
+// -----------------------------------------------------------------------------
+func isVisible(declaration *models.Declaration, position uint32) bool {
+	if declaration.Hoisted || declaration.Scope.Kind == models.ScopeKindModule || declaration.Scope.Kind == models.ScopeKindClass {
+		return true
+	}
+	return declaration.Visible <= position
+}
+
+// -----------------------------------------------------------------------------
+// statementEnd - Returns the end of the statement of a scope containing a node.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - scope (*models.Scope): The scope.
+//   - node (*sitter.Node): The node, nested in the statement.
+//
+// Returns:
+//   - (uint32): The byte following the statement, child of the node of the scope.
+//
+// -----------------------------------------------------------------------------
+func statementEnd(scope *models.Scope, node *sitter.Node) uint32 {
+	statement := node
+	for statement.Parent() != nil && statement.Parent() != scope.Node {
+		statement = statement.Parent()
+	}
+	return statement.EndByte()
+}
+
+// -----------------------------------------------------------------------------
+// containsNode - Checks if a node is inside the range of another one.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - outer (*sitter.Node): The enclosing node.
+//   - inner (*sitter.Node): The node to check.
+//
+// Returns:
+//   - (bool): True if the range of the inner node is within the range of the outer node.
+//
+// -----------------------------------------------------------------------------
+func containsNode(outer, inner *sitter.Node) bool {
+	return outer.StartByte() <= inner.StartByte() && inner.EndByte() <= outer.EndByte()
+}
+
+// -----------------------------------------------------------------------------
+// isOccurrenceNode - Checks if a node names a variable.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): The node to check.
+//
+// Returns:
+//   - (bool): True if the node is an identifier of a variable.
+//
+// -----------------------------------------------------------------------------
+func isOccurrenceNode(language string, node *sitter.Node) bool {
+	return languageService.IsSyntaxNode(language, node, languageService.SyntaxOccurrence) || languageService.GetControlKind(language, node) == languageService.ControlIdentifier
+}
@@ -0,0 +1,618 @@
+// Functions related to taint analysis: loading the source, sink and sanitizer rules and classifying data flows.
+
+package taintService
+
+import (
+	"dataflow/logger"
+	"dataflow/models"
+	"dataflow/services/languageService"
+	"dataflow/services/nodeService"
+	"dataflow/services/utilityService"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// Control kinds of the statements whose body may not run
+var conditionalControlKinds = map[string]bool{
+	languageService.ControlCondition: true,
+	languageService.ControlSwitch:    true,
+	languageService.ControlLoop:      true,
+	languageService.ControlWhile:     true,
+}
+
+// compiledRules - Regular expressions of the taint rules, compiled once for an analysis
+type compiledRules struct {
+	sources    []*regexp.Regexp
+	sinks      []*regexp.Regexp
+	sanitizers []*regexp.Regexp
+}
+
+// syntaxCache - Syntax trees of the files of a data flow, by path
+type syntaxCache struct {
+	contents map[string][]byte      // Contents given by the caller, the files are read from the disk otherwise
+	files    map[string]*parsedFile // Parsed files, nil for the files that could not be read or parsed
+	log      *logger.Logger         // Logger of the analysis
+}
+
+// parsedFile - Syntax tree of a file of a data flow
+type parsedFile struct {
+	tree     *sitter.Tree
+	content  []byte
+	language string
+	notebook *models.Notebook       // Notebook whose virtual module is parsed, nil for the other files
+	steps    map[uint32]*stepSyntax // Syntax of the lines of the steps, by line of the parsed source
+}
+
+// stepSyntax - Syntax tree of the file of a data flow step, with the line of the step
+type stepSyntax struct {
+	file    *parsedFile // Parsed file of the step, shared by the steps of the same file
+	root    *sitter.Node
+	content []byte
+	line    uint32         // Line of the step in the parsed source (in the virtual module of a notebook)
+	calls   []*sitter.Node // Calls of the line, found on first use
+}
+
+// stepMatch - Rules matched by a data flow step
+type stepMatch struct {
+	syntax        *stepSyntax  // Syntax of the step, nil if its file could not be parsed
+	sanitizerCall *sitter.Node // Call of a sanitizer on the line
+	sinkCall      *sitter.Node // Call of a sink on the line
+	isSource      bool
+	isSanitizer   bool
+	isSink        bool
+}
+
+// analyzedNode - Node of the syntax tree of a step
+type analyzedNode struct {
+	syntax *stepSyntax
+	node   *sitter.Node
+}
+
+// Default rules shipped for each supported language
+//
+//go:embed rules/*.json
+var defaultRules embed.FS
+
+// -----------------------------------------------------------------------------
+// LoadRules - Loads the taint rules for a language from a rules file or from the default rules.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The programming language of the analyzed code.
+//   - rulesFile (string): The path to a JSON rules file, or an empty string to use the default rules.
+//   - log (*logger.Logger): The logger of the analysis.
+//
+// Returns:
+//   - (models.TaintRules): The loaded rules.
+//   - (error): An error object if the rules could not be read or decoded.
+//
+// -----------------------------------------------------------------------------
+func LoadRules(language, rulesFile string, log *logger.Logger) (models.TaintRules, error) {
+	var rules models.TaintRules
+	var data []byte
+	var err error
+
+	if rulesFile != "" {
+		data, err = os.ReadFile(rulesFile)
+	} else {
+		data, err = defaultRules.ReadFile("rules/" + language + ".json")
+	}
+	if err != nil {
+		return rules, fmt.Errorf("error reading taint rules: %v", err)
+	}
+
+	if err := json.Unmarshal(data, &rules); err != nil {
+		return rules, fmt.Errorf("error decoding taint rules: %v", err)
+	}
+
+	if rules.Language != "" && rules.Language != language {
+		log.PrintWarning("Taint rules are declared for '%s' but the analyzed language is '%s'", rules.Language, language)
+	}
+
+	log.PrintDebug("Taint rules loaded: %d sources, %d sinks, %d sanitizers", len(rules.Sources), len(rules.Sinks), len(rules.Sanitizers))
+	return rules, nil
+}
+
+// -----------------------------------------------------------------------------
+// AnalyzeDataflow - Marks the sources, sinks and sanitizers of a data flow and computes its taint status.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - dataflow ([]models.DataFlow): The data flow to classify.
+//   - rules (models.TaintRules): The taint rules of the language.
+//   - variable (string): The analyzed variable.
+//   - contents (map[string][]byte): The contents of the files given by the caller (unsaved files), by path. The other
+//     files of the steps are read from the disk.
+//   - log (*logger.Logger): The logger of the analysis.
+//
+// Returns:
+//   - (models.TaintReport): The taint report, with the steps annotated with their role.
+//
+// -----------------------------------------------------------------------------
+func AnalyzeDataflow(dataflow []models.DataFlow, rules models.TaintRules, variable string, contents map[string][]byte, log *logger.Logger) models.TaintReport {
+	report := models.TaintReport{
+		Variable: variable,
+		Status:   models.TaintStatusClean,
+		Dataflow: dataflow,
+	}
+
+	compiled := compileRules(rules)
+	files := newSyntaxCache(contents, log)
+
+	// The sinks and the sanitizers are calls of the step lines, the sources may also be read (os.Args, $_GET)
+	matches := make([]stepMatch, len(report.Dataflow))
+	sourceIndex := 0
+	for i := range report.Dataflow {
+		step := &report.Dataflow[i]
+		match := &matches[i]
+		match.syntax = files.get(*step)
+
+		if match.syntax == nil {
+			// Without the syntax tree of the file, the rules are matched on the code of the line
+			code := stepCode(*step)
+			match.isSource = matchesAnyRule(code, compiled.sources)
+			match.isSanitizer = matchesAnyRule(code, compiled.sanitizers)
+			match.isSink = matchesAnyRule(code, compiled.sinks)
+		} else {
+			calls := match.syntax.callsAtLine()
+			match.isSource = !match.syntax.declaresFunction() && matchesAnyRule(stepCode(*step), compiled.sources)
+			match.sanitizerCall = findMatchingCall(match.syntax.file.language, calls, match.syntax.content, compiled.sanitizers)
+			match.sinkCall = findMatchingCall(match.syntax.file.language, calls, match.syntax.content, compiled.sinks)
+			match.isSanitizer = match.sanitizerCall != nil
+			match.isSink = match.sinkCall != nil
+		}
+
+		// A line both reading and sanitizing a value is a sanitizer first
+		switch {
+		case match.isSanitizer:
+			step.TaintRole = models.TaintRoleSanitizer
+		case match.isSource:
+			step.TaintRole = models.TaintRoleSource
+		case match.isSink:
+			step.TaintRole = models.TaintRoleSink
+		}
+
+		if match.isSource && report.SourceStep == nil {
+			report.SourceStep = step
+			sourceIndex = i
+		}
+		if match.isSink && report.SinkStep == nil {
+			report.SinkStep = step
+		}
+
+		log.PrintDebug("Step %d at line %d: source=%v sanitizer=%v sink=%v", step.Order, step.Line, match.isSource, match.isSanitizer, match.isSink)
+	}
+
+	if report.SourceStep == nil {
+		return report
+	}
+
+	// Only the sanitizers between the source and the sink (the start of the flow without a sink) protect the sink
+	first, last := 0, sourceIndex
+	reference := analyzedNode{}
+	for i, match := range matches {
+		if match.isSink && match.syntax != nil {
+			first, last = utilityService.Min(i, sourceIndex), utilityService.Max(i, sourceIndex)
+			reference = analyzedNode{syntax: match.syntax, node: match.sinkCall}
+			break
+		}
+	}
+	if reference.node == nil && matches[0].syntax != nil {
+		reference = analyzedNode{syntax: matches[0].syntax, node: nodeService.FindNodeAtLine(matches[0].syntax.file.language, matches[0].syntax.root, matches[0].syntax.line)}
+	}
+
+	for i := first; i <= last; i++ {
+		match := matches[i]
+		if !match.isSanitizer {
+			continue
+		}
+
+		// A sanitizer applied in a branch only (if util.CheckLevel(r) { term = HTMLEscapeString(term) }) does not sanitize
+		sanitizer := analyzedNode{syntax: match.syntax, node: match.sanitizerCall}
+		if match.syntax != nil && !alwaysRuns(sanitizer, reference, matches, make(map[*sitter.Node]bool)) {
+			log.PrintInfo("Sanitizer at line %d is only applied conditionally", report.Dataflow[i].Line)
+			continue
+		}
+
+		report.SanitizerStep = &report.Dataflow[i]
+		break
+	}
+
+	if report.SanitizerStep != nil {
+		report.Status = models.TaintStatusSanitized
+		log.PrintInfo("Variable '%s' is sanitized at line %d", variable, report.SanitizerStep.Line)
+	} else {
+		report.Status = models.TaintStatusTainted
+		log.PrintInfo("Variable '%s' is tainted by the source at line %d", variable, report.SourceStep.Line)
+	}
+
+	return report
+}
+
+// -----------------------------------------------------------------------------
+// stepCode - Returns the code of the line of a data flow step.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - step (models.DataFlow): The data flow step.
+//
+// Returns:
+//   - (string): The code of the step line, or an empty string if not available.
+//
+// -----------------------------------------------------------------------------
+func stepCode(step models.DataFlow) string {
+	for _, codeLine := range step.Code {
+		if codeLine.Line == step.Line {
+			return codeLine.Content
+		}
+	}
+	return ""
+}
+
+// -----------------------------------------------------------------------------
+// compileRules - Compiles the patterns of the taint rules into regular expressions.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - rules (models.TaintRules): The taint rules of the language.
+//
+// Returns:
+//   - (compiledRules): The regular expressions of the sources, sinks and sanitizers.
+//
+// -----------------------------------------------------------------------------
+func compileRules(rules models.TaintRules) compiledRules {
+	return compiledRules{
+		sources:    compilePatterns(rules.Sources),
+		sinks:      compilePatterns(rules.Sinks),
+		sanitizers: compilePatterns(rules.Sanitizers),
+	}
+}
+
+// -----------------------------------------------------------------------------
+// compilePatterns - Compiles rule patterns into regular expressions matching whole names.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - patterns ([]string): The rule patterns (e.g. "r.FormValue", "os.system", "input(").
+//
+// Returns:
+//   - ([]*regexp.Regexp): The regular expressions, "FormValue" matching "r.FormValue" but not "GetFormValues".
+//
+// -----------------------------------------------------------------------------
+func compilePatterns(patterns []string) []*regexp.Regexp {
+	var expressions []*regexp.Regexp
+	for _, pattern := range patterns {
+		if pattern == "" {
+			continue
+		}
+
+		expression := regexp.QuoteMeta(pattern)
+		if isIdentifierCharacter(pattern[0]) {
+			expression = `(^|[^A-Za-z0-9_$])` + expression
+		}
+		if isIdentifierCharacter(pattern[len(pattern)-1]) {
+			expression += `($|[^A-Za-z0-9_])`
+		}
+		expressions = append(expressions, regexp.MustCompile(expression))
+	}
+	return expressions
+}
+
+// -----------------------------------------------------------------------------
+// matchesAnyRule - Checks if a code contains one of the rule patterns.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - code (string): The code (a line, or the called function of a call).
+//   - expressions ([]*regexp.Regexp): The compiled rule patterns.
+//
+// Returns:
+//   - (bool): True if one of the patterns appears as a whole name in the code.
+//
+// -----------------------------------------------------------------------------
+func matchesAnyRule(code string, expressions []*regexp.Regexp) bool {
+	for _, expression := range expressions {
+		if expression.MatchString(code) {
+			return true
+		}
+	}
+	return false
+}
+
+// -----------------------------------------------------------------------------
+// findMatchingCall - Finds the first call whose called function matches a rule.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the file of the calls.
+//   - calls ([]*sitter.Node): The calls of a line.
+//   - content ([]byte): The content of the source code.
+//   - expressions ([]*regexp.Regexp): The compiled rule patterns.
+//
+// Returns:
+//   - (*sitter.Node): The matching call (html.EscapeString(s) for "html.EscapeString"), or nil.
+//
+// -----------------------------------------------------------------------------
+func findMatchingCall(language string, calls []*sitter.Node, content []byte, expressions []*regexp.Regexp) *sitter.Node {
+	for _, call := range calls {
+		if matchesAnyRule(nodeService.GetCallTarget(language, call, content), expressions) {
+			return call
+		}
+	}
+	return nil
+}
+
+// -----------------------------------------------------------------------------
+// alwaysRuns - Checks if a sanitizer is applied on every path from the source to the sink.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - call (analyzedNode): The sanitizer call.
+//   - reference (analyzedNode): The sink call, or the start line of the flow without a sink.
+//   - matches ([]stepMatch): The syntax of the steps of the flow, to find the calls of the function of the sanitizer.
+//   - visited (map[*sitter.Node]bool): The functions already checked, against recursive calls.
+//
+// Returns:
+//   - (bool): False if the call is in a branch or a loop the reference is not in, or if its function is only called in
+//     such branches.
+//
+// -----------------------------------------------------------------------------
+func alwaysRuns(call, reference analyzedNode, matches []stepMatch, visited map[*sitter.Node]bool) bool {
+	if isConditional(call, reference) {
+		return false
+	}
+
+	// The function of the sanitizer (HTMLEscapeString) is only as sure as the calls leading to it
+	function := nodeService.FindEnclosingFunctionNode(call.syntax.file.language, call.node)
+	if function == nil || reference.contains(call.syntax, function) || visited[function] {
+		return true
+	}
+	visited[function] = true
+
+	name := nodeService.IsFunctionDeclaration(call.syntax.file.language, call.syntax.root, function, call.syntax.content)
+	called := false
+	for _, match := range matches {
+		if match.syntax == nil || match.syntax.file != call.syntax.file {
+			continue
+		}
+		for _, caller := range match.syntax.callsAtLine() {
+			if nodeService.GetCalledFunctionName(match.syntax.file.language, caller, match.syntax.content) != name || containsNode(function, caller) {
+				continue
+			}
+			called = true
+			if alwaysRuns(analyzedNode{syntax: match.syntax, node: caller}, reference, matches, visited) {
+				return true
+			}
+		}
+	}
+	return !called
+}
+
+// -----------------------------------------------------------------------------
+// isConditional - Checks if a node is in a branch or a loop of its function that does not contain the reference.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - node (analyzedNode): The node (a sanitizer call).
+//   - reference (analyzedNode): The sink call, or the start line of the flow.
+//
+// Returns:
+//   - (bool): True if the node is in the body of a condition, a switch or a loop (not in its condition) that the
+//     reference is not in.
+//
+// -----------------------------------------------------------------------------
+func isConditional(node, reference analyzedNode) bool {
+	for child, current := node.node, node.node.Parent(); current != nil; child, current = current, current.Parent() {
+		if languageService.IsFunctionNode(node.syntax.file.language, current) {
+			return false
+		}
+		if !conditionalControlKinds[languageService.GetControlKind(node.syntax.file.language, current)] || reference.contains(node.syntax, current) {
+			continue
+		}
+		if condition := current.ChildByFieldName("condition"); condition != nil && condition.Equal(child) {
+			continue
+		}
+		return true
+	}
+	return false
+}
+
+// -----------------------------------------------------------------------------
+// containsNode - Checks if a node is inside another node of the same syntax tree.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - outer (*sitter.Node): The enclosing node.
+//   - inner (*sitter.Node): The node to locate.
+//
+// Returns:
+//   - (bool): True if the inner node lies within the outer node.
+//
+// -----------------------------------------------------------------------------
+func containsNode(outer, inner *sitter.Node) bool {
+	return outer.StartByte() <= inner.StartByte() && inner.EndByte() <= outer.EndByte()
+}
+
+// -----------------------------------------------------------------------------
+// contains - Checks if a node of a file encloses the analyzed node.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - syntax (*stepSyntax): The syntax tree of the enclosing node.
+//   - outer (*sitter.Node): The enclosing node (a branch, a function).
+//
+// Returns:
+//   - (bool): True if the analyzed node is in the same file and inside the enclosing node.
+//
+// -----------------------------------------------------------------------------
+func (n analyzedNode) contains(syntax *stepSyntax, outer *sitter.Node) bool {
+	return n.node != nil && n.syntax.file == syntax.file && containsNode(outer, n.node)
+}
+
+// -----------------------------------------------------------------------------
+// newSyntaxCache - Creates the cache of the syntax trees of the files of a data flow.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - contents (map[string][]byte): The contents given by the caller, by path.
+//   - log (*logger.Logger): The logger of the analysis.
+//
+// Returns:
+//   - (*syntaxCache): The empty cache.
+//
+// -----------------------------------------------------------------------------
+func newSyntaxCache(contents map[string][]byte, log *logger.Logger) *syntaxCache {
+	return &syntaxCache{contents: contents, files: make(map[string]*parsedFile), log: log}
+}
+
+// -----------------------------------------------------------------------------
+// get - Returns the syntax tree of the file of a data flow step, parsing the file on first use.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - step (models.DataFlow): The data flow step.
+//
+// Returns:
+//   - (*stepSyntax): The syntax tree and the line of the step in the parsed source, or nil if the file could not be read or
+//     parsed.
+//
+// -----------------------------------------------------------------------------
+func (c *syntaxCache) get(step models.DataFlow) *stepSyntax {
+	file, exists := c.files[step.Path]
+	if !exists {
+		file = c.parse(step.Path, step.Language)
+		c.files[step.Path] = file
+	}
+	if file == nil {
+		return nil
+	}
+
+	// The steps of a notebook are at their line in their cell, the parsed source is the virtual module of the cells
+	line := step.Line
+	if step.Cell != nil {
+		if file.notebook == nil {
+			return nil
+		}
+		moduleLine, err := languageService.GetNotebookModuleLine(file.notebook, *step.Cell, step.Line)
+		if err != nil {
+			return nil
+		}
+		line = moduleLine
+	}
+
+	key := uint32(line)
+	if file.steps[key] == nil {
+		file.steps[key] = &stepSyntax{file: file, root: file.tree.RootNode(), content: file.content, line: key}
+	}
+	return file.steps[key]
+}
+
+// -----------------------------------------------------------------------------
+// parse - Reads and parses a file of the data flow.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - path (string): The path of the file.
+//   - language (string): The language of the file.
+//
+// Returns:
+//   - (*parsedFile): The syntax tree of the code of the language (the cells of a notebook, the scripts of a template), or
+//     nil if the file could not be read or parsed.
+//
+// -----------------------------------------------------------------------------
+func (c *syntaxCache) parse(path, language string) *parsedFile {
+	content, exists := c.contents[path]
+	if !exists {
+		var err error
+		content, err = os.ReadFile(path)
+		if err != nil {
+			c.log.PrintWarning("Taint rules matched on the code of the lines of %s: %v", path, err)
+			return nil
+		}
+	}
+
+	file := &parsedFile{steps: make(map[uint32]*stepSyntax)}
+	if languageService.IsNotebookFile(path) {
+		notebook, err := languageService.LoadNotebook(content)
+		if err != nil {
+			return nil
+		}
+		file.notebook = notebook
+		content = notebook.Content
+	} else if embedded := languageService.ExtractEmbeddedSource(path, content, language); embedded != nil {
+		content = embedded
+	}
+
+	file.tree = languageService.ParseContent(content, language)
+	if file.tree == nil {
+		return nil
+	}
+	file.content = content
+	file.language = language
+	return file
+}
+
+// -----------------------------------------------------------------------------
+// callsAtLine - Returns the calls starting on the line of a step.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - None
+//
+// Returns:
+//   - ([]*sitter.Node): The calls of the line, the outer calls first.
+//
+// -----------------------------------------------------------------------------
+func (s *stepSyntax) callsAtLine() []*sitter.Node {
+	if s.calls == nil {
+		s.calls = []*sitter.Node{}
+		for _, call := range nodeService.FindCallExpressions(s.file.language, s.root) {
+			if call.StartPoint().Row+1 == s.line {
+				s.calls = append(s.calls, call)
+			}
+		}
+	}
+	return s.calls
+}
+
+// -----------------------------------------------------------------------------
+// declaresFunction - Checks if the line of a step is the head of a function declaration.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - None
+//
+// Returns:
+//   - (bool): True if a function is declared on the line (func HTMLEscapeString(text string) string {).
+//
+// -----------------------------------------------------------------------------
+func (s *stepSyntax) declaresFunction() bool {
+	node := nodeService.FindNodeAtLine(s.file.language, s.root, s.line)
+	for current := node; current != nil && current.StartPoint().Row+1 == s.line; current = current.Parent() {
+		if languageService.IsFunctionNode(s.file.language, current) {
+			return true
+		}
+	}
+	return false
+}
+
+// -----------------------------------------------------------------------------
+// isIdentifierCharacter - Checks if a character can be part of an identifier.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - char (byte): The character to check.
+//
+// Returns:
+//   - (bool): True if the character is a letter, a digit, an underscore or a dollar sign.
+//
+// -----------------------------------------------------------------------------
+func isIdentifierCharacter(char byte) bool {
+	return char == '_' || char == '$' || strings.ContainsRune("abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789", rune(char))
+}
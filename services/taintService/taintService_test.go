@@ -0,0 +1,139 @@
+package taintService
+
+import (
+	"dataflow/logger"
+	"dataflow/models"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// Logger of the analyses of the tests, which discards the messages
+var discardLogger = logger.New(nil, nil, nil, nil)
+
+// -----------------------------------------------------------------------------
+// TestLoadRules - Checks that the default rules of a language and the rules of a file are loaded.
+// -----------------------------------------------------------------------------
+func TestLoadRules(t *testing.T) {
+	rules, err := LoadRules("python", "", discardLogger)
+	if err != nil {
+		t.Fatalf("default rules: %v", err)
+	}
+	if rules.Language != "python" || len(rules.Sources) == 0 || len(rules.Sinks) == 0 || len(rules.Sanitizers) == 0 {
+		t.Errorf("default python rules are incomplete: %+v", rules)
+	}
+
+	rulesFile := filepath.Join(t.TempDir(), "rules.json")
+	if err := os.WriteFile(rulesFile, []byte(`{"language": "go", "sources": ["os.Args"], "sinks": ["exec.Command"], "sanitizers": []}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	rules, err = LoadRules("go", rulesFile, discardLogger)
+	if err != nil {
+		t.Fatalf("rules file: %v", err)
+	}
+	if len(rules.Sources) != 1 || rules.Sources[0] != "os.Args" || len(rules.Sinks) != 1 || rules.Sinks[0] != "exec.Command" {
+		t.Errorf("rules of the file not loaded: %+v", rules)
+	}
+
+	if _, err := LoadRules("cobol", "", discardLogger); err == nil {
+		t.Error("no error for a language without default rules")
+	}
+
+	invalidFile := filepath.Join(t.TempDir(), "invalid.json")
+	if err := os.WriteFile(invalidFile, []byte(`{"sources": [`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadRules("go", invalidFile, discardLogger); err == nil || !strings.Contains(err.Error(), "decoding") {
+		t.Errorf("invalid rules file: got error %v", err)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TestAnalyzeDataflow - Checks the taint status of flows from a source to a sink, through sanitizers or not.
+// -----------------------------------------------------------------------------
+func TestAnalyzeDataflow(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		lines     []int // Lines of the steps, from the source to the sink
+		status    string
+		sanitizer int // Line of the sanitizer step, 0 if the flow is not sanitized
+	}{
+		{
+			name:    "sanitized",
+			content: "import os, html\n\ndef handler(request):\n    name = request.args.get('name')\n    safe = html.escape(name)\n    os.system('echo ' + safe)\n",
+			lines:   []int{4, 5, 6},
+			status:  models.TaintStatusSanitized, sanitizer: 5,
+		},
+		{
+			name:    "conditional sanitizer",
+			content: "import os, html\n\ndef handler(request, strict):\n    name = request.args.get('name')\n    if strict:\n        name = html.escape(name)\n    os.system('echo ' + name)\n",
+			lines:   []int{4, 6, 7},
+			status:  models.TaintStatusTainted,
+		},
+		{
+			name:    "tainted",
+			content: "import os\n\ndef handler(request):\n    name = request.args.get('name')\n    os.system('echo ' + name)\n",
+			lines:   []int{4, 5},
+			status:  models.TaintStatusTainted,
+		},
+		{
+			name:    "clean",
+			content: "import os\n\ndef handler():\n    name = 'world'\n    os.system('echo ' + name)\n",
+			lines:   []int{4, 5},
+			status:  models.TaintStatusClean,
+		},
+	}
+
+	rules, err := LoadRules("python", "", discardLogger)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			path := "handler.py"
+			report := AnalyzeDataflow(newSteps(path, test.content, test.lines), rules, "name", map[string][]byte{path: []byte(test.content)}, discardLogger)
+
+			if report.Status != test.status {
+				t.Errorf("status: got %s, want %s", report.Status, test.status)
+			}
+			switch {
+			case test.sanitizer == 0 && report.SanitizerStep != nil:
+				t.Errorf("unexpected sanitizer at line %d", report.SanitizerStep.Line)
+			case test.sanitizer != 0 && (report.SanitizerStep == nil || report.SanitizerStep.Line != test.sanitizer):
+				t.Errorf("sanitizer: got %+v, want line %d", report.SanitizerStep, test.sanitizer)
+			}
+		})
+	}
+}
+
+// -----------------------------------------------------------------------------
+// newSteps - Creates the data flow steps of lines of a python file, with their code.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - path (string): The path of the file.
+//   - content (string): The content of the file.
+//   - lines ([]int): The lines of the steps.
+//
+// Returns:
+//   - ([]models.DataFlow): The steps, in the order of the lines.
+//
+// -----------------------------------------------------------------------------
+func newSteps(path, content string, lines []int) []models.DataFlow {
+	codeLines := strings.Split(content, "\n")
+	var dataflow []models.DataFlow
+	for i, line := range lines {
+		dataflow = append(dataflow, models.DataFlow{
+			Line:     line,
+			Code:     []models.CodeLine{{Line: line, Content: codeLines[line-1]}},
+			Language: "python",
+			Path:     path,
+			Order:    i + 1,
+		})
+	}
+	return dataflow
+}
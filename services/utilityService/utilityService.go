@@ -2,6 +2,8 @@
 
 package utilityService
 
+import "sort"
+
 // -----------------------------------------------------------------------------
 // ContainsString - Checks if a slice contains a specific string
 // -----------------------------------------------------------------------------
@@ -176,3 +178,23 @@ func Min(x, y int) int {
 	}
 	return y
 }
+
+// -----------------------------------------------------------------------------
+// SortedKeys - Returns the keys of a set in alphabetical order
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - set (map[string]bool): The set to list.
+//
+// Returns:
+//   - ([]string): The keys of the set, sorted so that iterating them is deterministic.
+//
+// -----------------------------------------------------------------------------
+func SortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
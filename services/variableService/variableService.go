@@ -0,0 +1,501 @@
+// Functions that detect the variables of a line: every identifier of the line is classified as a variable, a function, a field, a module or a literal.
+
+package variableService
+
+import (
+	"dataflow/logger"
+	"dataflow/models"
+	"dataflow/services/languageService"
+	"dataflow/services/nodeService"
+	"strings"
+	"unicode"
+
+	sitter "github.com/smacker/go-tree-sitter"
+)
+
+// -----------------------------------------------------------------------------
+// DetectVariables - Lists and classifies the identifiers and literals of a line.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - root (*sitter.Node): The root node of the syntax tree.
+//   - content ([]byte): The content of the source code.
+//   - line (uint32): The line to analyze, starting at 1.
+//   - log (*logger.Logger): The logger of the analysis.
+//
+// Returns:
+//   - ([]models.IdentifyVariableResponse): The candidates of the line in the order they appear, each name once.
+//
+// -----------------------------------------------------------------------------
+func DetectVariables(language string, root *sitter.Node, content []byte, line uint32, log *logger.Logger) []models.IdentifyVariableResponse {
+	var candidates []models.IdentifyVariableResponse
+	indexes := make(map[string]int)
+	importedNames := collectImportedNames(language, root, content)
+
+	addCandidate := func(name, kind string) {
+		if name == "" {
+			return
+		}
+		if index, exists := indexes[name]; exists {
+			// A name used once as a variable on the line is a variable
+			if kind == models.IdentifierKindVariable && !candidates[index].IsVariable {
+				candidates[index].Kind = kind
+				candidates[index].IsVariable = true
+			}
+			return
+		}
+		indexes[name] = len(candidates)
+		candidates = append(candidates, models.IdentifyVariableResponse{
+			VariableOrValue: name,
+			IsVariable:      kind == models.IdentifierKindVariable,
+			Kind:            kind,
+		})
+	}
+
+	var traverse func(node *sitter.Node)
+	traverse = func(node *sitter.Node) {
+		if node == nil || node.StartPoint().Row+1 > line || node.EndPoint().Row+1 < line {
+			return
+		}
+
+		if node.StartPoint().Row+1 == line {
+			if languageService.IsLiteralNode(language, node) && !hasInterpolation(language, node) {
+				addCandidate(node.Content(content), models.IdentifierKindLiteral)
+				return
+			}
+			if languageService.IsSyntaxNode(language, node, languageService.SyntaxDetectedName) {
+				// The name of a PHP variable is a candidate with its '$', as the analysis tracks it
+				identifier := nodeService.GetIdentifierNode(language, node)
+				if kind := classifyIdentifier(language, root, identifier, content, importedNames, log); kind != "" {
+					addCandidate(identifier.Content(content), kind)
+				}
+				return
+			}
+		}
+
+		for i := 0; i < int(node.NamedChildCount()); i++ {
+			traverse(node.NamedChild(i))
+		}
+	}
+	traverse(root)
+
+	log.PrintDebug("Candidates detected at line %d: %+v", line, candidates)
+	return candidates
+}
+
+// -----------------------------------------------------------------------------
+// GetVariableNames - Returns the names of the candidates classified as variables.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - candidates ([]models.IdentifyVariableResponse): The candidates of a line.
+//
+// Returns:
+//   - ([]string): The variable names, in the order of the candidates.
+//
+// -----------------------------------------------------------------------------
+func GetVariableNames(candidates []models.IdentifyVariableResponse) []string {
+	var variables []string
+	for _, candidate := range candidates {
+		if candidate.IsVariable {
+			variables = append(variables, candidate.VariableOrValue)
+		}
+	}
+	return variables
+}
+
+// -----------------------------------------------------------------------------
+// classifyIdentifier - Classifies an identifier from its position in the syntax tree.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - root (*sitter.Node): The root node of the syntax tree.
+//   - node (*sitter.Node): The identifier node.
+//   - content ([]byte): The content of the source code.
+//   - importedNames (map[string]bool): The names declared by the imports of the file.
+//   - log (*logger.Logger): The logger of the analysis.
+//
+// Returns:
+//   - (string): The kind of the identifier, or an empty string if it must be ignored (e.g. a keyword argument name).
+//
+// -----------------------------------------------------------------------------
+func classifyIdentifier(language string, root, node *sitter.Node, content []byte, importedNames map[string]bool, log *logger.Logger) string {
+	parent := node.Parent()
+	name := node.Content(content)
+	if parent == nil {
+		return models.IdentifierKindVariable
+	}
+
+	// Names of keyword arguments (e.g. algorithm='HS256') are not values
+	if isKeywordArgumentName(language, parent, node) {
+		return ""
+	}
+
+	// Names of JSX attributes (e.g. className="page") are not values, only their expressions are used
+	if languageService.IsSyntaxNode(language, parent, languageService.SyntaxMarkupAttribute) && parent.NamedChildCount() > 0 && sameNode(parent.NamedChild(0), node) {
+		return ""
+	}
+
+	// Names of JSX elements are components (functions) or HTML tags
+	if isJSXElementName(language, parent, node) {
+		if startsWithUpper(name) && languageService.IsSyntaxNode(language, node, languageService.SyntaxIdentifier) {
+			return models.IdentifierKindFunction
+		}
+		return ""
+	}
+
+	// Name of a declared function
+	if isField(parent, "name", node) && nodeService.IsFunctionDeclaration(language, root, parent, content) != "" {
+		return models.IdentifierKindFunction
+	}
+
+	// Called function or method
+	if isCallee(language, node, content) {
+		return models.IdentifierKindFunction
+	}
+
+	// Member of an object: its field, or a method when the member access is called
+	if isMemberName(language, parent, node) {
+		if isCallee(language, parent, content) {
+			return models.IdentifierKindFunction
+		}
+		return models.IdentifierKindField
+	}
+
+	if !nodeService.IsValidVariableToTrack(language, root, name, content) {
+		return models.IdentifierKindFunction
+	}
+
+	// Objects of member accesses can also be modules or classes (e.g. strings.ToUpper, Math.max)
+	if isMemberObject(language, parent, node) {
+		if importedNames[name] {
+			return models.IdentifierKindModule
+		}
+		if startsWithUpper(name) && !nodeService.IsVariableGlobal(language, root, name, content, log) {
+			return models.IdentifierKindModule
+		}
+	}
+
+	return models.IdentifierKindVariable
+}
+
+// -----------------------------------------------------------------------------
+// isKeywordArgumentName - Checks if a node is the name of a keyword argument.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - parent (*sitter.Node): The parent of the node.
+//   - node (*sitter.Node): The node to check.
+//
+// Returns:
+//   - (bool): True if the parent is a keyword argument and the node names its parameter, in its name field or without
+//     fields (prefix in prefix = value in Kotlin).
+//
+// -----------------------------------------------------------------------------
+func isKeywordArgumentName(language string, parent, node *sitter.Node) bool {
+	if !languageService.IsSyntaxNode(language, parent, languageService.SyntaxKeywordArgument) {
+		return false
+	}
+	if isField(parent, "name", node) {
+		return true
+	}
+	values, ok := languageService.FindVariableNodes(language, parent)
+	if !ok {
+		return false
+	}
+	for _, value := range values {
+		if sameNode(value, node) {
+			return false
+		}
+	}
+	return true
+}
+
+// -----------------------------------------------------------------------------
+// hasInterpolation - Checks if a string literal is a template interpolating expressions.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): The literal node.
+//
+// Returns:
+//   - (bool): True if the literal interpolates identifiers or expressions, whose variables are then used on the line.
+//
+// -----------------------------------------------------------------------------
+func hasInterpolation(language string, node *sitter.Node) bool {
+	for i := 0; i < int(node.NamedChildCount()); i++ {
+		if languageService.IsSyntaxNode(language, node.NamedChild(i), languageService.SyntaxInterpolation) {
+			return true
+		}
+	}
+	return false
+}
+
+// -----------------------------------------------------------------------------
+// isCallee - Checks if a node is the function of a call.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): The node to check.
+//   - content ([]byte): The content of the source code.
+//
+// Returns:
+//   - (bool): True if the node is the called function or method of its parent call.
+//
+// -----------------------------------------------------------------------------
+func isCallee(language string, node *sitter.Node, content []byte) bool {
+	parent := node.Parent()
+	if parent == nil {
+		return false
+	}
+	if languageService.IsCallNode(language, parent) || languageService.IsSyntaxNode(language, parent, languageService.SyntaxMethodCall) {
+		for _, field := range languageService.GetFieldNames(language, languageService.FieldCallee) {
+			if isField(parent, field, node) {
+				return true
+			}
+		}
+	}
+
+	// Without fields (e.g. Kotlin), the calls query names the callee, possibly the method of a navigation
+	call := parent
+	for languageService.IsSyntaxNode(language, call, languageService.SyntaxMemberSuffix) || languageService.IsSyntaxNode(language, call, languageService.SyntaxSuffixedAccess) {
+		if call = call.Parent(); call == nil {
+			return false
+		}
+	}
+	if !languageService.IsCallNode(language, call) {
+		return false
+	}
+	callee := nodeService.GetCallee(language, call, content)
+	return callee != nil && sameNode(callee, node)
+}
+
+// -----------------------------------------------------------------------------
+// isMemberName - Checks if a node is the member name of a member access.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - parent (*sitter.Node): The parent of the node.
+//   - node (*sitter.Node): The node to check.
+//
+// Returns:
+//   - (bool): True if the parent accesses a member of an object and the node is the member name.
+//
+// -----------------------------------------------------------------------------
+func isMemberName(language string, parent, node *sitter.Node) bool {
+	// Kotlin names the member in a navigation suffix (e.g. .length), without fields
+	if languageService.IsSyntaxNode(language, parent, languageService.SyntaxMemberSuffix) {
+		return true
+	}
+
+	// The name of an assignment is the assigned variable, even with a value field (e.g. NAME=value in a shell script)
+	if languageService.IsAssignmentNode(language, parent) {
+		return false
+	}
+
+	hasObject := false
+	for _, field := range getObjectFields(language) {
+		if object := parent.ChildByFieldName(field); object != nil && !sameNode(object, node) {
+			hasObject = true
+			break
+		}
+	}
+	if !hasObject {
+		return false
+	}
+
+	for _, field := range languageService.GetFieldNames(language, languageService.FieldMember) {
+		if isField(parent, field, node) {
+			return true
+		}
+	}
+	return false
+}
+
+// -----------------------------------------------------------------------------
+// isMemberObject - Checks if a node is the object of a member access.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - parent (*sitter.Node): The parent of the node.
+//   - node (*sitter.Node): The node to check.
+//
+// Returns:
+//   - (bool): True if the node is the object whose member is accessed.
+//
+// -----------------------------------------------------------------------------
+func isMemberObject(language string, parent, node *sitter.Node) bool {
+	// Kotlin puts the object before the suffixes of the access, without fields
+	if languageService.IsSyntaxNode(language, parent, languageService.SyntaxMemberAccess) && languageService.IsSyntaxNode(language, parent, languageService.SyntaxSuffixedAccess) {
+		return sameNode(parent.NamedChild(0), node)
+	}
+	for _, field := range getObjectFields(language) {
+		if isField(parent, field, node) {
+			return true
+		}
+	}
+	return false
+}
+
+// -----------------------------------------------------------------------------
+// collectImportedNames - Collects the names declared by the imports of a file.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - root (*sitter.Node): The root node of the syntax tree.
+//   - content ([]byte): The content of the source code.
+//
+// Returns:
+//   - (map[string]bool): The imported names (aliases, last segments of the module paths).
+//
+// -----------------------------------------------------------------------------
+func collectImportedNames(language string, root *sitter.Node, content []byte) map[string]bool {
+	names := make(map[string]bool)
+
+	var collect func(node *sitter.Node)
+	collect = func(node *sitter.Node) {
+		text := strings.Trim(node.Content(content), "\"'`<>")
+		if languageService.IsSyntaxNode(language, node, languageService.SyntaxDetectedName) || languageService.IsSyntaxNode(language, node, languageService.SyntaxModuleName) {
+			// Only the last segment of a qualified path names the module (java.nio.file.Files)
+			parent := node.Parent()
+			if parent == nil || !isQualifiedPath(language, parent) || (sameNode(parent.NamedChild(int(parent.NamedChildCount())-1), node) && (parent.Parent() == nil || !isQualifiedPath(language, parent.Parent()))) {
+				names[text] = true
+			}
+		} else if node.NamedChildCount() == 0 {
+			// Module paths: "net/http", java.util.List, System.IO
+			segments := strings.FieldsFunc(text, func(char rune) bool { return char == '/' || char == '.' || char == ':' || char == '\\' })
+			if len(segments) > 0 {
+				names[segments[len(segments)-1]] = true
+			}
+		}
+		for i := 0; i < int(node.NamedChildCount()); i++ {
+			collect(node.NamedChild(i))
+		}
+	}
+
+	var traverse func(node *sitter.Node)
+	traverse = func(node *sitter.Node) {
+		if languageService.IsSyntaxNode(language, node, languageService.SyntaxImport) {
+			collect(node)
+			return
+		}
+		for i := 0; i < int(node.NamedChildCount()); i++ {
+			traverse(node.NamedChild(i))
+		}
+	}
+	traverse(root)
+
+	return names
+}
+
+// -----------------------------------------------------------------------------
+// isQualifiedPath - Checks if a node is a qualified path made of several names.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - node (*sitter.Node): The node to check.
+//
+// Returns:
+//   - (bool): True if the node is a dotted or scoped name (e.g. java.util.List, std::fs).
+//
+// -----------------------------------------------------------------------------
+func isQualifiedPath(language string, node *sitter.Node) bool {
+	return languageService.IsSyntaxNode(language, node, languageService.SyntaxQualifiedPath)
+}
+
+// -----------------------------------------------------------------------------
+// isJSXElementName - Checks if a node is the name of a JSX element, or a part of its qualified name (e.g. Foo.Bar).
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//   - parent (*sitter.Node): The parent of the node.
+//   - node (*sitter.Node): The node to check.
+//
+// Returns:
+//   - (bool): True if the node names a JSX element.
+//
+// -----------------------------------------------------------------------------
+func isJSXElementName(language string, parent, node *sitter.Node) bool {
+	for parent != nil && languageService.IsSyntaxNode(language, parent, languageService.SyntaxMemberAccess) {
+		node, parent = parent, parent.Parent()
+	}
+	return parent != nil && languageService.IsSyntaxNode(language, parent, languageService.SyntaxMarkupElement) && isField(parent, "name", node)
+}
+
+// -----------------------------------------------------------------------------
+// getObjectFields - Returns the fields holding the object of a member access, or the qualifier of a scoped name.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - language (string): The language of the syntax tree.
+//
+// Returns:
+//   - ([]string): The object fields of the language, followed by its qualifier fields (Store in Store::open).
+//
+// -----------------------------------------------------------------------------
+func getObjectFields(language string) []string {
+	fields := languageService.GetFieldNames(language, languageService.FieldObject)
+	return append(append([]string(nil), fields...), languageService.GetFieldNames(language, languageService.FieldQualifier)...)
+}
+
+// -----------------------------------------------------------------------------
+// isField - Checks if a node is the child of its parent for a field.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - parent (*sitter.Node): The parent node.
+//   - field (string): The field name.
+//   - node (*sitter.Node): The node to check.
+//
+// Returns:
+//   - (bool): True if the child of the parent for the field is the node.
+//
+// -----------------------------------------------------------------------------
+func isField(parent *sitter.Node, field string, node *sitter.Node) bool {
+	child := parent.ChildByFieldName(field)
+	return child != nil && sameNode(child, node)
+}
+
+// -----------------------------------------------------------------------------
+// sameNode - Checks if two nodes are the same node of the syntax tree.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - a (*sitter.Node): The first node.
+//   - b (*sitter.Node): The second node.
+//
+// Returns:
+//   - (bool): True if both nodes have the same type and the same position.
+//
+// -----------------------------------------------------------------------------
+func sameNode(a, b *sitter.Node) bool {
+	return a.Type() == b.Type() && a.StartByte() == b.StartByte() && a.EndByte() == b.EndByte()
+}
+
+// -----------------------------------------------------------------------------
+// startsWithUpper - Checks if a name starts with an upper case letter.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - name (string): The name to check.
+//
+// Returns:
+//   - (bool): True if the first letter of the name is upper case.
+//
+// -----------------------------------------------------------------------------
+func startsWithUpper(name string) bool {
+	for _, char := range name {
+		return unicode.IsUpper(char)
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+type Request struct {
+	Path string
+	Name string
+}
+
+func main() {
+	serve(os.Args[1], os.Args[2])
+}
+
+func serve(input string, label string) {
+	req := Request{}
+	req.Path = input
+	req.Name = label
+
+	// Seul le champ Path vient de l'entrée : le nom ne fait pas partie du flux
+	fmt.Println("Opening", req.Name)
+	data, err := readRequest(req)
+	fmt.Println(string(data), err)
+}
+
+func readRequest(r Request) ([]byte, error) {
+	settings := map[string]string{}
+	settings["path"] = r.Path
+	settings["mode"] = "read"
+	return os.ReadFile(settings["path"])
+}
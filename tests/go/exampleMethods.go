@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+type Disk struct {
+	root string
+}
+
+// Seule la méthode Open de Disk reçoit le chemin de main
+func (d *Disk) Open(name string) ([]byte, error) {
+	path := filepath.Join(d.root, name)
+	return os.ReadFile(path)
+}
+
+type Cache struct {
+	entries map[string][]byte
+}
+
+func (c *Cache) Open(name string) ([]byte, error) {
+	return c.entries[name], nil
+}
+
+func main() {
+	name := os.Args[1]
+	disk := &Disk{root: os.Getenv("DATA_DIR")}
+	data, err := disk.Open(name)
+	fmt.Println(string(data), err)
+}
@@ -0,0 +1,28 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func buildPath(base string, name string) string {
+	clean := strings.TrimPrefix(name, "/")
+	if clean == "" {
+		return base
+	}
+	full := filepath.Join(base, clean)
+	return full
+}
+
+func openFile(name string) ([]byte, error) {
+	path := buildPath(os.Getenv("DATA_DIR"), name)
+	return os.ReadFile(path)
+}
+
+func main() {
+	name := os.Args[1]
+	data, err := openFile(name)
+	fmt.Println(string(data), err)
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+func main() {
+	content, err := readConfig("config.yaml")
+	fmt.Println(content, err)
+}
+
+func readConfig(filePath string) (string, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		// Cette erreur masque celle de la lecture : elle ne fait pas partie du flux
+		err := fmt.Errorf("cannot read %s", filePath)
+		fmt.Println(err)
+		return "", err
+	}
+	for _, line := range data {
+		err := validate(line)
+		fmt.Println(err)
+	}
+	return string(data), err
+}
+
+func validate(line byte) error {
+	return nil
+}
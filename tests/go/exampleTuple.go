@@ -0,0 +1,17 @@
+package main
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+)
+
+func main() {
+	p := os.Args[1]
+	// Les deux résultats de l'appel viennent de p, pas du paquet ioutil
+	data, err := ioutil.ReadFile(p)
+	if err != nil {
+		fmt.Println(err)
+	}
+	fmt.Println(string(data))
+}
@@ -0,0 +1,43 @@
+// Comparison of the outputs written by the tests with their golden files, shared by the exporter tests.
+
+package golden
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"testing"
+)
+
+// Rewrites the golden files with the current output (go test ./services/sarifService -update)
+var update = flag.Bool("update", false, "update the golden files")
+
+// -----------------------------------------------------------------------------
+// Check - Compares an output with its golden file, after rewriting the file when the tests run with -update
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - t (*testing.T): The test that produced the output.
+//   - goldenPath (string): The path of the golden file, relative to the package of the test.
+//   - got ([]byte): The output to compare.
+//
+// Returns:
+//   - None
+//
+// -----------------------------------------------------------------------------
+func Check(t *testing.T, goldenPath string, got []byte) {
+	t.Helper()
+
+	if *update {
+		if err := os.WriteFile(goldenPath, got, 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(goldenPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("output differs from %s:\n%s", goldenPath, got)
+	}
+}
@@ -0,0 +1,13 @@
+const fs = require('fs');
+
+function openFile(options) {
+    // Seul le chemin vient des options, le mode a une valeur par défaut
+    const { path, mode = 'r' } = options;
+    let [first, second] = [path, mode];
+    [first, second] = [second, first];
+    const content = fs.readFileSync(second, mode);
+    return content;
+}
+
+const options = { path: process.argv[2], mode: 'utf8' };
+openFile(options);
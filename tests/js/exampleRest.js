@@ -0,0 +1,9 @@
+const fs = require('fs');
+
+function readFiles(first, ...rest) {
+    // Le second chemin vient du premier argument après first, pas de l'indice 0
+    const second = rest[0];
+    return fs.readFileSync(second, 'utf8');
+}
+
+readFiles('a.txt', process.argv[2]);
@@ -0,0 +1,14 @@
+const fs = require('fs');
+
+function readConfig(filePath) {
+    let content = fs.readFileSync(filePath, 'utf8');
+    if (content.length === 0) {
+        // Ce contenu masque celui du fichier dans le bloc
+        let content = 'default';
+        console.log(content);
+    }
+    const config = content;
+    return config;
+}
+
+readConfig('config.json');
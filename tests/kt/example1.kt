@@ -0,0 +1,59 @@
+import java.io.File
+
+// Fonction DataFlowTest
+fun DataFlowTest(filePath: String, test: String): String {
+    var filePath = "example backward"
+    var newPath = filePath
+    var result: String
+    newPath = functionTest()
+
+    // Vérifie si le fichier existe
+    val file = File(newPath)
+    if (!file.exists()) {
+        result = "File does not exist"
+    } else {
+        // Lis le contenu du fichier
+        try {
+            result = file.readText()
+        } catch (e: Exception) {
+            result = "Error reading file"
+        }
+    }
+
+    newPath = "test"
+    return result
+}
+
+// Fonction functionTest
+fun functionTest(): String {
+    return "example backward"
+}
+
+// Fonction TEST2
+fun TEST2(test: String): String {
+    var test = "example testAAA"
+    return test
+}
+
+// Fonction test
+fun test() {
+    val filePath = "example.txt"
+    if (filePath.isEmpty()) {
+        println("File does not exist")
+    }
+
+    val testStr = "test"
+    TEST2(filePathModified)
+
+    val filePathModified = filePathModified + "1"
+    val test = "1"
+    val message = DataFlowTest(filePathModified, test = test)
+
+    println(message)
+}
+
+// Fonction principale
+fun main() {
+    val filePath = "example backward"
+    test()
+}
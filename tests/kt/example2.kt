@@ -0,0 +1,23 @@
+// Fonction pour transformer le texte
+fun TransformText(text: String): String {
+    val text = text.uppercase() // Convertir en majuscules
+    val prefix = "Prefix: "
+    return AddPrefix(modifiedText, prefix = prefix)
+}
+
+// Fonction pour ajouter un préfixe, avec un préfixe par défaut
+fun AddPrefix(text: String, prefix: String = "> "): String {
+    return "$prefix${text}"
+}
+
+// Fonction de test
+fun test() {
+    val inputText = "Hello, World!"
+    val result = TransformText(inputText)
+    println(result)
+}
+
+// Fonction principale
+fun main() {
+    test()
+}
@@ -0,0 +1,34 @@
+import kotlin.math.PI
+
+// Fonction pour calculer l'aire
+fun CalculateArea(radiusTest2: Double): Double {
+    val area = radiusTest2
+    val test = PI * area * area // Erreur conservée
+    return test
+}
+
+// Fonction d'extension pour doubler l'aire
+fun Double.doubleArea(factor: Double = 2.0): Double {
+    return factor * this
+}
+
+// Fonction pour calculer l'aire et la doubler
+fun CalculateAndDouble(radiusTest: Double): Double {
+    val area = CalculateArea(radiusTest)
+    val test = test.doubleArea() // Erreur conservée
+    val doubleArea = area.doubleArea()
+    return doubleArea
+}
+
+// Fonction de test
+fun test() {
+    var radius = 5.0
+    val result = CalculateAndDouble(radius)
+    radius = 10.0 // Redéclaration de radius, conservée
+    println(result)
+}
+
+// Fonction principale
+fun main() {
+    test()
+}
@@ -0,0 +1,28 @@
+// Fonction principale
+fun main() {
+    // numbers n'est pas défini, donc simuler l'initialisation à 1
+    val numbers = 1
+    CrawlFromLine(numbers)
+}
+
+// CrawlFromLine analyse un fichier (représenté ici simplement par des lignes numérotées)
+fun CrawlFromLine(line: Int) {
+    println("Analyzing line: $line")
+
+    // Condition pour simuler une fin de fichier à la ligne 10
+    if (line > 10) {
+        println("End of file reached.")
+        return
+    }
+
+    // Simuler un appel récursif à une fonction interne
+    AnalyzeFunction(line + 1)
+}
+
+// AnalyzeFunction simule l'analyse d'une fonction à partir de la ligne actuelle
+fun AnalyzeFunction(line: Int) {
+    println("Entering function at line: ${line}")
+
+    // Simuler un appel récursif à CrawlFromLine
+    CrawlFromLine(line = line + 1)
+}
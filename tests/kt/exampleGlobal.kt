@@ -0,0 +1,37 @@
+var secret = "MYSUPERSECRETKEY"
+val notAfter = 60 // 1 minute
+
+fun main() {
+    val username = "user"
+    val password = "pass"
+    val generatedToken = keygen(username, password, loginRequired = true)
+    if (generatedToken != null && generatedToken.isNotEmpty()) {
+        println("Generated token: $generatedToken")
+    } else {
+        println("Login failed.")
+    }
+}
+
+fun login(username: String, password: String): Boolean {
+    // Mock login function
+    return username.isNotEmpty() && password.isNotEmpty()
+}
+
+fun encodeToken(username: String, expiry: Int, secret: String = ""): String {
+    // Simplified token encoding for demonstration
+    return "encoded($username,$expiry,$secret)"
+}
+
+fun keygen(username: String, password: String, loginRequired: Boolean = false): String? {
+    if (loginRequired) {
+        if (!login(username, password)) {
+            return null
+        }
+    }
+
+    val now = 100 // Mock current time
+    secret = secret ?: ""
+    val token = encodeToken(username, now + notAfter, secret = secret)
+
+    return token
+}
@@ -0,0 +1,14 @@
+import java.io.File
+
+fun resolve(base: String = System.getProperty("user.dir"), name: String): String {
+    // Le chemin est construit à partir des deux entrées du modèle
+    val path = "$base/$name"
+    return path
+}
+
+fun main(args: Array<String>) {
+    val input = args[0]
+    // L'argument nommé va au paramètre name, base prend sa valeur par défaut
+    val path = resolve(name = input)
+    println(File(path).readText())
+}
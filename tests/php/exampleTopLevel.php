@@ -0,0 +1,7 @@
+<?php
+
+// Script sans fonction : le code est exécuté au niveau du fichier
+$name = $_GET['name'];
+$greeting = "Bonjour " . $name;
+$message = strtoupper($greeting);
+echo $message;
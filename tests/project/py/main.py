@@ -0,0 +1,7 @@
+from pkg.util import build_path
+
+
+def main():
+    name = input("File: ")
+    path = build_path(name)
+    print(open(path).read())
@@ -0,0 +1,7 @@
+import os
+
+
+def build_path(name, suffix=".txt"):
+    base = os.environ["DATA_DIR"]
+    path = os.path.join(base, name + suffix)
+    return path
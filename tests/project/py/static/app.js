@@ -0,0 +1,8 @@
+// Script de la page : le chemin est construit dans le navigateur, par une fonction du même nom que celle de pkg/util.py
+function build_path(name) {
+    const path = "/files/" + name;
+    return path;
+}
+
+const path = build_path(location.hash);
+fetch(path);
@@ -0,0 +1,26 @@
+import sys
+
+
+class Disk:
+    def locate(self, name):
+        path = "/data/" + name
+        return path
+
+
+class Cache:
+    def locate(self, name):
+        return "cached"
+
+
+def load(storage, name):
+    # Le type de storage est inconnu : le chemin peut venir de Disk.locate ou de Cache.locate
+    path = storage.locate(name)
+    return open(path).read()
+
+
+def preview(storage):
+    # Cet autre appel ne mène pas au chemin chargé par load
+    return storage.locate("preview")
+
+
+print(load(Cache(), sys.argv[1]))
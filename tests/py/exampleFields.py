@@ -0,0 +1,12 @@
+import sys
+
+
+def load(cfg):
+    # Le chemin est lu dans une clé du dictionnaire : la valeur vient de cfg, pas d'un littéral
+    v = cfg["path"]
+    return open(v).read()
+
+
+def main():
+    cfg = {"path": sys.argv[1], "mode": "r"}
+    print(load(cfg))
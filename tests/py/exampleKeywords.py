@@ -0,0 +1,15 @@
+import os
+import sys
+
+
+def read_file(name, directory=os.getcwd(), *extra, mode="r", **options):
+    # Le répertoire prend sa valeur par défaut quand l'appel ne le donne pas
+    path = os.path.join(directory, name)
+    encoding = options.get("encoding")
+    with open(path, mode, encoding=encoding) as f:
+        return f.read()
+
+
+settings = {"encoding": sys.argv[2]}
+read_file(name=sys.argv[1], mode="rb", **settings)
+read_file(sys.argv[1], os.environ["DATA_DIR"], "extra", encoding="utf-8")
@@ -0,0 +1,34 @@
+import os
+import sys
+
+
+class Disk:
+    def __init__(self, root):
+        self.root = root
+
+    def open(self, name):
+        # self.resolve appelle la méthode de Disk, pas celle de Cache
+        path = self.resolve(name)
+        with open(path) as f:
+            return f.read()
+
+    def resolve(self, name):
+        return os.path.join(self.root, name)
+
+
+class Cache:
+    def resolve(self, name):
+        return name
+
+    def open(self, name):
+        return self.resolve(name)
+
+
+def load(storage, name):
+    # Le type de storage est inconnu : l'appel peut viser Disk.open ou Cache.open
+    return storage.open(name)
+
+
+name = sys.argv[1]
+content = load(Disk(os.getcwd()), name)
+print(content)
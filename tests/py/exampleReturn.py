@@ -0,0 +1,36 @@
+import os
+import sys
+
+
+def build_path(base, name):
+    clean = name.lstrip("/")
+    if not clean:
+        return base
+    full = os.path.join(base, clean)
+    return full
+
+
+def main():
+    base = os.environ.get("DATA_DIR")
+    name = sys.argv[1]
+    path = build_path(base, name)
+    with open(path) as f:
+        print(f.read())
+
+
+def resolve(base, name, cache):
+    # Le chemin renvoyé vient d'un littéral ou des deux paramètres
+    if name in cache:
+        return "cached"
+    full = base + "/" + name
+    return full
+
+
+def lookup(cache):
+    base = os.environ.get("DATA_DIR")
+    name = sys.argv[2]
+    target = resolve(base, name, cache)
+    print(target)
+
+
+main()
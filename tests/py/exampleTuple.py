@@ -0,0 +1,14 @@
+import os
+import sys
+
+
+def read_pair(directory):
+    # Le nom et le répertoire sont échangés avant la lecture
+    name, folder = sys.argv[1], directory
+    folder, name = name, folder
+    path = folder + os.sep + name
+    with open(path) as f:
+        return f.read()
+
+
+read_pair(os.environ.get("DATA_DIR"))
@@ -0,0 +1,9 @@
+def read_file(name, *extra, directory: Dir.pwd, **options)
+  # Les arguments par mot-clé rejoignent leurs paramètres quel que soit leur ordre
+  path = File.join(directory, name)
+  File.read(path, **options)
+end
+
+base = ENV["DATA_DIR"]
+read_file(ARGV[0], directory: base)
+read_file(ARGV[1], "extra", mode: "rb")
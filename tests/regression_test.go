@@ -0,0 +1,1004 @@
+package main
+
+import (
+	"bufio"
+	"dataflow/core"
+	"dataflow/logger"
+	"dataflow/models"
+	"dataflow/services/languageService"
+	"dataflow/services/nodeService"
+	"dataflow/services/projectService"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+// baselineCase - Analysis of a base example, with the steps the initial version of the tool found for it
+type baselineCase struct {
+	language  string
+	filePath  string
+	startLine int
+	variable  string
+	steps     []string // "<line> <type>", in line order
+}
+
+// -----------------------------------------------------------------------------
+// TestBaselineSteps - Checks that the base examples still give the steps of the initial version of the tool.
+// -----------------------------------------------------------------------------
+func TestBaselineSteps(t *testing.T) {
+	cases, err := loadBaselineCases(filepath.Join("testdata", "baseline_steps.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range cases {
+		test := test
+		t.Run(fmt.Sprintf("%s:%d", test.filePath, test.startLine), func(t *testing.T) {
+			dataflow, err := core.RunDataflowAnalysis(models.Config{
+				FilePath:  filepath.Join("..", test.filePath),
+				StartLine: test.startLine,
+				Language:  test.language,
+				Variable:  test.variable,
+			})
+			if err != nil {
+				t.Fatalf("analysis failed: %v", err)
+			}
+
+			steps := describeSteps(dataflow)
+			if strings.Join(steps, "\n") != strings.Join(test.steps, "\n") {
+				t.Errorf("steps of '%s' changed:\n got:\n  %s\n want:\n  %s", test.variable, strings.Join(steps, "\n  "), strings.Join(test.steps, "\n  "))
+			}
+		})
+	}
+}
+
+// -----------------------------------------------------------------------------
+// loadBaselineCases - Reads the base examples and their steps.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - path (string): The path of the file of the steps (testdata/baseline_steps.txt).
+//
+// Returns:
+//   - ([]baselineCase): The examples, in the order of the file.
+//   - (error): An error object if the file could not be read or is malformed.
+//
+// -----------------------------------------------------------------------------
+func loadBaselineCases(path string) ([]baselineCase, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	var cases []baselineCase
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		switch {
+		case line == "" || strings.HasPrefix(line, "#"):
+			continue
+		case strings.HasPrefix(line, "== "):
+			fields := strings.Fields(strings.TrimPrefix(line, "== "))
+			if len(fields) != 4 {
+				return nil, fmt.Errorf("invalid example header: %q", line)
+			}
+			startLine, err := strconv.Atoi(fields[2])
+			if err != nil {
+				return nil, fmt.Errorf("invalid start line: %q", line)
+			}
+			cases = append(cases, baselineCase{language: fields[0], filePath: fields[1], startLine: startLine, variable: fields[3]})
+		case len(cases) > 0:
+			cases[len(cases)-1].steps = append(cases[len(cases)-1].steps, line)
+		default:
+			return nil, fmt.Errorf("step outside of an example: %q", line)
+		}
+	}
+	return cases, scanner.Err()
+}
+
+// -----------------------------------------------------------------------------
+// describeSteps - Describes the steps of a data flow.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - dataflow ([]models.DataFlow): The data flow.
+//
+// Returns:
+//   - ([]string): The steps as "<line> <type>", in line order.
+//
+// -----------------------------------------------------------------------------
+func describeSteps(dataflow []models.DataFlow) []string {
+	var steps []string
+	for _, step := range sortSteps(dataflow) {
+		steps = append(steps, fmt.Sprintf("%d %s", step.Line, step.Type))
+	}
+	return steps
+}
+
+// -----------------------------------------------------------------------------
+// sortSteps - Sorts the steps of a data flow.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - dataflow ([]models.DataFlow): The data flow.
+//
+// Returns:
+//   - ([]models.DataFlow): A copy of the steps, in line order then in type order.
+//
+// -----------------------------------------------------------------------------
+func sortSteps(dataflow []models.DataFlow) []models.DataFlow {
+	sorted := append([]models.DataFlow(nil), dataflow...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if sorted[i].Line != sorted[j].Line {
+			return sorted[i].Line < sorted[j].Line
+		}
+		return sorted[i].Type < sorted[j].Type
+	})
+	return sorted
+}
+
+// -----------------------------------------------------------------------------
+// TestChainedAssignments - Checks that the variables found on a line are analyzed on the same line.
+// -----------------------------------------------------------------------------
+func TestChainedAssignments(t *testing.T) {
+	tests := []struct {
+		filePath  string
+		content   string
+		startLine int
+	}{
+		{"chain.js", "function f(base) {\n    full = base;\n    other = full;\n    return other;\n}\n", 4},
+		{"chain.py", "def f(base):\n    full = base\n    other = full\n    return other\n", 4},
+		{"Chain.java", "class Chain {\n    String f(String base) {\n        String full = base;\n        String other = full;\n        return other;\n    }\n}\n", 5},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.filePath, func(t *testing.T) {
+			_, steps, err := core.RunDataflowAnalysisWithSteps(models.Config{
+				FilePath:  test.filePath,
+				Content:   []byte(test.content),
+				StartLine: test.startLine,
+				Variable:  "other",
+			})
+			if err != nil {
+				t.Fatalf("analysis failed: %v", err)
+			}
+
+			// base is found on the line of full, while full is analyzed
+			for _, variable := range []string{"other", "full", "base"} {
+				found := false
+				for _, step := range steps {
+					found = found || step.Variable == variable
+				}
+				if !found {
+					t.Errorf("no step for '%s' in %v", variable, steps)
+				}
+			}
+		})
+	}
+}
+
+// fixtureCase - Analysis of an example of the tests directory, with the steps it must give
+type fixtureCase struct {
+	language  string
+	filePath  string
+	startLine int
+	variable  string
+	steps     []string // "<line> <type>", in line order
+}
+
+// Examples of the followed constructs and their steps
+var fixtureCases = []fixtureCase{
+	// The value read in a key of a dictionary comes from the dictionary, not from the literal key
+	{"python", "tests/py/exampleFields.py", 7, "v", []string{
+		"4 Function parameters",
+		"6 Assignment of value",
+		"6 Assignment of value",
+		"7 Function parameters",
+		"11 Assignment of value",
+		"11 Assignment of value",
+		"12 Function parameters",
+	}},
+	// The returned value comes from a literal or from both variables of a binary expression
+	{"python", "tests/py/exampleReturn.py", 33, "target", []string{
+		"21 Function parameters",
+		"23 Variable used in 'if' condition",
+		"24 Returned Literal Value",
+		"25 Assignment of value",
+		"25 Assignment of value",
+		"25 Assignment of value",
+		"26 Variable used in return statement",
+		"30 Assignment of value",
+		"30 Assignment of value",
+		"31 Assignment of value",
+		"31 Assignment of value",
+		"32 Assignment of value",
+		"32 Assignment of value",
+		"32 Assignment of value",
+		"32 Assignment of value",
+		"33 Function parameters",
+	}},
+	// Every result of a call comes from the arguments of the call, not from the package of the function
+	{"go", "tests/go/exampleTuple.go", 14, "err", []string{
+		"10 Assignment of value",
+		"10 Assignment of value",
+		"12 Assignment of value",
+		"12 Assignment of value",
+		"13 Variable used in 'if' condition",
+		"14 Function parameters",
+	}},
+	// An element of a rest parameter comes from the argument at its index, not from the literal index
+	{"javascript", "tests/js/exampleRest.js", 6, "second", []string{
+		"3 Function parameters",
+		"5 Assignment of value",
+		"5 Assignment of value",
+		"6 Function parameters",
+		"9 Function parameters",
+	}},
+	// The value of an ambiguous call comes from the returns of its candidates, each one leading back to this call only
+	{"python", "tests/py/exampleCandidates.py", 18, "path", []string{
+		"1 Use of variable",
+		"5 Function parameters",
+		"6 Assignment of value",
+		"6 Assignment of value",
+		"7 Variable used in return statement",
+		"11 Function parameters",
+		"12 Returned Literal Value",
+		"15 Function parameters",
+		"17 Assignment of value",
+		"17 Assignment of value",
+		"17 Assignment of value",
+		"18 Function parameters",
+		"26 Function parameters",
+	}},
+	// A named argument goes to the parameter of its name, and every entry of a template is a source of the string
+	{"kotlin", "tests/kt/exampleKeywords.kt", 13, "path", []string{
+		"3 Default Parameter Value",
+		"3 Function parameters",
+		"5 Assignment of value",
+		"5 Assignment of value",
+		"5 Assignment of value",
+		"6 Variable used in return statement",
+		"10 Assignment of value",
+		"10 Assignment of value",
+		"12 Assignment of value",
+		"12 Assignment of value",
+		"13 Function parameters",
+	}},
+	// A script embedded in a template is analyzed in the language of the script
+	{"javascript", "tests/templates/page.php", 13, "q", []string{
+		"11 Assignment of value",
+		"11 Assignment of value",
+		"11 Global Variable Declaration",
+		"12 Assignment of value",
+		"12 Assignment of value",
+		"12 Global Variable Declaration",
+		"13 Variable used in assignment",
+	}},
+	{"typescript", "tests/templates/App.vue", 9, "query", []string{
+		"7 Assignment of value",
+		"7 Global Variable Declaration",
+		"8 Assignment of value",
+		"8 Assignment of value",
+		"8 Global Variable Declaration",
+		"9 Assignment of value",
+		"9 Function parameters",
+		"9 Global Variable Declaration",
+	}},
+	// The code of a template line starts after its markup
+	{"php", "tests/templates/page.php", 9, "$greeting", []string{
+		"6 Assignment of value",
+		"6 Assignment of value",
+		"6 Global Variable Declaration",
+		"7 Assignment of value",
+		"7 Assignment of value",
+		"7 Global Variable Declaration",
+		"9 Use of variable",
+	}},
+	// The code of a script without functions is analyzed like the body of a function
+	{"php", "tests/php/exampleTopLevel.php", 7, "$message", []string{
+		"4 Assignment of value",
+		"4 Assignment of value",
+		"4 Global Variable Declaration",
+		"5 Assignment of value",
+		"5 Assignment of value",
+		"5 Global Variable Declaration",
+		"6 Assignment of value",
+		"6 Assignment of value",
+		"6 Global Variable Declaration",
+		"7 Use of variable",
+	}},
+	// A variable declared in an inner block shadows the tracked one in that block only
+	{"go", "tests/go/exampleShadowing.go", 25, "err", []string{
+		"14 Assignment of value",
+		"14 Assignment of value",
+		"15 Variable used in 'if' condition",
+		"25 Variable used in return statement",
+	}},
+	{"javascript", "tests/js/exampleShadowing.js", 11, "config", []string{
+		"4 Assignment of value",
+		"4 Assignment of value",
+		"5 Variable used in 'if' condition",
+		"10 Assignment of value",
+		"10 Assignment of value",
+		"11 Variable used in return statement",
+	}},
+	{"rust", "tests/rs/exampleShadowing.rs", 11, "content", []string{
+		"4 Assignment of value",
+		"4 Assignment of value",
+		"5 Assignment of value",
+		"11 Use of variable",
+	}},
+	// A key of a map is followed as its own access path, back through the fields it is copied from
+	{"go", "tests/go/exampleFields.go", 32, "settings[\"path\"]", []string{
+		"14 Function parameters",
+		"17 Function parameters",
+		"18 Assignment of value",
+		"19 Assignment of value",
+		"19 Assignment of value",
+		"24 Function parameters",
+		"28 Function parameters",
+		"29 Assignment of value",
+		"30 Assignment of value",
+		"30 Assignment of value",
+		"32 Function parameters",
+	}},
+	// The value returned by a function comes from its return statements, then from the arguments of the call
+	{"go", "tests/go/exampleReturn.go", 21, "path", []string{
+		"10 Function parameters",
+		"11 Assignment of value",
+		"11 Assignment of value",
+		"12 Variable used in 'if' condition",
+		"12 Variable used in 'if' condition",
+		"13 Variable used in return statement",
+		"15 Assignment of value",
+		"15 Assignment of value",
+		"15 Assignment of value",
+		"16 Variable used in return statement",
+		"19 Function parameters",
+		"20 Assignment of value",
+		"20 Assignment of value",
+		"21 Function parameters",
+		"25 Assignment of value",
+		"25 Assignment of value",
+		"26 Function parameters",
+	}},
+	{"python", "tests/py/exampleReturn.py", 17, "path", []string{
+		"5 Function parameters",
+		"6 Assignment of value",
+		"6 Assignment of value",
+		"7 Variable used in 'if' condition",
+		"7 Variable used in 'if' condition",
+		"8 Variable used in return statement",
+		"9 Assignment of value",
+		"9 Assignment of value",
+		"9 Assignment of value",
+		"9 Assignment of value",
+		"10 Variable used in return statement",
+		"14 Assignment of value",
+		"14 Assignment of value",
+		"15 Assignment of value",
+		"15 Assignment of value",
+		"16 Assignment of value",
+		"16 Assignment of value",
+		"16 Assignment of value",
+		"17 Function parameters",
+	}},
+	// Each target of a destructuring or a swap receives its own value
+	{"javascript", "tests/js/exampleDestructuring.js", 8, "second", []string{
+		"3 Function parameters",
+		"5 Assignment of value",
+		"5 Assignment of value",
+		"6 Assignment of value",
+		"6 Assignment of value",
+		"7 Assignment of value",
+		"7 Assignment of value",
+		"8 Assignment of value",
+		"8 Function parameters",
+		"12 Assignment of value",
+		"12 Assignment of value",
+		"12 Global Variable Declaration",
+		"13 Function parameters",
+	}},
+	// Each variable of a tuple assignment receives the element at its position
+	{"python", "tests/py/exampleTuple.py", 10, "path", []string{
+		"7 Assignment of value",
+		"7 Assignment of value",
+		"8 Assignment of value",
+		"8 Assignment of value",
+		"9 Assignment of value",
+		"9 Assignment of value",
+		"9 Assignment of value",
+		"9 Assignment of value",
+		"10 Function parameters",
+	}},
+	// A parameter left out by a call comes from its default value, the others from the arguments bound by name
+	{"python", "tests/py/exampleKeywords.py", 7, "directory", []string{
+		"1 Use of variable",
+		"5 Default Parameter Value",
+		"5 Function parameters",
+		"7 Assignment of value",
+		"7 Function parameters",
+		"15 Function parameters",
+	}},
+	{"ruby", "tests/rb/exampleKeywords.rb", 3, "directory", []string{
+		"1 Default Parameter Value",
+		"1 Function parameters",
+		"3 Assignment of value",
+		"3 Function parameters",
+		"7 Assignment of value",
+		"7 Global Variable Declaration",
+		"8 Function parameters",
+	}},
+	// A method call goes to the method of the type of its receiver, or to every candidate when the type is unknown
+	{"go", "tests/go/exampleMethods.go", 31, "data", []string{
+		"14 Function parameters",
+		"15 Assignment of value",
+		"15 Assignment of value",
+		"15 Assignment of value",
+		"16 Function parameters",
+		"28 Assignment of value",
+		"28 Assignment of value",
+		"29 Assignment of value",
+		"30 Assignment of value",
+		"30 Assignment of value",
+		"30 Assignment of value",
+		"31 Function parameters",
+	}},
+	{"python", "tests/py/exampleMethods.py", 34, "content", []string{
+		"2 Use of variable",
+		"11 Assignment of value",
+		"11 Assignment of value",
+		"15 Function Declaration",
+		"16 Function parameters",
+		"20 Function parameters",
+		"24 Function parameters",
+		"27 Function parameters",
+		"29 Function parameters",
+		"29 Variable used in return statement",
+		"32 Assignment of value",
+		"32 Assignment of value",
+		"32 Global Variable Declaration",
+		"33 Assignment of value",
+		"33 Assignment of value",
+		"33 Global Variable Declaration",
+		"34 Function parameters",
+	}},
+}
+
+// -----------------------------------------------------------------------------
+// TestFixtureSteps - Checks the steps of the examples of the followed constructs.
+// -----------------------------------------------------------------------------
+func TestFixtureSteps(t *testing.T) {
+	for _, test := range fixtureCases {
+		test := test
+		t.Run(fmt.Sprintf("%s:%d", test.filePath, test.startLine), func(t *testing.T) {
+			dataflow, err := core.RunDataflowAnalysis(models.Config{
+				FilePath:  filepath.Join("..", test.filePath),
+				StartLine: test.startLine,
+				Language:  test.language,
+				Variable:  test.variable,
+			})
+			if err != nil {
+				t.Fatalf("analysis failed: %v", err)
+			}
+
+			steps := describeSteps(dataflow)
+			if strings.Join(steps, "\n") != strings.Join(test.steps, "\n") {
+				t.Errorf("steps of '%s':\n got:\n  %s\n want:\n  %s", test.variable, strings.Join(steps, "\n  "), strings.Join(test.steps, "\n  "))
+			}
+		})
+	}
+}
+
+// Sources followed forward to the places their value ends up in, with the steps they must give
+var forwardCases = []fixtureCase{
+	// A value goes through the conditions and the assignments of its function, then through the return to the caller
+	{"go", "tests/go/example1.go", 13, "result", []string{
+		"13 Use of variable",
+		"17 Variable used in 'if' condition",
+		"18 Variable used in assignment",
+		"22 Variable used in 'if' condition",
+		"23 Variable used in assignment",
+		"25 Variable used in assignment",
+		"31 Variable used in return statement",
+		"45 Assignment of value",
+		"47 Function parameters",
+	}},
+	// An argument goes into the parameter of the called function, and its returned value back to the variable of the call
+	{"python", "tests/py/exampleReturn.py", 15, "name", []string{
+		"6 Assignment of value",
+		"6 Variable used in function call",
+		"7 Variable used in 'if' condition",
+		"9 Assignment of value",
+		"9 Function parameters",
+		"10 Variable used in return statement",
+		"15 Use of variable",
+		"16 Assignment of value",
+		"16 Function parameters",
+		"17 Function parameters",
+	}},
+}
+
+// -----------------------------------------------------------------------------
+// TestForwardSteps - Checks the places a source value is followed to by a forward analysis.
+// -----------------------------------------------------------------------------
+func TestForwardSteps(t *testing.T) {
+	for _, test := range forwardCases {
+		test := test
+		t.Run(fmt.Sprintf("%s:%d", test.filePath, test.startLine), func(t *testing.T) {
+			dataflow, err := core.RunDataflowAnalysis(models.Config{
+				FilePath:  filepath.Join("..", test.filePath),
+				StartLine: test.startLine,
+				Language:  test.language,
+				Variable:  test.variable,
+				Direction: models.DirectionForward,
+			})
+			if err != nil {
+				t.Fatalf("analysis failed: %v", err)
+			}
+
+			steps := describeSteps(dataflow)
+			if strings.Join(steps, "\n") != strings.Join(test.steps, "\n") {
+				t.Errorf("steps of '%s':\n got:\n  %s\n want:\n  %s", test.variable, strings.Join(steps, "\n  "), strings.Join(test.steps, "\n  "))
+			}
+		})
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TestDetectedLanguageSteps - Checks that a file analyzed without its language is analyzed in the language of its name, its first lines or its content.
+// -----------------------------------------------------------------------------
+func TestDetectedLanguageSteps(t *testing.T) {
+	tests := []struct {
+		name      string // Detection checked by the case
+		filePath  string
+		language  string // Given language or alias, empty to detect it
+		content   string
+		startLine int
+		want      string // Language of the steps
+		steps     []string
+	}{
+		{"extension", "build.py", "", "def build(base):\n    full = base\n    return full\n", 3, "python", []string{
+			"2 Assignment of value",
+			"2 Assignment of value",
+			"3 Variable used in return statement",
+		}},
+		{"alias", "build", "rb", "def build(base)\n  full = base\n  full\nend\n", 3, "ruby", []string{
+			"2 Assignment of value",
+			"2 Assignment of value",
+			"3 Use of variable",
+		}},
+		{"shebang", "build", "", "#!/usr/bin/env python3\ndef build(base):\n    full = base\n    return full\n", 4, "python", []string{
+			"3 Assignment of value",
+			"3 Assignment of value",
+			"4 Variable used in return statement",
+		}},
+		{"modeline", "build", "", "# vim: set ft=sh:\nbuild() {\n  full=\"$1\"\n  echo \"$full\"\n}\n", 4, "bash", []string{
+			"3 Assignment of value",
+			"3 Assignment of value",
+			"4 Function parameters",
+		}},
+		{"content", "build", "", "package main\n\nfunc build(base string) string {\n\tfull := base\n\treturn full\n}\n", 5, "go", []string{
+			"4 Assignment of value",
+			"4 Assignment of value",
+			"5 Variable used in return statement",
+		}},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			dataflow, err := core.RunDataflowAnalysis(models.Config{
+				FilePath:  test.filePath,
+				Language:  test.language,
+				Content:   []byte(test.content),
+				StartLine: test.startLine,
+				Variable:  "full",
+			})
+			if err != nil {
+				t.Fatalf("analysis failed: %v", err)
+			}
+
+			for _, step := range dataflow {
+				if step.Language != test.want {
+					t.Fatalf("step at line %d analyzed as %s, want %s", step.Line, step.Language, test.want)
+				}
+			}
+			steps := describeSteps(dataflow)
+			if strings.Join(steps, "\n") != strings.Join(test.steps, "\n") {
+				t.Errorf("steps of 'full':\n got:\n  %s\n want:\n  %s", strings.Join(steps, "\n  "), strings.Join(test.steps, "\n  "))
+			}
+		})
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TestNotebookSteps - Checks that a variable of a notebook is followed across its code cells, each step being reported at its cell and its line in the cell.
+// -----------------------------------------------------------------------------
+func TestNotebookSteps(t *testing.T) {
+	dataflow, err := core.RunDataflowAnalysis(models.Config{
+		FilePath:  filepath.Join("..", "tests", "py", "exampleNotebook.ipynb"),
+		Cell:      4,
+		StartLine: 1,
+		Variable:  "path",
+	})
+	if err != nil {
+		t.Fatalf("analysis failed: %v", err)
+	}
+
+	// The markdown cells, the magics and the shell commands are not code, the lines keep their place in their cell
+	want := []string{
+		"cell 1 line 2 Use of variable",
+		"cell 1 line 4 Assignment of value",
+		"cell 1 line 4 Global Variable Declaration",
+		"cell 2 line 2 Assignment of value",
+		"cell 2 line 2 Global Variable Declaration",
+		"cell 2 line 3 Assignment of value",
+		"cell 2 line 3 Global Variable Declaration",
+		"cell 4 line 1 Function parameters",
+	}
+	seen := make(map[string]bool)
+	var got []string
+	for _, step := range dataflow {
+		if step.Cell == nil {
+			t.Fatalf("step at line %d of the notebook module has no cell", step.Line)
+		}
+		description := fmt.Sprintf("cell %d line %d %s", *step.Cell, step.Line, step.Type)
+		if !seen[description] {
+			seen[description] = true
+			got = append(got, description)
+		}
+	}
+	sort.Strings(got)
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("steps of 'path':\n got:\n  %s\n want:\n  %s", strings.Join(got, "\n  "), strings.Join(want, "\n  "))
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TestParseErrorSteps - Checks that the syntax errors of a file are reported with the analysis, the steps in their regions being flagged.
+// -----------------------------------------------------------------------------
+func TestParseErrorSteps(t *testing.T) {
+	tests := []struct {
+		filePath    string
+		content     string
+		startLine   int
+		diagnostics []string // "<kind> <line>:<column>-<end line>:<end column>"
+		steps       []string // "<line> <type>", followed by "(parse error)" in an error region
+	}{
+		// The condition without its colon makes the end of the function an error region
+		{"build.py", "def build(base):\n    full = base\n    if full\n        full = full.strip()\n    path = full\n    return path\n", 6,
+			[]string{"error 3:5-5:16"},
+			[]string{
+				"5 Use of variable (parse error)",
+				"6 Variable used in return statement",
+			}},
+		// The closing brace of the function is inserted by the parser at the end of the file
+		{"build.js", "function build(base) {\n  const full = base;\n  const path = full;\n  return path;\n", 4,
+			[]string{"missing 4:15-4:15"},
+			[]string{
+				"1 Use of variable",
+				"2 Assignment of value",
+				"2 Assignment of value",
+				"2 Global Variable Declaration",
+				"3 Assignment of value",
+				"3 Assignment of value",
+				"3 Global Variable Declaration",
+				"4 Variable used in return statement (parse error)",
+			}},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.filePath, func(t *testing.T) {
+			config := models.Config{
+				FilePath:  test.filePath,
+				Content:   []byte(test.content),
+				StartLine: test.startLine,
+				Variable:  "path",
+			}
+			analyzer := core.NewAnalyzer(config)
+			dataflow, _, err := analyzer.Run()
+			if err != nil {
+				t.Fatalf("analysis failed: %v", err)
+			}
+
+			var diagnostics []string
+			for _, diagnostic := range analyzer.Diagnostics() {
+				diagnostics = append(diagnostics, fmt.Sprintf("%s %d:%d-%d:%d", diagnostic.Kind, diagnostic.Line, diagnostic.Column, diagnostic.EndLine, diagnostic.EndColumn))
+			}
+			if strings.Join(diagnostics, "\n") != strings.Join(test.diagnostics, "\n") {
+				t.Errorf("diagnostics: got %v, want %v", diagnostics, test.diagnostics)
+			}
+
+			var steps []string
+			for _, step := range sortSteps(dataflow) {
+				description := fmt.Sprintf("%d %s", step.Line, step.Type)
+				if step.ParseError {
+					description += " (parse error)"
+				}
+				steps = append(steps, description)
+			}
+			if strings.Join(steps, "\n") != strings.Join(test.steps, "\n") {
+				t.Errorf("steps of 'path':\n got:\n  %s\n want:\n  %s", strings.Join(steps, "\n  "), strings.Join(test.steps, "\n  "))
+			}
+
+			// The analysis stops at the first error when asked to
+			config.FailOnParseError = true
+			if _, err := core.RunDataflowAnalysis(config); err == nil {
+				t.Errorf("analysis of a file with syntax errors did not fail")
+			}
+		})
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TestCallResults - Checks that every target of a multi-value call is paired with the variables of the call, not with the names it calls.
+// -----------------------------------------------------------------------------
+func TestCallResults(t *testing.T) {
+	filePath := filepath.Join("..", "tests", "go", "exampleTuple.go")
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("cannot read %s: %v", filePath, err)
+	}
+	language := languageService.GetLanguageFromExtension(filePath)
+	tree := languageService.ParseContent(content, language)
+
+	for node := nodeService.FindNodeAtLine(language, tree.RootNode(), 12); node != nil; node = node.Parent() {
+		pairs, ok := nodeService.ExtractAssignmentPairs(language, node, content)
+		if !ok {
+			continue
+		}
+		got := fmt.Sprint(pairs)
+		if want := "[{data [p] } {err [p] }]"; got != want {
+			t.Errorf("pairs of line 12: got %s, want %s", got, want)
+		}
+		return
+	}
+	t.Errorf("no assignment found at line 12")
+}
+
+// -----------------------------------------------------------------------------
+// TestAmbiguousCallValues - Checks that the values assigned and returned through an ambiguous call name its candidates.
+// -----------------------------------------------------------------------------
+func TestAmbiguousCallValues(t *testing.T) {
+	tests := []struct {
+		direction string
+		startLine int
+		variable  string
+		lines     []int // Lines of the assignment and return steps of the call
+	}{
+		{models.DirectionBackward, 18, "path", []int{7, 12, 17}},
+		{models.DirectionForward, 15, "name", []int{7, 17}},
+	}
+
+	want := "[Disk.locate (line 5) Cache.locate (line 11)]"
+	for _, test := range tests {
+		dataflow, err := core.RunDataflowAnalysis(models.Config{
+			FilePath:  filepath.Join("..", "tests", "py", "exampleCandidates.py"),
+			StartLine: test.startLine,
+			Variable:  test.variable,
+			Direction: test.direction,
+		})
+		if err != nil {
+			t.Fatalf("%s analysis failed: %v", test.direction, err)
+		}
+
+		for _, line := range test.lines {
+			found := false
+			for _, step := range dataflow {
+				if step.Line != line || step.Type == "Function parameters" {
+					continue
+				}
+				found = true
+				if got := fmt.Sprint(step.Candidates); got != want {
+					t.Errorf("%s step %q at line %d: candidates %s, want %s", test.direction, step.Type, line, got, want)
+				}
+			}
+			if !found {
+				t.Errorf("%s analysis: no step at line %d", test.direction, line)
+			}
+		}
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TestProjectStepFiles - Checks that the steps found in the functions of other project files are reported in their own file.
+// -----------------------------------------------------------------------------
+func TestProjectStepFiles(t *testing.T) {
+	root := filepath.Join("..", "tests", "project", "py")
+	dataflow, err := core.RunDataflowAnalysis(models.Config{
+		ProjectRoot: root,
+		FilePath:    filepath.Join(root, "main.py"),
+		StartLine:   7,
+		Variable:    "path",
+	})
+	if err != nil {
+		t.Fatalf("analysis failed: %v", err)
+	}
+
+	// The parameter and the default value of build_path are in pkg/util.py, not at the same lines of main.py
+	want := []string{
+		"main.py 5 Assignment of value",
+		"main.py 6 Assignment of value",
+		"main.py 7 Function parameters",
+		"pkg/util.py 4 Default Parameter Value",
+		"pkg/util.py 4 Function parameters",
+		"pkg/util.py 5 Assignment of value",
+		"pkg/util.py 6 Assignment of value",
+		"pkg/util.py 7 Variable used in return statement",
+	}
+	got := describeProjectSteps(t, root, dataflow)
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("steps of 'path':\n got:\n  %s\n want:\n  %s", strings.Join(got, "\n  "), strings.Join(want, "\n  "))
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TestProjectContent - Checks that the content given for the start file of a project is analyzed instead of the file on disk.
+// -----------------------------------------------------------------------------
+func TestProjectContent(t *testing.T) {
+	root := filepath.Join("..", "tests", "project", "py")
+	filePath := filepath.Join(root, "main.py")
+	content, err := os.ReadFile(filePath)
+	if err != nil {
+		t.Fatalf("cannot read %s: %v", filePath, err)
+	}
+
+	// An unsaved line moves the code of main.py one line down
+	content = append([]byte("import sys\n"), content...)
+	dataflow, err := core.RunDataflowAnalysis(models.Config{
+		ProjectRoot: root,
+		FilePath:    filePath,
+		Content:     content,
+		StartLine:   8,
+		Variable:    "path",
+	})
+	if err != nil {
+		t.Fatalf("analysis failed: %v", err)
+	}
+
+	want := []string{
+		"main.py 6 Assignment of value",
+		"main.py 7 Assignment of value",
+		"main.py 8 Function parameters",
+		"pkg/util.py 4 Default Parameter Value",
+		"pkg/util.py 4 Function parameters",
+		"pkg/util.py 5 Assignment of value",
+		"pkg/util.py 6 Assignment of value",
+		"pkg/util.py 7 Variable used in return statement",
+	}
+	got := describeProjectSteps(t, root, dataflow)
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("steps of 'path':\n got:\n  %s\n want:\n  %s", strings.Join(got, "\n  "), strings.Join(want, "\n  "))
+	}
+}
+
+// -----------------------------------------------------------------------------
+// TestProjectLanguages - Checks that a project is loaded with the files of every language, and that a call is only resolved in the files of its language.
+// -----------------------------------------------------------------------------
+func TestProjectLanguages(t *testing.T) {
+	root := filepath.Join("..", "tests", "project", "py")
+	project, err := projectService.LoadProject(root, logger.New(nil, nil, nil, nil))
+	if err != nil {
+		t.Fatalf("cannot load the project: %v", err)
+	}
+
+	var files []string
+	for _, file := range project.Files {
+		path, _ := filepath.Rel(root, file.Path)
+		files = append(files, filepath.ToSlash(path)+" "+file.Language)
+	}
+	sort.Strings(files)
+	want := []string{"main.py python", "pkg/util.py python", "static/app.js javascript"}
+	if strings.Join(files, "\n") != strings.Join(want, "\n") {
+		t.Errorf("files of the project:\n got:\n  %s\n want:\n  %s", strings.Join(files, "\n  "), strings.Join(want, "\n  "))
+	}
+
+	// The build_path of the script is followed, not the build_path of pkg/util.py
+	dataflow, err := core.RunDataflowAnalysis(models.Config{
+		ProjectRoot: root,
+		FilePath:    filepath.Join(root, "static", "app.js"),
+		StartLine:   8,
+		Variable:    "path",
+	})
+	if err != nil {
+		t.Fatalf("analysis failed: %v", err)
+	}
+
+	want = []string{
+		"static/app.js 2 Function parameters",
+		"static/app.js 3 Assignment of value",
+		"static/app.js 4 Variable used in return statement",
+		"static/app.js 7 Assignment of value",
+		"static/app.js 7 Global Variable Declaration",
+		"static/app.js 8 Function parameters",
+	}
+	got := describeProjectSteps(t, root, dataflow)
+	if strings.Join(got, "\n") != strings.Join(want, "\n") {
+		t.Errorf("steps of 'path':\n got:\n  %s\n want:\n  %s", strings.Join(got, "\n  "), strings.Join(want, "\n  "))
+	}
+}
+
+// -----------------------------------------------------------------------------
+// describeProjectSteps - Describes the distinct steps of a project data flow.
+// -----------------------------------------------------------------------------
+//
+// Parameters:
+//   - t (*testing.T): The test.
+//   - root (string): The root directory of the project.
+//   - dataflow ([]models.DataFlow): The data flow.
+//
+// Returns:
+//   - ([]string): The steps as "<path in the project> <line> <type>", sorted.
+//
+// -----------------------------------------------------------------------------
+func describeProjectSteps(t *testing.T, root string, dataflow []models.DataFlow) []string {
+	t.Helper()
+	seen := make(map[string]bool)
+	var steps []string
+	for _, step := range dataflow {
+		path, err := filepath.Rel(root, step.Path)
+		if err != nil {
+			t.Fatalf("step at line %d outside of the project: %s", step.Line, step.Path)
+		}
+		description := fmt.Sprintf("%s %d %s", filepath.ToSlash(path), step.Line, step.Type)
+		if !seen[description] {
+			seen[description] = true
+			steps = append(steps, description)
+		}
+	}
+	sort.Strings(steps)
+	return steps
+}
+
+// -----------------------------------------------------------------------------
+// TestDetectedVariables - Checks that the variables detected on a line are named the way the analysis tracks them.
+// -----------------------------------------------------------------------------
+func TestDetectedVariables(t *testing.T) {
+	tests := []struct {
+		filePath   string
+		startLine  int
+		candidates []string // "<name> <kind>", in the order of the line
+	}{
+		{"tests/php/example1.php", 13, []string{"$content variable", "file_get_contents function", "$newPath variable"}},
+		{"tests/go/example1.go", 21, []string{"content variable", "err variable", "ioutil module", "ReadFile function", "newPath variable"}},
+	}
+
+	for _, test := range tests {
+		config := models.Config{
+			FilePath:  filepath.Join("..", test.filePath),
+			StartLine: test.startLine,
+		}
+		candidates, err := core.DetectVariables(config)
+		if err != nil {
+			t.Fatalf("%s:%d: detection failed: %v", test.filePath, test.startLine, err)
+		}
+		var got []string
+		for _, candidate := range candidates {
+			got = append(got, candidate.VariableOrValue+" "+candidate.Kind)
+		}
+		if strings.Join(got, ", ") != strings.Join(test.candidates, ", ") {
+			t.Errorf("%s:%d: candidates %v, want %v", test.filePath, test.startLine, got, test.candidates)
+		}
+
+		// The detected variables are followed beyond their line
+		dataflow, err := core.RunDataflowAnalysis(config)
+		if err != nil {
+			t.Fatalf("%s:%d: analysis failed: %v", test.filePath, test.startLine, err)
+		}
+		followed := false
+		for _, step := range dataflow {
+			followed = followed || step.Line != test.startLine
+		}
+		if !followed {
+			t.Errorf("%s:%d: no step found beyond the start line for the detected variables", test.filePath, test.startLine)
+		}
+	}
+}
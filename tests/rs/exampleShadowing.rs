@@ -0,0 +1,16 @@
+use std::fs;
+
+fn read_config(file_path: &str) -> String {
+    let content = fs::read_to_string(file_path).unwrap();
+    let content = content.trim().to_string();
+    {
+        // Ce contenu masque le précédent jusqu'à la fin du bloc
+        let content = "default";
+        println!("{}", content);
+    }
+    content
+}
+
+fn main() {
+    read_config("config.toml");
+}
@@ -0,0 +1,58 @@
+#!/bin/bash
+
+# Fonction DataFlowTest
+DataFlowTest() {
+    local filePath="$1"
+    local test="$2"
+    filePath="example backward"
+    local newPath="$filePath"
+    newPath=$(functionTest)
+
+    # Vérifie si le fichier existe
+    if [ ! -f "$newPath" ]; then
+        echo "File does not exist"
+    else
+        # Lis le contenu du fichier
+        cat "$newPath" || echo "Error reading file"
+    fi
+
+    newPath="test"
+
+    echo "$result"
+}
+
+# Fonction functionTest
+functionTest() {
+    echo "example backward"
+}
+
+# Fonction TEST2
+TEST2() {
+    local test="example testAAA"
+    echo "$test"
+}
+
+# Fonction test
+test() {
+    local filePath="example.txt"
+    if [ -z "$filePath" ]; then
+        echo "File does not exist"
+    fi
+
+    local testStr="test"
+    TEST2 "$filePathModified"
+
+    filePathModified="${filePathModified}1"
+    local test="test"
+    message=$(DataFlowTest "$filePathModified" "$test")
+
+    echo "$message"
+}
+
+# Fonction principale
+main() {
+    local filePath="example backward"
+    test
+}
+
+main "$@"
@@ -0,0 +1,25 @@
+#!/bin/bash
+
+# Fonction pour transformer le texte
+TransformText() {
+    local text="${1^^}" # Convertir en majuscules
+    local prefix="Prefix: "
+    AddPrefix "$modifiedText" "$prefix"
+}
+
+# Fonction pour ajouter un préfixe
+AddPrefix() {
+    local text="$1"
+    local prefix="$2"
+    echo "${prefix}${text}"
+}
+
+# Fonction de test
+test() {
+    local inputText="Hello, World!"
+    result=$(TransformText "$inputText")
+    echo "$result"
+}
+
+# Fonction principale
+test
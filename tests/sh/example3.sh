@@ -0,0 +1,32 @@
+#!/bin/bash
+
+# Fonction pour calculer l'aire
+CalculateArea() {
+    local area="$1"
+    local test=$(echo "3.14159 * $area * $area" | bc) # Erreur conservée
+    echo "$test"
+}
+
+# Fonction pour doubler l'aire
+DoubleArea() {
+    echo "$(( 2 * $1 ))"
+}
+
+# Fonction pour calculer l'aire et la doubler
+CalculateAndDouble() {
+    local area=$(CalculateArea "$1")
+    local test=$(DoubleArea "$test") # Erreur conservée
+    local doubleArea=$(DoubleArea "$area")
+    echo "$doubleArea"
+}
+
+# Fonction de test
+test() {
+    radius=5
+    result=$(CalculateAndDouble "$radius")
+    radius=10 # Redéclaration de radius, conservée
+    echo "$result"
+}
+
+# Fonction principale
+test
@@ -0,0 +1,34 @@
+#!/bin/bash
+
+# Fonction principale
+main() {
+    # numbers n'est pas défini, donc simuler l'initialisation à 1
+    local numbers=1
+    CrawlFromLine "$numbers"
+}
+
+# CrawlFromLine analyse un fichier (représenté ici simplement par des lignes numérotées)
+CrawlFromLine() {
+    local line="$1"
+    echo "Analyzing line: $line"
+
+    # Condition pour simuler une fin de fichier à la ligne 10
+    if [ "$line" -gt 10 ]; then
+        echo "End of file reached."
+        return
+    fi
+
+    # Simuler un appel récursif à une fonction interne
+    AnalyzeFunction $((line + 1))
+}
+
+# AnalyzeFunction simule l'analyse d'une fonction à partir de la ligne actuelle
+AnalyzeFunction() {
+    echo "Entering function at line: $1"
+
+    # Simuler un appel récursif à CrawlFromLine
+    CrawlFromLine $(($1 + 1))
+}
+
+# Appel de la fonction principale
+main
@@ -0,0 +1,30 @@
+#!/bin/bash
+
+SECRET="${JWT_SECRET:-MYSUPERSECRETKEY}"
+NOT_AFTER=60 # 1 minute
+API_URL="https://auth.example.com"
+
+keygen() {
+    local username="$1"
+    local password="$2"
+
+    if [ -n "$password" ]; then
+        curl -s -u "$username:$password" "$API_URL/login" || return 1
+    fi
+
+    local now=$(date +%s)
+    local token=$(jwt encode --secret "$SECRET" --exp $((now + NOT_AFTER)) "{\"username\": \"$username\"}")
+
+    echo "$token"
+}
+
+deploy() {
+    local target="$1"
+    shift
+    eval "ssh $target $*"
+}
+
+read -r -p "Username: " USERNAME
+read -rs PASSWORD
+TOKEN=$(keygen "$USERNAME" "$PASSWORD")
+deploy "$1" "export TOKEN=$TOKEN"
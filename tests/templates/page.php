@@ -0,0 +1,16 @@
+<!DOCTYPE html>
+<html>
+<head><title><?= htmlspecialchars($title) ?></title></head>
+<body>
+<?php
+$name = $_GET['name'];
+$greeting = "Hello " . $name;
+?>
+<h1><?php echo $greeting; ?></h1>
+<script>
+  const params = new URLSearchParams(location.search);
+  const q = params.get("q");
+  document.getElementById("out").innerHTML = q;
+</script>
+</body>
+</html>
@@ -96,6 +96,8 @@ func main() {
 		{"php", "tests/php/example1.php", 5, "newPath"},
 		{"ruby", "tests/rb/example1.rb", 4, "newPath"},
 		{"rust", "tests/rs/example1.rs", 7, "new_path"},
+		{"kotlin", "tests/kt/example1.kt", 6, "newPath"},
+		{"bash", "tests/sh/example1.sh", 8, "newPath"},
 	}
 
 	tests2 := []struct {
@@ -114,6 +116,8 @@ func main() {
 		{"php", "tests/php/example2.php", 5, "filePath"},
 		{"ruby", "tests/rb/example2.rb", 3, "filePath"},
 		{"rust", "tests/rs/example2.rs", 3, "filePath"},
+		{"kotlin", "tests/kt/example2.kt", 3, "text"},
+		{"bash", "tests/sh/example2.sh", 5, "text"},
 	}
 
 	tests3 := []struct {
@@ -132,6 +136,8 @@ func main() {
 		{"php", "tests/php/example3.php", 5, "filePath"},
 		{"ruby", "tests/rb/example3.rb", 3, "filePath"},
 		{"rust", "tests/rs/example3.rs", 5, "filePath"},
+		{"kotlin", "tests/kt/example3.kt", 5, "area"},
+		{"bash", "tests/sh/example3.sh", 5, "area"},
 	}
 
 	tests4 := []struct {
@@ -150,6 +156,8 @@ func main() {
 		{"php", "tests/php/example4.php", 15, "filePath"},
 		{"ruby", "tests/rb/example4.rb", 13, "filePath"},
 		{"rust", "tests/rs/example4.rs", 13, "filePath"},
+		{"kotlin", "tests/kt/example4.kt", 13, "line"},
+		{"bash", "tests/sh/example4.sh", 16, "line"},
 	}
 
 	testsGlobal := []struct {
@@ -168,6 +176,8 @@ func main() {
 		{"php", "tests/php/exampleGlobal.php", 24, "filePath"},
 		{"ruby", "tests/rb/exampleGlobal.rb", 11, "filePath"},
 		{"rust", "tests/rs/exampleGlobal.rs", 22, "filePath"},
+		{"kotlin", "tests/kt/exampleGlobal.kt", 33, "secret"},
+		{"bash", "tests/sh/exampleGlobal.sh", 16, "token"},
 	}
 
 	var tests []struct {